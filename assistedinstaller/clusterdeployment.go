@@ -1,7 +1,7 @@
 package assistedinstaller
 
 import (
-	"github.com/openshift-assisted/cluster-api-agent/controlplane/api/v1alpha2"
+	controlplanev1beta1 "github.com/openshift-assisted/cluster-api-agent/controlplane/api/v1beta1"
 	"github.com/openshift-assisted/cluster-api-agent/util"
 	hiveext "github.com/openshift/assisted-service/api/hiveextension/v1beta1"
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
@@ -10,7 +10,7 @@ import (
 )
 
 func GetClusterDeploymentFromConfig(
-	acp *v1alpha2.OpenshiftAssistedControlPlane,
+	acp *controlplanev1beta1.OpenshiftAssistedControlPlane,
 	clusterName string,
 ) *hivev1.ClusterDeployment {
 	assistedClusterName := clusterName
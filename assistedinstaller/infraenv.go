@@ -4,7 +4,7 @@ import (
 	"fmt"
 	"net/url"
 
-	bootstrapv1alpha1 "github.com/openshift-assisted/cluster-api-agent/bootstrap/api/v1alpha1"
+	bootstrapv1beta1 "github.com/openshift-assisted/cluster-api-agent/bootstrap/api/v1beta1"
 	aiv1beta1 "github.com/openshift/assisted-service/api/v1beta1"
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -14,7 +14,7 @@ import (
 
 func GetInfraEnvFromConfig(
 	infraEnvName string,
-	config *bootstrapv1alpha1.OpenshiftAssistedConfig,
+	config *bootstrapv1beta1.OpenshiftAssistedConfig,
 	clusterDeployment *hivev1.ClusterDeployment,
 ) *aiv1beta1.InfraEnv {
 	infraEnv := &aiv1beta1.InfraEnv{
@@ -22,7 +22,7 @@ func GetInfraEnvFromConfig(
 			Name:      infraEnvName,
 			Namespace: config.Namespace,
 			Labels: map[string]string{
-				bootstrapv1alpha1.OpenshiftAssistedConfigLabel: config.Name,
+				bootstrapv1beta1.OpenshiftAssistedConfigLabel: config.Name,
 			},
 		},
 	}
@@ -0,0 +1,119 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	bootstrapv1beta1 "github.com/openshift-assisted/cluster-api-agent/bootstrap/api/v1beta1"
+	apiconversion "k8s.io/apimachinery/pkg/conversion"
+	utilconversion "sigs.k8s.io/cluster-api/util/conversion"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// ConvertTo converts this OpenshiftAssistedConfig to the Hub version (v1beta1).
+func (src *OpenshiftAssistedConfig) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*bootstrapv1beta1.OpenshiftAssistedConfig)
+	if err := Convert_v1alpha1_OpenshiftAssistedConfig_To_v1beta1_OpenshiftAssistedConfig(src, dst, nil); err != nil {
+		return err
+	}
+
+	// Restore the fields that only exist in the Hub version
+	restored := &bootstrapv1beta1.OpenshiftAssistedConfig{}
+	if ok, err := utilconversion.UnmarshalData(src, restored); err != nil || !ok {
+		return err
+	}
+	dst.Spec.BootstrapMode = restored.Spec.BootstrapMode
+	dst.Spec.MachineConfigPool = restored.Spec.MachineConfigPool
+	dst.Status.Initialization = restored.Status.Initialization
+	dst.Status.V1Beta2 = restored.Status.V1Beta2
+	dst.Status.InstallationProgress = restored.Status.InstallationProgress
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1beta1) to this version.
+func (dst *OpenshiftAssistedConfig) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*bootstrapv1beta1.OpenshiftAssistedConfig)
+	if err := Convert_v1beta1_OpenshiftAssistedConfig_To_v1alpha1_OpenshiftAssistedConfig(src, dst, nil); err != nil {
+		return err
+	}
+
+	// Preserve the Hub data in an annotation, so it is not lost on a round trip
+	return utilconversion.MarshalData(src, dst)
+}
+
+// ConvertTo converts this OpenshiftAssistedConfigList to the Hub version (v1beta1).
+func (src *OpenshiftAssistedConfigList) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*bootstrapv1beta1.OpenshiftAssistedConfigList)
+	return Convert_v1alpha1_OpenshiftAssistedConfigList_To_v1beta1_OpenshiftAssistedConfigList(src, dst, nil)
+}
+
+// ConvertFrom converts from the Hub version (v1beta1) to this version.
+func (dst *OpenshiftAssistedConfigList) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*bootstrapv1beta1.OpenshiftAssistedConfigList)
+	return Convert_v1beta1_OpenshiftAssistedConfigList_To_v1alpha1_OpenshiftAssistedConfigList(src, dst, nil)
+}
+
+// ConvertTo converts this OpenshiftAssistedConfigTemplate to the Hub version (v1beta1).
+func (src *OpenshiftAssistedConfigTemplate) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*bootstrapv1beta1.OpenshiftAssistedConfigTemplate)
+	if err := Convert_v1alpha1_OpenshiftAssistedConfigTemplate_To_v1beta1_OpenshiftAssistedConfigTemplate(src, dst, nil); err != nil {
+		return err
+	}
+
+	// Restore the fields that only exist in the Hub version
+	restored := &bootstrapv1beta1.OpenshiftAssistedConfigTemplate{}
+	if ok, err := utilconversion.UnmarshalData(src, restored); err != nil || !ok {
+		return err
+	}
+	dst.Spec.Template.Spec.BootstrapMode = restored.Spec.Template.Spec.BootstrapMode
+	dst.Spec.Template.Spec.MachineConfigPool = restored.Spec.Template.Spec.MachineConfigPool
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1beta1) to this version.
+func (dst *OpenshiftAssistedConfigTemplate) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*bootstrapv1beta1.OpenshiftAssistedConfigTemplate)
+	if err := Convert_v1beta1_OpenshiftAssistedConfigTemplate_To_v1alpha1_OpenshiftAssistedConfigTemplate(src, dst, nil); err != nil {
+		return err
+	}
+
+	// Preserve the Hub data in an annotation, so it is not lost on a round trip
+	return utilconversion.MarshalData(src, dst)
+}
+
+// ConvertTo converts this OpenshiftAssistedConfigTemplateList to the Hub version (v1beta1).
+func (src *OpenshiftAssistedConfigTemplateList) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*bootstrapv1beta1.OpenshiftAssistedConfigTemplateList)
+	return Convert_v1alpha1_OpenshiftAssistedConfigTemplateList_To_v1beta1_OpenshiftAssistedConfigTemplateList(src, dst, nil)
+}
+
+// ConvertFrom converts from the Hub version (v1beta1) to this version.
+func (dst *OpenshiftAssistedConfigTemplateList) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*bootstrapv1beta1.OpenshiftAssistedConfigTemplateList)
+	return Convert_v1beta1_OpenshiftAssistedConfigTemplateList_To_v1alpha1_OpenshiftAssistedConfigTemplateList(src, dst, nil)
+}
+
+// Convert_v1beta1_OpenshiftAssistedConfigSpec_To_v1alpha1_OpenshiftAssistedConfigSpec drops the bootstrap mode fields,
+// which are preserved in the conversion data annotation by ConvertFrom.
+func Convert_v1beta1_OpenshiftAssistedConfigSpec_To_v1alpha1_OpenshiftAssistedConfigSpec(in *bootstrapv1beta1.OpenshiftAssistedConfigSpec, out *OpenshiftAssistedConfigSpec, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_OpenshiftAssistedConfigSpec_To_v1alpha1_OpenshiftAssistedConfigSpec(in, out, s)
+}
+
+// Convert_v1beta1_OpenshiftAssistedConfigStatus_To_v1alpha1_OpenshiftAssistedConfigStatus drops the initialization
+// status and the v1beta2 conditions, which are preserved in the conversion data annotation by ConvertFrom.
+func Convert_v1beta1_OpenshiftAssistedConfigStatus_To_v1alpha1_OpenshiftAssistedConfigStatus(in *bootstrapv1beta1.OpenshiftAssistedConfigStatus, out *OpenshiftAssistedConfigStatus, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_OpenshiftAssistedConfigStatus_To_v1alpha1_OpenshiftAssistedConfigStatus(in, out, s)
+}
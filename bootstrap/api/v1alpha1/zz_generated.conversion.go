@@ -0,0 +1,453 @@
+//go:build !ignore_autogenerated_bootstrap
+// +build !ignore_autogenerated_bootstrap
+
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by conversion-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	unsafe "unsafe"
+
+	v1beta1 "github.com/openshift-assisted/cluster-api-agent/bootstrap/api/v1beta1"
+	apiv1beta1 "github.com/openshift/assisted-service/api/v1beta1"
+	v1 "k8s.io/api/core/v1"
+	conversion "k8s.io/apimachinery/pkg/conversion"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	clusterapiapiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func init() {
+	localSchemeBuilder.Register(RegisterConversions)
+}
+
+// RegisterConversions adds conversion functions to the given scheme.
+// Public to allow building arbitrary schemes.
+func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*NodeRegistrationOptions)(nil), (*v1beta1.NodeRegistrationOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_NodeRegistrationOptions_To_v1beta1_NodeRegistrationOptions(a.(*NodeRegistrationOptions), b.(*v1beta1.NodeRegistrationOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.NodeRegistrationOptions)(nil), (*NodeRegistrationOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_NodeRegistrationOptions_To_v1alpha1_NodeRegistrationOptions(a.(*v1beta1.NodeRegistrationOptions), b.(*NodeRegistrationOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*OpenshiftAssistedConfig)(nil), (*v1beta1.OpenshiftAssistedConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_OpenshiftAssistedConfig_To_v1beta1_OpenshiftAssistedConfig(a.(*OpenshiftAssistedConfig), b.(*v1beta1.OpenshiftAssistedConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.OpenshiftAssistedConfig)(nil), (*OpenshiftAssistedConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_OpenshiftAssistedConfig_To_v1alpha1_OpenshiftAssistedConfig(a.(*v1beta1.OpenshiftAssistedConfig), b.(*OpenshiftAssistedConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*OpenshiftAssistedConfigList)(nil), (*v1beta1.OpenshiftAssistedConfigList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_OpenshiftAssistedConfigList_To_v1beta1_OpenshiftAssistedConfigList(a.(*OpenshiftAssistedConfigList), b.(*v1beta1.OpenshiftAssistedConfigList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.OpenshiftAssistedConfigList)(nil), (*OpenshiftAssistedConfigList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_OpenshiftAssistedConfigList_To_v1alpha1_OpenshiftAssistedConfigList(a.(*v1beta1.OpenshiftAssistedConfigList), b.(*OpenshiftAssistedConfigList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*OpenshiftAssistedConfigSpec)(nil), (*v1beta1.OpenshiftAssistedConfigSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_OpenshiftAssistedConfigSpec_To_v1beta1_OpenshiftAssistedConfigSpec(a.(*OpenshiftAssistedConfigSpec), b.(*v1beta1.OpenshiftAssistedConfigSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*OpenshiftAssistedConfigStatus)(nil), (*v1beta1.OpenshiftAssistedConfigStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_OpenshiftAssistedConfigStatus_To_v1beta1_OpenshiftAssistedConfigStatus(a.(*OpenshiftAssistedConfigStatus), b.(*v1beta1.OpenshiftAssistedConfigStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*OpenshiftAssistedConfigTemplate)(nil), (*v1beta1.OpenshiftAssistedConfigTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_OpenshiftAssistedConfigTemplate_To_v1beta1_OpenshiftAssistedConfigTemplate(a.(*OpenshiftAssistedConfigTemplate), b.(*v1beta1.OpenshiftAssistedConfigTemplate), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.OpenshiftAssistedConfigTemplate)(nil), (*OpenshiftAssistedConfigTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_OpenshiftAssistedConfigTemplate_To_v1alpha1_OpenshiftAssistedConfigTemplate(a.(*v1beta1.OpenshiftAssistedConfigTemplate), b.(*OpenshiftAssistedConfigTemplate), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*OpenshiftAssistedConfigTemplateList)(nil), (*v1beta1.OpenshiftAssistedConfigTemplateList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_OpenshiftAssistedConfigTemplateList_To_v1beta1_OpenshiftAssistedConfigTemplateList(a.(*OpenshiftAssistedConfigTemplateList), b.(*v1beta1.OpenshiftAssistedConfigTemplateList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.OpenshiftAssistedConfigTemplateList)(nil), (*OpenshiftAssistedConfigTemplateList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_OpenshiftAssistedConfigTemplateList_To_v1alpha1_OpenshiftAssistedConfigTemplateList(a.(*v1beta1.OpenshiftAssistedConfigTemplateList), b.(*OpenshiftAssistedConfigTemplateList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*OpenshiftAssistedConfigTemplateResource)(nil), (*v1beta1.OpenshiftAssistedConfigTemplateResource)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_OpenshiftAssistedConfigTemplateResource_To_v1beta1_OpenshiftAssistedConfigTemplateResource(a.(*OpenshiftAssistedConfigTemplateResource), b.(*v1beta1.OpenshiftAssistedConfigTemplateResource), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.OpenshiftAssistedConfigTemplateResource)(nil), (*OpenshiftAssistedConfigTemplateResource)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_OpenshiftAssistedConfigTemplateResource_To_v1alpha1_OpenshiftAssistedConfigTemplateResource(a.(*v1beta1.OpenshiftAssistedConfigTemplateResource), b.(*OpenshiftAssistedConfigTemplateResource), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*OpenshiftAssistedConfigTemplateSpec)(nil), (*v1beta1.OpenshiftAssistedConfigTemplateSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_OpenshiftAssistedConfigTemplateSpec_To_v1beta1_OpenshiftAssistedConfigTemplateSpec(a.(*OpenshiftAssistedConfigTemplateSpec), b.(*v1beta1.OpenshiftAssistedConfigTemplateSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.OpenshiftAssistedConfigTemplateSpec)(nil), (*OpenshiftAssistedConfigTemplateSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_OpenshiftAssistedConfigTemplateSpec_To_v1alpha1_OpenshiftAssistedConfigTemplateSpec(a.(*v1beta1.OpenshiftAssistedConfigTemplateSpec), b.(*OpenshiftAssistedConfigTemplateSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*OpenshiftAssistedConfigTemplateStatus)(nil), (*v1beta1.OpenshiftAssistedConfigTemplateStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_OpenshiftAssistedConfigTemplateStatus_To_v1beta1_OpenshiftAssistedConfigTemplateStatus(a.(*OpenshiftAssistedConfigTemplateStatus), b.(*v1beta1.OpenshiftAssistedConfigTemplateStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.OpenshiftAssistedConfigTemplateStatus)(nil), (*OpenshiftAssistedConfigTemplateStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_OpenshiftAssistedConfigTemplateStatus_To_v1alpha1_OpenshiftAssistedConfigTemplateStatus(a.(*v1beta1.OpenshiftAssistedConfigTemplateStatus), b.(*OpenshiftAssistedConfigTemplateStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1beta1.OpenshiftAssistedConfigSpec)(nil), (*OpenshiftAssistedConfigSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_OpenshiftAssistedConfigSpec_To_v1alpha1_OpenshiftAssistedConfigSpec(a.(*v1beta1.OpenshiftAssistedConfigSpec), b.(*OpenshiftAssistedConfigSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1beta1.OpenshiftAssistedConfigStatus)(nil), (*OpenshiftAssistedConfigStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_OpenshiftAssistedConfigStatus_To_v1alpha1_OpenshiftAssistedConfigStatus(a.(*v1beta1.OpenshiftAssistedConfigStatus), b.(*OpenshiftAssistedConfigStatus), scope)
+	}); err != nil {
+		return err
+	}
+	return nil
+}
+
+func autoConvert_v1alpha1_NodeRegistrationOptions_To_v1beta1_NodeRegistrationOptions(in *NodeRegistrationOptions, out *v1beta1.NodeRegistrationOptions, s conversion.Scope) error {
+	out.Name = in.Name
+	out.KubeletExtraLabels = *(*[]string)(unsafe.Pointer(&in.KubeletExtraLabels))
+	return nil
+}
+
+// Convert_v1alpha1_NodeRegistrationOptions_To_v1beta1_NodeRegistrationOptions is an autogenerated conversion function.
+func Convert_v1alpha1_NodeRegistrationOptions_To_v1beta1_NodeRegistrationOptions(in *NodeRegistrationOptions, out *v1beta1.NodeRegistrationOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_NodeRegistrationOptions_To_v1beta1_NodeRegistrationOptions(in, out, s)
+}
+
+func autoConvert_v1beta1_NodeRegistrationOptions_To_v1alpha1_NodeRegistrationOptions(in *v1beta1.NodeRegistrationOptions, out *NodeRegistrationOptions, s conversion.Scope) error {
+	out.Name = in.Name
+	out.KubeletExtraLabels = *(*[]string)(unsafe.Pointer(&in.KubeletExtraLabels))
+	return nil
+}
+
+// Convert_v1beta1_NodeRegistrationOptions_To_v1alpha1_NodeRegistrationOptions is an autogenerated conversion function.
+func Convert_v1beta1_NodeRegistrationOptions_To_v1alpha1_NodeRegistrationOptions(in *v1beta1.NodeRegistrationOptions, out *NodeRegistrationOptions, s conversion.Scope) error {
+	return autoConvert_v1beta1_NodeRegistrationOptions_To_v1alpha1_NodeRegistrationOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_OpenshiftAssistedConfig_To_v1beta1_OpenshiftAssistedConfig(in *OpenshiftAssistedConfig, out *v1beta1.OpenshiftAssistedConfig, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1alpha1_OpenshiftAssistedConfigSpec_To_v1beta1_OpenshiftAssistedConfigSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_OpenshiftAssistedConfigStatus_To_v1beta1_OpenshiftAssistedConfigStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha1_OpenshiftAssistedConfig_To_v1beta1_OpenshiftAssistedConfig is an autogenerated conversion function.
+func Convert_v1alpha1_OpenshiftAssistedConfig_To_v1beta1_OpenshiftAssistedConfig(in *OpenshiftAssistedConfig, out *v1beta1.OpenshiftAssistedConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha1_OpenshiftAssistedConfig_To_v1beta1_OpenshiftAssistedConfig(in, out, s)
+}
+
+func autoConvert_v1beta1_OpenshiftAssistedConfig_To_v1alpha1_OpenshiftAssistedConfig(in *v1beta1.OpenshiftAssistedConfig, out *OpenshiftAssistedConfig, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1beta1_OpenshiftAssistedConfigSpec_To_v1alpha1_OpenshiftAssistedConfigSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_v1beta1_OpenshiftAssistedConfigStatus_To_v1alpha1_OpenshiftAssistedConfigStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_OpenshiftAssistedConfig_To_v1alpha1_OpenshiftAssistedConfig is an autogenerated conversion function.
+func Convert_v1beta1_OpenshiftAssistedConfig_To_v1alpha1_OpenshiftAssistedConfig(in *v1beta1.OpenshiftAssistedConfig, out *OpenshiftAssistedConfig, s conversion.Scope) error {
+	return autoConvert_v1beta1_OpenshiftAssistedConfig_To_v1alpha1_OpenshiftAssistedConfig(in, out, s)
+}
+
+func autoConvert_v1alpha1_OpenshiftAssistedConfigList_To_v1beta1_OpenshiftAssistedConfigList(in *OpenshiftAssistedConfigList, out *v1beta1.OpenshiftAssistedConfigList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]v1beta1.OpenshiftAssistedConfig, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha1_OpenshiftAssistedConfig_To_v1beta1_OpenshiftAssistedConfig(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha1_OpenshiftAssistedConfigList_To_v1beta1_OpenshiftAssistedConfigList is an autogenerated conversion function.
+func Convert_v1alpha1_OpenshiftAssistedConfigList_To_v1beta1_OpenshiftAssistedConfigList(in *OpenshiftAssistedConfigList, out *v1beta1.OpenshiftAssistedConfigList, s conversion.Scope) error {
+	return autoConvert_v1alpha1_OpenshiftAssistedConfigList_To_v1beta1_OpenshiftAssistedConfigList(in, out, s)
+}
+
+func autoConvert_v1beta1_OpenshiftAssistedConfigList_To_v1alpha1_OpenshiftAssistedConfigList(in *v1beta1.OpenshiftAssistedConfigList, out *OpenshiftAssistedConfigList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OpenshiftAssistedConfig, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_OpenshiftAssistedConfig_To_v1alpha1_OpenshiftAssistedConfig(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+// Convert_v1beta1_OpenshiftAssistedConfigList_To_v1alpha1_OpenshiftAssistedConfigList is an autogenerated conversion function.
+func Convert_v1beta1_OpenshiftAssistedConfigList_To_v1alpha1_OpenshiftAssistedConfigList(in *v1beta1.OpenshiftAssistedConfigList, out *OpenshiftAssistedConfigList, s conversion.Scope) error {
+	return autoConvert_v1beta1_OpenshiftAssistedConfigList_To_v1alpha1_OpenshiftAssistedConfigList(in, out, s)
+}
+
+func autoConvert_v1alpha1_OpenshiftAssistedConfigSpec_To_v1beta1_OpenshiftAssistedConfigSpec(in *OpenshiftAssistedConfigSpec, out *v1beta1.OpenshiftAssistedConfigSpec, s conversion.Scope) error {
+	out.Proxy = (*apiv1beta1.Proxy)(unsafe.Pointer(in.Proxy))
+	out.PullSecretRef = (*v1.LocalObjectReference)(unsafe.Pointer(in.PullSecretRef))
+	out.AdditionalNTPSources = *(*[]string)(unsafe.Pointer(&in.AdditionalNTPSources))
+	out.SSHAuthorizedKey = in.SSHAuthorizedKey
+	out.NMStateConfigLabelSelector = in.NMStateConfigLabelSelector
+	out.CpuArchitecture = in.CpuArchitecture
+	out.KernelArguments = *(*[]apiv1beta1.KernelArgument)(unsafe.Pointer(&in.KernelArguments))
+	out.AdditionalTrustBundle = in.AdditionalTrustBundle
+	out.OSImageVersion = in.OSImageVersion
+	if err := Convert_v1alpha1_NodeRegistrationOptions_To_v1beta1_NodeRegistrationOptions(&in.NodeRegistration, &out.NodeRegistration, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha1_OpenshiftAssistedConfigSpec_To_v1beta1_OpenshiftAssistedConfigSpec is an autogenerated conversion function.
+func Convert_v1alpha1_OpenshiftAssistedConfigSpec_To_v1beta1_OpenshiftAssistedConfigSpec(in *OpenshiftAssistedConfigSpec, out *v1beta1.OpenshiftAssistedConfigSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha1_OpenshiftAssistedConfigSpec_To_v1beta1_OpenshiftAssistedConfigSpec(in, out, s)
+}
+
+func autoConvert_v1beta1_OpenshiftAssistedConfigSpec_To_v1alpha1_OpenshiftAssistedConfigSpec(in *v1beta1.OpenshiftAssistedConfigSpec, out *OpenshiftAssistedConfigSpec, s conversion.Scope) error {
+	out.Proxy = (*apiv1beta1.Proxy)(unsafe.Pointer(in.Proxy))
+	out.PullSecretRef = (*v1.LocalObjectReference)(unsafe.Pointer(in.PullSecretRef))
+	out.AdditionalNTPSources = *(*[]string)(unsafe.Pointer(&in.AdditionalNTPSources))
+	out.SSHAuthorizedKey = in.SSHAuthorizedKey
+	out.NMStateConfigLabelSelector = in.NMStateConfigLabelSelector
+	out.CpuArchitecture = in.CpuArchitecture
+	out.KernelArguments = *(*[]apiv1beta1.KernelArgument)(unsafe.Pointer(&in.KernelArguments))
+	out.AdditionalTrustBundle = in.AdditionalTrustBundle
+	out.OSImageVersion = in.OSImageVersion
+	if err := Convert_v1beta1_NodeRegistrationOptions_To_v1alpha1_NodeRegistrationOptions(&in.NodeRegistration, &out.NodeRegistration, s); err != nil {
+		return err
+	}
+	// WARNING: in.BootstrapMode requires manual conversion: does not exist in peer-type
+	// WARNING: in.MachineConfigPool requires manual conversion: does not exist in peer-type
+	return nil
+}
+
+func autoConvert_v1alpha1_OpenshiftAssistedConfigStatus_To_v1beta1_OpenshiftAssistedConfigStatus(in *OpenshiftAssistedConfigStatus, out *v1beta1.OpenshiftAssistedConfigStatus, s conversion.Scope) error {
+	out.InfraEnvRef = (*v1.ObjectReference)(unsafe.Pointer(in.InfraEnvRef))
+	out.AgentRef = (*v1.LocalObjectReference)(unsafe.Pointer(in.AgentRef))
+	out.ISODownloadURL = in.ISODownloadURL
+	out.Ready = in.Ready
+	out.DataSecretName = (*string)(unsafe.Pointer(in.DataSecretName))
+	out.FailureReason = in.FailureReason
+	out.FailureMessage = in.FailureMessage
+	out.ObservedGeneration = in.ObservedGeneration
+	out.Conditions = *(*clusterapiapiv1beta1.Conditions)(unsafe.Pointer(&in.Conditions))
+	return nil
+}
+
+// Convert_v1alpha1_OpenshiftAssistedConfigStatus_To_v1beta1_OpenshiftAssistedConfigStatus is an autogenerated conversion function.
+func Convert_v1alpha1_OpenshiftAssistedConfigStatus_To_v1beta1_OpenshiftAssistedConfigStatus(in *OpenshiftAssistedConfigStatus, out *v1beta1.OpenshiftAssistedConfigStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_OpenshiftAssistedConfigStatus_To_v1beta1_OpenshiftAssistedConfigStatus(in, out, s)
+}
+
+func autoConvert_v1beta1_OpenshiftAssistedConfigStatus_To_v1alpha1_OpenshiftAssistedConfigStatus(in *v1beta1.OpenshiftAssistedConfigStatus, out *OpenshiftAssistedConfigStatus, s conversion.Scope) error {
+	out.InfraEnvRef = (*v1.ObjectReference)(unsafe.Pointer(in.InfraEnvRef))
+	out.AgentRef = (*v1.LocalObjectReference)(unsafe.Pointer(in.AgentRef))
+	out.ISODownloadURL = in.ISODownloadURL
+	out.Ready = in.Ready
+	out.DataSecretName = (*string)(unsafe.Pointer(in.DataSecretName))
+	out.FailureReason = in.FailureReason
+	out.FailureMessage = in.FailureMessage
+	out.ObservedGeneration = in.ObservedGeneration
+	out.Conditions = *(*clusterapiapiv1beta1.Conditions)(unsafe.Pointer(&in.Conditions))
+	// WARNING: in.InstallationProgress requires manual conversion: does not exist in peer-type
+	// WARNING: in.Initialization requires manual conversion: does not exist in peer-type
+	// WARNING: in.V1Beta2 requires manual conversion: does not exist in peer-type
+	return nil
+}
+
+func autoConvert_v1alpha1_OpenshiftAssistedConfigTemplate_To_v1beta1_OpenshiftAssistedConfigTemplate(in *OpenshiftAssistedConfigTemplate, out *v1beta1.OpenshiftAssistedConfigTemplate, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1alpha1_OpenshiftAssistedConfigTemplateSpec_To_v1beta1_OpenshiftAssistedConfigTemplateSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_OpenshiftAssistedConfigTemplateStatus_To_v1beta1_OpenshiftAssistedConfigTemplateStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha1_OpenshiftAssistedConfigTemplate_To_v1beta1_OpenshiftAssistedConfigTemplate is an autogenerated conversion function.
+func Convert_v1alpha1_OpenshiftAssistedConfigTemplate_To_v1beta1_OpenshiftAssistedConfigTemplate(in *OpenshiftAssistedConfigTemplate, out *v1beta1.OpenshiftAssistedConfigTemplate, s conversion.Scope) error {
+	return autoConvert_v1alpha1_OpenshiftAssistedConfigTemplate_To_v1beta1_OpenshiftAssistedConfigTemplate(in, out, s)
+}
+
+func autoConvert_v1beta1_OpenshiftAssistedConfigTemplate_To_v1alpha1_OpenshiftAssistedConfigTemplate(in *v1beta1.OpenshiftAssistedConfigTemplate, out *OpenshiftAssistedConfigTemplate, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1beta1_OpenshiftAssistedConfigTemplateSpec_To_v1alpha1_OpenshiftAssistedConfigTemplateSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_v1beta1_OpenshiftAssistedConfigTemplateStatus_To_v1alpha1_OpenshiftAssistedConfigTemplateStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_OpenshiftAssistedConfigTemplate_To_v1alpha1_OpenshiftAssistedConfigTemplate is an autogenerated conversion function.
+func Convert_v1beta1_OpenshiftAssistedConfigTemplate_To_v1alpha1_OpenshiftAssistedConfigTemplate(in *v1beta1.OpenshiftAssistedConfigTemplate, out *OpenshiftAssistedConfigTemplate, s conversion.Scope) error {
+	return autoConvert_v1beta1_OpenshiftAssistedConfigTemplate_To_v1alpha1_OpenshiftAssistedConfigTemplate(in, out, s)
+}
+
+func autoConvert_v1alpha1_OpenshiftAssistedConfigTemplateList_To_v1beta1_OpenshiftAssistedConfigTemplateList(in *OpenshiftAssistedConfigTemplateList, out *v1beta1.OpenshiftAssistedConfigTemplateList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]v1beta1.OpenshiftAssistedConfigTemplate, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha1_OpenshiftAssistedConfigTemplate_To_v1beta1_OpenshiftAssistedConfigTemplate(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha1_OpenshiftAssistedConfigTemplateList_To_v1beta1_OpenshiftAssistedConfigTemplateList is an autogenerated conversion function.
+func Convert_v1alpha1_OpenshiftAssistedConfigTemplateList_To_v1beta1_OpenshiftAssistedConfigTemplateList(in *OpenshiftAssistedConfigTemplateList, out *v1beta1.OpenshiftAssistedConfigTemplateList, s conversion.Scope) error {
+	return autoConvert_v1alpha1_OpenshiftAssistedConfigTemplateList_To_v1beta1_OpenshiftAssistedConfigTemplateList(in, out, s)
+}
+
+func autoConvert_v1beta1_OpenshiftAssistedConfigTemplateList_To_v1alpha1_OpenshiftAssistedConfigTemplateList(in *v1beta1.OpenshiftAssistedConfigTemplateList, out *OpenshiftAssistedConfigTemplateList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OpenshiftAssistedConfigTemplate, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_OpenshiftAssistedConfigTemplate_To_v1alpha1_OpenshiftAssistedConfigTemplate(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+// Convert_v1beta1_OpenshiftAssistedConfigTemplateList_To_v1alpha1_OpenshiftAssistedConfigTemplateList is an autogenerated conversion function.
+func Convert_v1beta1_OpenshiftAssistedConfigTemplateList_To_v1alpha1_OpenshiftAssistedConfigTemplateList(in *v1beta1.OpenshiftAssistedConfigTemplateList, out *OpenshiftAssistedConfigTemplateList, s conversion.Scope) error {
+	return autoConvert_v1beta1_OpenshiftAssistedConfigTemplateList_To_v1alpha1_OpenshiftAssistedConfigTemplateList(in, out, s)
+}
+
+func autoConvert_v1alpha1_OpenshiftAssistedConfigTemplateResource_To_v1beta1_OpenshiftAssistedConfigTemplateResource(in *OpenshiftAssistedConfigTemplateResource, out *v1beta1.OpenshiftAssistedConfigTemplateResource, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1alpha1_OpenshiftAssistedConfigSpec_To_v1beta1_OpenshiftAssistedConfigSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha1_OpenshiftAssistedConfigTemplateResource_To_v1beta1_OpenshiftAssistedConfigTemplateResource is an autogenerated conversion function.
+func Convert_v1alpha1_OpenshiftAssistedConfigTemplateResource_To_v1beta1_OpenshiftAssistedConfigTemplateResource(in *OpenshiftAssistedConfigTemplateResource, out *v1beta1.OpenshiftAssistedConfigTemplateResource, s conversion.Scope) error {
+	return autoConvert_v1alpha1_OpenshiftAssistedConfigTemplateResource_To_v1beta1_OpenshiftAssistedConfigTemplateResource(in, out, s)
+}
+
+func autoConvert_v1beta1_OpenshiftAssistedConfigTemplateResource_To_v1alpha1_OpenshiftAssistedConfigTemplateResource(in *v1beta1.OpenshiftAssistedConfigTemplateResource, out *OpenshiftAssistedConfigTemplateResource, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1beta1_OpenshiftAssistedConfigSpec_To_v1alpha1_OpenshiftAssistedConfigSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_OpenshiftAssistedConfigTemplateResource_To_v1alpha1_OpenshiftAssistedConfigTemplateResource is an autogenerated conversion function.
+func Convert_v1beta1_OpenshiftAssistedConfigTemplateResource_To_v1alpha1_OpenshiftAssistedConfigTemplateResource(in *v1beta1.OpenshiftAssistedConfigTemplateResource, out *OpenshiftAssistedConfigTemplateResource, s conversion.Scope) error {
+	return autoConvert_v1beta1_OpenshiftAssistedConfigTemplateResource_To_v1alpha1_OpenshiftAssistedConfigTemplateResource(in, out, s)
+}
+
+func autoConvert_v1alpha1_OpenshiftAssistedConfigTemplateSpec_To_v1beta1_OpenshiftAssistedConfigTemplateSpec(in *OpenshiftAssistedConfigTemplateSpec, out *v1beta1.OpenshiftAssistedConfigTemplateSpec, s conversion.Scope) error {
+	if err := Convert_v1alpha1_OpenshiftAssistedConfigTemplateResource_To_v1beta1_OpenshiftAssistedConfigTemplateResource(&in.Template, &out.Template, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha1_OpenshiftAssistedConfigTemplateSpec_To_v1beta1_OpenshiftAssistedConfigTemplateSpec is an autogenerated conversion function.
+func Convert_v1alpha1_OpenshiftAssistedConfigTemplateSpec_To_v1beta1_OpenshiftAssistedConfigTemplateSpec(in *OpenshiftAssistedConfigTemplateSpec, out *v1beta1.OpenshiftAssistedConfigTemplateSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha1_OpenshiftAssistedConfigTemplateSpec_To_v1beta1_OpenshiftAssistedConfigTemplateSpec(in, out, s)
+}
+
+func autoConvert_v1beta1_OpenshiftAssistedConfigTemplateSpec_To_v1alpha1_OpenshiftAssistedConfigTemplateSpec(in *v1beta1.OpenshiftAssistedConfigTemplateSpec, out *OpenshiftAssistedConfigTemplateSpec, s conversion.Scope) error {
+	if err := Convert_v1beta1_OpenshiftAssistedConfigTemplateResource_To_v1alpha1_OpenshiftAssistedConfigTemplateResource(&in.Template, &out.Template, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_OpenshiftAssistedConfigTemplateSpec_To_v1alpha1_OpenshiftAssistedConfigTemplateSpec is an autogenerated conversion function.
+func Convert_v1beta1_OpenshiftAssistedConfigTemplateSpec_To_v1alpha1_OpenshiftAssistedConfigTemplateSpec(in *v1beta1.OpenshiftAssistedConfigTemplateSpec, out *OpenshiftAssistedConfigTemplateSpec, s conversion.Scope) error {
+	return autoConvert_v1beta1_OpenshiftAssistedConfigTemplateSpec_To_v1alpha1_OpenshiftAssistedConfigTemplateSpec(in, out, s)
+}
+
+func autoConvert_v1alpha1_OpenshiftAssistedConfigTemplateStatus_To_v1beta1_OpenshiftAssistedConfigTemplateStatus(in *OpenshiftAssistedConfigTemplateStatus, out *v1beta1.OpenshiftAssistedConfigTemplateStatus, s conversion.Scope) error {
+	return nil
+}
+
+// Convert_v1alpha1_OpenshiftAssistedConfigTemplateStatus_To_v1beta1_OpenshiftAssistedConfigTemplateStatus is an autogenerated conversion function.
+func Convert_v1alpha1_OpenshiftAssistedConfigTemplateStatus_To_v1beta1_OpenshiftAssistedConfigTemplateStatus(in *OpenshiftAssistedConfigTemplateStatus, out *v1beta1.OpenshiftAssistedConfigTemplateStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_OpenshiftAssistedConfigTemplateStatus_To_v1beta1_OpenshiftAssistedConfigTemplateStatus(in, out, s)
+}
+
+func autoConvert_v1beta1_OpenshiftAssistedConfigTemplateStatus_To_v1alpha1_OpenshiftAssistedConfigTemplateStatus(in *v1beta1.OpenshiftAssistedConfigTemplateStatus, out *OpenshiftAssistedConfigTemplateStatus, s conversion.Scope) error {
+	return nil
+}
+
+// Convert_v1beta1_OpenshiftAssistedConfigTemplateStatus_To_v1alpha1_OpenshiftAssistedConfigTemplateStatus is an autogenerated conversion function.
+func Convert_v1beta1_OpenshiftAssistedConfigTemplateStatus_To_v1alpha1_OpenshiftAssistedConfigTemplateStatus(in *v1beta1.OpenshiftAssistedConfigTemplateStatus, out *OpenshiftAssistedConfigTemplateStatus, s conversion.Scope) error {
+	return autoConvert_v1beta1_OpenshiftAssistedConfigTemplateStatus_To_v1alpha1_OpenshiftAssistedConfigTemplateStatus(in, out, s)
+}
@@ -23,7 +23,7 @@ package v1alpha1
 import (
 	"github.com/openshift/assisted-service/api/v1beta1"
 	"k8s.io/api/core/v1"
-	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime"
 	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
@@ -0,0 +1,30 @@
+package v1beta1
+
+import clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+const (
+	InfraEnvFailedReason                                          = "InfraEnvFailed"
+	PropagatingLiveISOURLFailedReason                             = "PropagatingLiveISOURLFailed"
+	CreatingSecretFailedReason                                    = "CreatingSecretFailed"
+	WaitingForLiveISOURLReason                                    = "WaitingForLiveISOURL"
+	WaitingForInstallCompleteReason                               = "WaitingForInstallComplete"
+	WaitingForAssistedInstallerReason                             = "WaitingForAssistedInstaller"
+	WaitingForClusterInfrastructureReason                         = "WaitingForClusterInfrastructure"
+	WaitingForMachineConfigServerReason                           = "WaitingForMachineConfigServer"
+	DataSecretAvailableCondition          clusterv1.ConditionType = "DataSecretAvailable"
+
+	// AgentInstalledCondition documents that the host booted by the OpenshiftAssistedConfig is installed.
+	// When this condition is false, its reason and message report the installation stage of the host.
+	AgentInstalledCondition clusterv1.ConditionType = "AgentInstalled"
+	// WaitingForInstallationReason (Severity=Info) documents an Agent waiting for the installation to start.
+	WaitingForInstallationReason = "WaitingForInstallation"
+	// AgentInstallingReason (Severity=Info) documents an Agent being installed.
+	AgentInstallingReason = "AgentInstalling"
+	// AgentValidationsFailingReason (Severity=Warning) documents an Agent whose failing validations prevent the
+	// installation from starting.
+	AgentValidationsFailingReason = "AgentValidationsFailing"
+	// AgentInstallationFailedReason (Severity=Error) documents an Agent whose installation failed.
+	AgentInstallationFailedReason = "AgentInstallationFailed"
+
+	OpenshiftAssistedConfigLabel = "bootstrap.cluster.x-k8s.io/openshiftAssistedConfig"
+)
@@ -0,0 +1,29 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+// Hub marks OpenshiftAssistedConfig as a conversion hub.
+func (*OpenshiftAssistedConfig) Hub() {}
+
+// Hub marks OpenshiftAssistedConfigList as a conversion hub.
+func (*OpenshiftAssistedConfigList) Hub() {}
+
+// Hub marks OpenshiftAssistedConfigTemplate as a conversion hub.
+func (*OpenshiftAssistedConfigTemplate) Hub() {}
+
+// Hub marks OpenshiftAssistedConfigTemplateList as a conversion hub.
+func (*OpenshiftAssistedConfigTemplateList) Hub() {}
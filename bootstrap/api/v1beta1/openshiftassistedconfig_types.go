@@ -0,0 +1,274 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	aiv1beta1 "github.com/openshift/assisted-service/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// OpenshiftAssistedConfigSpec defines the desired state of OpenshiftAssistedConfig
+type OpenshiftAssistedConfigSpec struct {
+	// Below some fields that would map to the InfraEnv https://github.com/openshift/assisted-service/blob/5b9d5f9197c950750f0d57dc7900a60cef255171/api/v1beta1/infraenv_types.go#L48
+
+	// Proxy defines the proxy settings for agents and clusters that use the InfraEnv. If
+	// unset, the agents and clusters will not be configured to use a proxy.
+	// +optional
+	Proxy *aiv1beta1.Proxy `json:"proxy,omitempty"`
+
+	// PullSecretRef is the reference to the secret to use when pulling images.
+	PullSecretRef *corev1.LocalObjectReference `json:"pullSecretRef,omitempty"`
+
+	// AdditionalNTPSources is a list of NTP sources (hostname or IP) to be added to all cluster
+	// hosts. They are added to any NTP sources that were configured through other means.
+	// +optional
+	AdditionalNTPSources []string `json:"additionalNTPSources,omitempty"`
+
+	// SSHAuthorizedKey is a SSH public keys that will be added to all agents for use in debugging.
+	// +optional
+	SSHAuthorizedKey string `json:"sshAuthorizedKey,omitempty"`
+
+	// NmstateConfigLabelSelector associates NMStateConfigs for hosts that are considered part
+	// of this installation environment.
+	// +optional
+	NMStateConfigLabelSelector metav1.LabelSelector `json:"nmStateConfigLabelSelector,omitempty"`
+
+	// CpuArchitecture specifies the target CPU architecture. Default is x86_64
+	// +kubebuilder:default=x86_64
+	// +optional
+	CpuArchitecture string `json:"cpuArchitecture,omitempty"`
+
+	// KernelArguments is the additional kernel arguments to be passed during boot time of the discovery image.
+	// Applicable for both iPXE, and ISO streaming from Image Service.
+	// +optional
+	KernelArguments []aiv1beta1.KernelArgument `json:"kernelArguments,omitempty"`
+
+	// PEM-encoded X.509 certificate bundle. Hosts discovered by this
+	// infra-env will trust the certificates in this bundle. Clusters formed
+	// from the hosts discovered by this infra-env will also trust the
+	// certificates in this bundle.
+	// +optional
+	AdditionalTrustBundle string `json:"additionalTrustBundle,omitempty"`
+
+	// OSImageVersion is the version of OS image to use when generating the InfraEnv.
+	// The version should refer to an OSImage specified in the AgentServiceConfig
+	// (i.e. OSImageVersion should equal to an OpenshiftVersion in OSImages list).
+	// Note: OSImageVersion can't be specified along with ClusterRef.
+	// +optional
+	OSImageVersion string `json:"osImageVersion,omitempty"`
+
+	// NodeRegistrationOption holds fields related to registering nodes to the cluster
+	// +optional
+	NodeRegistration NodeRegistrationOptions `json:"nodeRegistration,omitempty"`
+
+	// BootstrapMode defines how the machine joins the cluster.
+	// With Discovery (default), the machine boots the discovery ISO and is installed by assisted installer as an Agent.
+	// With MachineConfigServer, worker machines added once the cluster is installed boot RHCOS straight away, with
+	// a pointer ignition to the machine-config-server of the workload cluster. Control plane machines and machines
+	// added before the cluster is installed always use Discovery.
+	// +kubebuilder:validation:Enum=Discovery;MachineConfigServer
+	// +optional
+	BootstrapMode BootstrapMode `json:"bootstrapMode,omitempty"`
+
+	// MachineConfigPool is the machine config pool whose configuration is served to the machine by the
+	// machine-config-server, when using the MachineConfigServer bootstrap mode. Defaults to worker.
+	// +optional
+	MachineConfigPool string `json:"machineConfigPool,omitempty"`
+}
+
+// BootstrapMode defines how a machine joins the cluster
+type BootstrapMode string
+
+const (
+	// DiscoveryBootstrapMode boots the machine with the discovery ISO, it is then installed by assisted installer
+	DiscoveryBootstrapMode BootstrapMode = "Discovery"
+
+	// MachineConfigServerBootstrapMode boots the machine with an ignition pointing to the machine-config-server
+	// of the workload cluster
+	MachineConfigServerBootstrapMode BootstrapMode = "MachineConfigServer"
+)
+
+// NodeRegistrationOption holds fields related to registering nodes to the cluster
+type NodeRegistrationOptions struct {
+	// Defaults to the hostname of the node if not provided.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// KubeletExtraLabels passes extra labels to kubelet.
+	// +optional
+	KubeletExtraLabels []string `json:"kubeletExtraLabels,omitempty"`
+}
+
+// OpenshiftAssistedConfigStatus defines the observed state of OpenshiftAssistedConfig
+type OpenshiftAssistedConfigStatus struct {
+	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+	// InfraEnvRef references the infra env to generate the ISO
+	InfraEnvRef *corev1.ObjectReference `json:"infraEnvRef,omitempty"`
+
+	// AgentRef references the agent this agent bootstrap config has booted
+	AgentRef *corev1.LocalObjectReference `json:"agentRef,omitempty"`
+
+	// ISODownloadURL is the url for the live-iso to be downloaded from Assisted Installer
+	ISODownloadURL string `json:"isoDownloadURL,omitempty"`
+
+	// Ready indicates the BootstrapData field is ready to be consumed
+	// +optional
+	Ready bool `json:"ready"`
+
+	// DataSecretName is the name of the secret that stores the bootstrap data script.
+	// +optional
+	DataSecretName *string `json:"dataSecretName,omitempty"`
+
+	// FailureReason will be set on non-retryable errors
+	// +optional
+	FailureReason string `json:"failureReason,omitempty"`
+
+	// FailureMessage will be set on non-retryable errors
+	// +optional
+	FailureMessage string `json:"failureMessage,omitempty"`
+
+	// ObservedGeneration is the latest generation observed by the controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions defines current service state of the OpenshiftAssistedConfig.
+	// +optional
+	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
+
+	// InstallationProgress mirrors the installation progress of the host, as reported by its Agent.
+	// +optional
+	InstallationProgress *HostInstallationProgress `json:"installationProgress,omitempty"`
+
+	// Initialization provides observations of the OpenshiftAssistedConfig initialization process.
+	// NOTE: Fields in this struct are part of the Cluster API contract and are used to orchestrate initial Machine provisioning.
+	// +optional
+	Initialization *OpenshiftAssistedConfigInitializationStatus `json:"initialization,omitempty"`
+
+	// V1Beta2 groups the fields of the status following the Cluster API v1beta2 contract.
+	// +optional
+	V1Beta2 *OpenshiftAssistedConfigV1Beta2Status `json:"v1beta2,omitempty"`
+}
+
+// HostInstallationProgress reports the installation of a host, as reported by its Agent.
+type HostInstallationProgress struct {
+	// State is the state of the Agent, e.g. known, installing or installed.
+	// +optional
+	State string `json:"state,omitempty"`
+
+	// StateInfo gives details on the state of the Agent.
+	// +optional
+	StateInfo string `json:"stateInfo,omitempty"`
+
+	// Stage is the current installation stage of the host, e.g. "Writing image to disk", "Rebooting" or "Joined".
+	// +optional
+	Stage string `json:"stage,omitempty"`
+
+	// StageInfo gives details on the current installation stage.
+	// +optional
+	StageInfo string `json:"stageInfo,omitempty"`
+
+	// Percentage is the estimated installation progress of the host.
+	// +optional
+	Percentage int64 `json:"percentage,omitempty"`
+
+	// FailedValidations are the messages of the failing validations of the host, preventing the installation
+	// from starting.
+	// +optional
+	FailedValidations []string `json:"failedValidations,omitempty"`
+}
+
+// OpenshiftAssistedConfigInitializationStatus provides observations of the OpenshiftAssistedConfig initialization process.
+type OpenshiftAssistedConfigInitializationStatus struct {
+	// DataSecretCreated is true when the Machine's bootstrap secret is created.
+	// +optional
+	DataSecretCreated bool `json:"dataSecretCreated,omitempty"`
+}
+
+// OpenshiftAssistedConfigV1Beta2Status groups the fields of the status following the Cluster API v1beta2 contract.
+type OpenshiftAssistedConfigV1Beta2Status struct {
+	// Conditions represents the observations of the OpenshiftAssistedConfig current state.
+	// Known condition types are Ready and DataSecretAvailable.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// +kubebuilder:validation:MaxItems=32
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:shortName=oac;oacs
+//+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+//+kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".metadata.labels['cluster\\.x-k8s\\.io/cluster-name']",description="Cluster"
+//+kubebuilder:printcolumn:name="Ready",type=boolean,JSONPath=".status.ready",description="Bootstrap data is ready to be consumed"
+//+kubebuilder:printcolumn:name="Stage",type=string,JSONPath=".status.installationProgress.stage",description="Installation stage of the host"
+//+kubebuilder:printcolumn:name="Progress",type=integer,JSONPath=".status.installationProgress.percentage",description="Estimated installation progress of the host, in percentage"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Time duration since creation of OpenshiftAssistedConfig"
+
+// OpenshiftAssistedConfig is the Schema for the openshiftassistedconfig API
+type OpenshiftAssistedConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OpenshiftAssistedConfigSpec   `json:"spec,omitempty"`
+	Status OpenshiftAssistedConfigStatus `json:"status,omitempty"`
+}
+
+// GetConditions returns the set of conditions for this object.
+func (c *OpenshiftAssistedConfig) GetConditions() clusterv1.Conditions {
+	return c.Status.Conditions
+}
+
+// SetConditions sets the conditions on this object.
+func (c *OpenshiftAssistedConfig) SetConditions(conditions clusterv1.Conditions) {
+	c.Status.Conditions = conditions
+}
+
+// GetV1Beta2Conditions returns the set of v1beta2 conditions for this object.
+func (c *OpenshiftAssistedConfig) GetV1Beta2Conditions() []metav1.Condition {
+	if c.Status.V1Beta2 == nil {
+		return nil
+	}
+	return c.Status.V1Beta2.Conditions
+}
+
+// SetV1Beta2Conditions sets the v1beta2 conditions on this object.
+func (c *OpenshiftAssistedConfig) SetV1Beta2Conditions(conditions []metav1.Condition) {
+	if c.Status.V1Beta2 == nil {
+		c.Status.V1Beta2 = &OpenshiftAssistedConfigV1Beta2Status{}
+	}
+	c.Status.V1Beta2.Conditions = conditions
+}
+
+//+kubebuilder:object:root=true
+
+// OpenshiftAssistedConfigList contains a list of OpenshiftAssistedConfig
+type OpenshiftAssistedConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OpenshiftAssistedConfig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OpenshiftAssistedConfig{}, &OpenshiftAssistedConfigList{})
+}
@@ -0,0 +1,20 @@
+package v1beta1
+
+import clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+// Conditions that will be used for the OpenshiftAssistedConfig object in v1Beta2 API version.
+const (
+	// OpenshiftAssistedConfigReadyV1Beta2Condition is true if the OpenshiftAssistedConfig is not deleted,
+	// and its DataSecretAvailable condition is true.
+	OpenshiftAssistedConfigReadyV1Beta2Condition = clusterv1.ReadyV1Beta2Condition
+
+	// OpenshiftAssistedConfigDataSecretAvailableV1Beta2Condition is true if the bootstrap secret is available.
+	OpenshiftAssistedConfigDataSecretAvailableV1Beta2Condition = "DataSecretAvailable"
+
+	// OpenshiftAssistedConfigDataSecretAvailableV1Beta2Reason surfaces when the bootstrap secret is available.
+	OpenshiftAssistedConfigDataSecretAvailableV1Beta2Reason = clusterv1.AvailableV1Beta2Reason
+
+	// OpenshiftAssistedConfigDataSecretNotAvailableV1Beta2Reason surfaces when the bootstrap secret is not available,
+	// and no more specific reason is known.
+	OpenshiftAssistedConfigDataSecretNotAvailableV1Beta2Reason = clusterv1.NotAvailableV1Beta2Reason
+)
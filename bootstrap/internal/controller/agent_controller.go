@@ -4,20 +4,26 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
+	config_types "github.com/coreos/ignition/v2/config/v3_1/types"
 	"github.com/openshift-assisted/cluster-api-agent/bootstrap/internal/ignition"
 	logutil "github.com/openshift-assisted/cluster-api-agent/util/log"
 
-	bootstrapv1alpha1 "github.com/openshift-assisted/cluster-api-agent/bootstrap/api/v1alpha1"
+	bootstrapv1beta1 "github.com/openshift-assisted/cluster-api-agent/bootstrap/api/v1beta1"
 	"github.com/openshift-assisted/cluster-api-agent/util"
+	"github.com/openshift/assisted-service/api/common"
 	aiv1beta1 "github.com/openshift/assisted-service/api/v1beta1"
 	"github.com/openshift/assisted-service/models"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/runtime"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/patch"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -25,6 +31,10 @@ import (
 const (
 	retryAfter               = 20 * time.Second
 	metal3ProviderIDLabelKey = "metal3.io/uuid"
+
+	// statuses of the failing Agent validations
+	validationFailureStatus = "failure"
+	validationErrorStatus   = "error"
 )
 
 // AgentReconciler reconciles an Agent object
@@ -65,10 +75,109 @@ func (r *AgentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 		return ctrl.Result{}, err
 	}
 
+	if err := r.setInstallationProgress(ctx, agent, config); err != nil {
+		log.Error(err, "failed to set the installation progress of the Agent on the OpenshiftAssistedConfig")
+		return ctrl.Result{}, err
+	}
+
 	return ctrl.Result{}, r.setAgentFields(ctx, agent, machine, config)
 }
 
-func (r *AgentReconciler) setAgentFields(ctx context.Context, agent *aiv1beta1.Agent, machine *clusterv1.Machine, config *bootstrapv1alpha1.OpenshiftAssistedConfig) error {
+// setInstallationProgress mirrors the installation progress of the Agent in the status of its OpenshiftAssistedConfig.
+// Only the installation progress and the AgentInstalled condition are patched, as the OpenshiftAssistedConfig
+// controller updates the rest of the status concurrently.
+func (r *AgentReconciler) setInstallationProgress(ctx context.Context, agent *aiv1beta1.Agent, config *bootstrapv1beta1.OpenshiftAssistedConfig) error {
+	progress := getHostInstallationProgress(agent)
+	if equality.Semantic.DeepEqual(config.Status.InstallationProgress, progress) {
+		return nil
+	}
+	patchHelper, err := patch.NewHelper(config, r.Client)
+	if err != nil {
+		return err
+	}
+	config.Status.InstallationProgress = progress
+	markAgentInstalled(config, progress)
+	return patchHelper.Patch(ctx, config, patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{
+		bootstrapv1beta1.AgentInstalledCondition,
+	}})
+}
+
+func getHostInstallationProgress(agent *aiv1beta1.Agent) *bootstrapv1beta1.HostInstallationProgress {
+	return &bootstrapv1beta1.HostInstallationProgress{
+		State:             agent.Status.DebugInfo.State,
+		StateInfo:         agent.Status.DebugInfo.StateInfo,
+		Stage:             string(agent.Status.Progress.CurrentStage),
+		StageInfo:         agent.Status.Progress.ProgressInfo,
+		Percentage:        agent.Status.Progress.InstallationPercentage,
+		FailedValidations: getFailedValidations(agent.Status.ValidationsInfo),
+	}
+}
+
+// getFailedValidations returns the messages of the failing validations, sorted by category
+func getFailedValidations(validationsInfo common.ValidationsStatus) []string {
+	categories := make([]string, 0, len(validationsInfo))
+	for category := range validationsInfo {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+
+	var failedValidations []string
+	for _, category := range categories {
+		for _, validation := range validationsInfo[category] {
+			if validation.Status == validationFailureStatus || validation.Status == validationErrorStatus {
+				failedValidations = append(failedValidations, validation.Message)
+			}
+		}
+	}
+	return failedValidations
+}
+
+func markAgentInstalled(config *bootstrapv1beta1.OpenshiftAssistedConfig, progress *bootstrapv1beta1.HostInstallationProgress) {
+	switch {
+	case progress.State == models.HostStatusInstalled || progress.State == models.HostStatusAddedToExistingCluster ||
+		progress.Stage == string(models.HostStageJoined) || progress.Stage == string(models.HostStageDone):
+		conditions.MarkTrue(config, bootstrapv1beta1.AgentInstalledCondition)
+	case progress.State == models.HostStatusError || progress.Stage == string(models.HostStageFailed):
+		conditions.MarkFalse(
+			config,
+			bootstrapv1beta1.AgentInstalledCondition,
+			bootstrapv1beta1.AgentInstallationFailedReason,
+			clusterv1.ConditionSeverityError,
+			"Agent installation failed: %s",
+			progress.StateInfo,
+		)
+	case progress.Stage != "":
+		conditions.MarkFalse(
+			config,
+			bootstrapv1beta1.AgentInstalledCondition,
+			bootstrapv1beta1.AgentInstallingReason,
+			clusterv1.ConditionSeverityInfo,
+			"%s (%d%%)",
+			progress.Stage,
+			progress.Percentage,
+		)
+	case len(progress.FailedValidations) > 0:
+		conditions.MarkFalse(
+			config,
+			bootstrapv1beta1.AgentInstalledCondition,
+			bootstrapv1beta1.AgentValidationsFailingReason,
+			clusterv1.ConditionSeverityWarning,
+			"Agent validations failing: %s",
+			strings.Join(progress.FailedValidations, ", "),
+		)
+	default:
+		conditions.MarkFalse(
+			config,
+			bootstrapv1beta1.AgentInstalledCondition,
+			bootstrapv1beta1.WaitingForInstallationReason,
+			clusterv1.ConditionSeverityInfo,
+			"Agent waiting for installation, status: %s",
+			progress.State,
+		)
+	}
+}
+
+func (r *AgentReconciler) setAgentFields(ctx context.Context, agent *aiv1beta1.Agent, machine *clusterv1.Machine, config *bootstrapv1beta1.OpenshiftAssistedConfig) error {
 	role := models.HostRoleWorker
 	if _, ok := machine.Labels[clusterv1.MachineControlPlaneLabel]; ok {
 		role = models.HostRoleMaster
@@ -87,7 +196,12 @@ func (r *AgentReconciler) setAgentFields(ctx context.Context, agent *aiv1beta1.A
 	return r.Client.Update(ctx, agent)
 }
 
-func getIgnitionConfig(config *bootstrapv1alpha1.OpenshiftAssistedConfig) (string, error) {
+func getIgnitionConfig(config *bootstrapv1beta1.OpenshiftAssistedConfig) (string, error) {
+	return ignition.GetIgnitionConfigOverrides(getIgnitionFiles(config)...)
+}
+
+// getIgnitionFiles returns the files CAPI expects on the nodes: the bootstrap success file and the kubelet extra labels
+func getIgnitionFiles(config *bootstrapv1beta1.OpenshiftAssistedConfig) []config_types.File {
 	capiSuccessFile := ignition.CreateIgnitionFile("/run/cluster-api/bootstrap-success.complete",
 		"root", "data:text/plain;charset=utf-8;base64,c3VjY2Vzcw==", 420, true)
 	// get labels and set them as KUBELET_EXTRA_LABELS in ignition
@@ -98,13 +212,11 @@ echo "CUSTOM_KUBELET_LABELS=` + extraLabels + `" | tee -a /etc/kubernetes/kubele
 	b64Content := base64.StdEncoding.EncodeToString([]byte(content))
 	kubeletCustomLabels := ignition.CreateIgnitionFile("/usr/local/bin/kubelet_custom_labels",
 		"root", "data:text/plain;charset=utf-8;base64,"+b64Content, 493, true)
-	ignition.CreateIgnitionFile("/run/cluster-api/bootstrap-success.complete",
-		"root", "data:text/plain;charset=utf-8;base64,c3VjY2Vzcw==", 420, true)
-	return ignition.GetIgnitionConfigOverrides(capiSuccessFile, kubeletCustomLabels)
+	return []config_types.File{capiSuccessFile, kubeletCustomLabels}
 }
 
-func (r *AgentReconciler) ensureBootstrapConfigReference(ctx context.Context, machine *clusterv1.Machine, agentName string) (*bootstrapv1alpha1.OpenshiftAssistedConfig, error) {
-	config := &bootstrapv1alpha1.OpenshiftAssistedConfig{}
+func (r *AgentReconciler) ensureBootstrapConfigReference(ctx context.Context, machine *clusterv1.Machine, agentName string) (*bootstrapv1beta1.OpenshiftAssistedConfig, error) {
+	config := &bootstrapv1beta1.OpenshiftAssistedConfig{}
 	if err := r.Client.Get(ctx,
 		client.ObjectKey{
 			Name:      machine.Spec.Bootstrap.ConfigRef.Name,
@@ -21,16 +21,19 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
-	bootstrapv1alpha1 "github.com/openshift-assisted/cluster-api-agent/bootstrap/api/v1alpha1"
+	bootstrapv1beta1 "github.com/openshift-assisted/cluster-api-agent/bootstrap/api/v1beta1"
 	testutils "github.com/openshift-assisted/cluster-api-agent/test/utils"
+	"github.com/openshift/assisted-service/api/common"
 	"github.com/openshift/assisted-service/api/v1beta1"
 	"github.com/openshift/assisted-service/models"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
@@ -44,7 +47,7 @@ var _ = Describe("Agent Controller", func() {
 		)
 		BeforeEach(func() {
 			k8sClient = fakeclient.NewClientBuilder().WithScheme(testScheme).
-				WithStatusSubresource(&bootstrapv1alpha1.OpenshiftAssistedConfig{}).
+				WithStatusSubresource(&bootstrapv1beta1.OpenshiftAssistedConfig{}).
 				Build()
 			Expect(k8sClient).NotTo(BeNil())
 
@@ -175,7 +178,7 @@ var _ = Describe("Agent Controller", func() {
 					NamespacedName: client.ObjectKeyFromObject(agent),
 				})
 				Expect(err).NotTo(HaveOccurred())
-				expectedOAC := bootstrapv1alpha1.OpenshiftAssistedConfig{}
+				expectedOAC := bootstrapv1beta1.OpenshiftAssistedConfig{}
 				Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: oacName}, &expectedOAC)).To(Succeed())
 				Expect(expectedOAC.Status.AgentRef).NotTo(BeNil())
 				Expect(expectedOAC.Status.AgentRef.Name).To(Equal(agentName))
@@ -207,7 +210,7 @@ var _ = Describe("Agent Controller", func() {
 					NamespacedName: client.ObjectKeyFromObject(agent),
 				})
 				Expect(err).NotTo(HaveOccurred())
-				expectedOAC := bootstrapv1alpha1.OpenshiftAssistedConfig{}
+				expectedOAC := bootstrapv1beta1.OpenshiftAssistedConfig{}
 				Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: oacName}, &expectedOAC)).To(Succeed())
 				Expect(expectedOAC.Status.AgentRef).NotTo(BeNil())
 				Expect(expectedOAC.Status.AgentRef.Name).To(Equal(agentName))
@@ -279,12 +282,165 @@ var _ = Describe("Agent Controller", func() {
 				By("Checking the result of the reconciliation")
 				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(agent), agent)).To(Succeed())
 				assertAgentIsReadyWithRole(agent, models.HostRoleMaster)
-				postOAC := &bootstrapv1alpha1.OpenshiftAssistedConfig{}
+				postOAC := &bootstrapv1beta1.OpenshiftAssistedConfig{}
 				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(oac), postOAC)).To(Succeed())
 				Expect(postOAC.Status.AgentRef).NotTo(BeNil())
 				Expect(postOAC.Status.AgentRef.Name).To(Equal(agent.Name))
 			})
 		})
+		When("an Agent resource with a valid Machine with OACs is installing", func() {
+			It("should mirror the installation progress of the agent in the OAC status", func() {
+				By("Creating the OpenshiftAssistedConfig")
+				oac := testutils.NewOpenshiftAssistedConfig(namespace, oacName, clusterName)
+				Expect(k8sClient.Create(ctx, oac)).To(Succeed())
+
+				agent := testutils.NewAgentWithInfraEnvLabel(namespace, agentName, machineName)
+				agent.Status.DebugInfo.State = models.HostStatusInstallingInProgress
+				agent.Status.Progress.CurrentStage = models.HostStageWritingImageToDisk
+				agent.Status.Progress.ProgressInfo = "68%"
+				agent.Status.Progress.InstallationPercentage = 40
+				Expect(k8sClient.Create(ctx, agent)).To(Succeed())
+
+				infraEnv := testutils.NewInfraEnv(namespace, machineName)
+				machine := testutils.NewMachine(namespace, machineName, clusterName)
+				machine.Spec.Bootstrap.ConfigRef = &corev1.ObjectReference{
+					Name:      oacName,
+					Namespace: namespace,
+				}
+				Expect(controllerutil.SetOwnerReference(machine, infraEnv, testScheme)).To(Succeed())
+				Expect(k8sClient.Create(ctx, machine)).To(Succeed())
+				Expect(k8sClient.Create(ctx, infraEnv)).To(Succeed())
+
+				By("Reconciling the Agent")
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: client.ObjectKeyFromObject(agent),
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				By("Checking the installation progress of the OpenshiftAssistedConfig")
+				postOAC := &bootstrapv1beta1.OpenshiftAssistedConfig{}
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(oac), postOAC)).To(Succeed())
+				Expect(postOAC.Status.InstallationProgress).To(Equal(&bootstrapv1beta1.HostInstallationProgress{
+					State:      models.HostStatusInstallingInProgress,
+					Stage:      string(models.HostStageWritingImageToDisk),
+					StageInfo:  "68%",
+					Percentage: 40,
+				}))
+				condition := conditions.Get(postOAC, bootstrapv1beta1.AgentInstalledCondition)
+				Expect(condition).NotTo(BeNil())
+				Expect(condition.Reason).To(Equal(bootstrapv1beta1.AgentInstallingReason))
+				Expect(condition.Message).To(Equal("Writing image to disk (40%)"))
+			})
+
+			It("should not conflict with a change of the OAC made since it was read", func() {
+				oac := testutils.NewOpenshiftAssistedConfig(namespace, oacName, clusterName)
+				oac.Status.AgentRef = &corev1.LocalObjectReference{Name: agentName}
+				Expect(k8sClient.Create(ctx, oac)).To(Succeed())
+				Expect(k8sClient.Status().Update(ctx, oac)).To(Succeed())
+
+				agent := testutils.NewAgentWithInfraEnvLabel(namespace, agentName, machineName)
+				agent.Status.DebugInfo.State = models.HostStatusInstallingInProgress
+				agent.Status.Progress.CurrentStage = models.HostStageWritingImageToDisk
+				agent.Status.Progress.InstallationPercentage = 40
+				Expect(k8sClient.Create(ctx, agent)).To(Succeed())
+
+				infraEnv := testutils.NewInfraEnv(namespace, machineName)
+				machine := testutils.NewMachine(namespace, machineName, clusterName)
+				machine.Spec.Bootstrap.ConfigRef = &corev1.ObjectReference{
+					Name:      oacName,
+					Namespace: namespace,
+				}
+				Expect(controllerutil.SetOwnerReference(machine, infraEnv, testScheme)).To(Succeed())
+				Expect(k8sClient.Create(ctx, machine)).To(Succeed())
+				Expect(k8sClient.Create(ctx, infraEnv)).To(Succeed())
+
+				By("Updating the OAC once the Agent controller read it")
+				oacChanged := false
+				controllerReconciler.Client = interceptor.NewClient(k8sClient.(client.WithWatch), interceptor.Funcs{
+					Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+						if err := c.Get(ctx, key, obj, opts...); err != nil {
+							return err
+						}
+						if _, ok := obj.(*bootstrapv1beta1.OpenshiftAssistedConfig); ok && !oacChanged {
+							oacChanged = true
+							latestOAC := obj.DeepCopyObject().(*bootstrapv1beta1.OpenshiftAssistedConfig)
+							latestOAC.Status.Ready = true
+							return c.Status().Update(ctx, latestOAC)
+						}
+						return nil
+					},
+				})
+
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: client.ObjectKeyFromObject(agent),
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(oacChanged).To(BeTrue())
+
+				postOAC := &bootstrapv1beta1.OpenshiftAssistedConfig{}
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(oac), postOAC)).To(Succeed())
+				Expect(postOAC.Status.Ready).To(BeTrue())
+				Expect(postOAC.Status.InstallationProgress.State).To(Equal(models.HostStatusInstallingInProgress))
+				Expect(conditions.GetReason(postOAC, bootstrapv1beta1.AgentInstalledCondition)).
+					To(Equal(bootstrapv1beta1.AgentInstallingReason))
+			})
+		})
+	})
+})
+
+var _ = Describe("Agent installation progress", func() {
+	DescribeTable("markAgentInstalled",
+		func(progress bootstrapv1beta1.HostInstallationProgress, expectedStatus corev1.ConditionStatus, expectedReason string) {
+			config := &bootstrapv1beta1.OpenshiftAssistedConfig{}
+			markAgentInstalled(config, &progress)
+			condition := conditions.Get(config, bootstrapv1beta1.AgentInstalledCondition)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(expectedStatus))
+			Expect(condition.Reason).To(Equal(expectedReason))
+		},
+		Entry("waiting for installation",
+			bootstrapv1beta1.HostInstallationProgress{State: models.HostStatusKnown},
+			corev1.ConditionFalse, bootstrapv1beta1.WaitingForInstallationReason),
+		Entry("validations failing",
+			bootstrapv1beta1.HostInstallationProgress{
+				State:             models.HostStatusInsufficient,
+				FailedValidations: []string{"Insufficient CPU cores"},
+			},
+			corev1.ConditionFalse, bootstrapv1beta1.AgentValidationsFailingReason),
+		Entry("rebooting",
+			bootstrapv1beta1.HostInstallationProgress{
+				State: models.HostStatusInstallingInProgress,
+				Stage: string(models.HostStageRebooting),
+			},
+			corev1.ConditionFalse, bootstrapv1beta1.AgentInstallingReason),
+		Entry("installation failed",
+			bootstrapv1beta1.HostInstallationProgress{
+				State: models.HostStatusError,
+				Stage: string(models.HostStageWritingImageToDisk),
+			},
+			corev1.ConditionFalse, bootstrapv1beta1.AgentInstallationFailedReason),
+		Entry("joined",
+			bootstrapv1beta1.HostInstallationProgress{
+				State: models.HostStatusInstallingInProgress,
+				Stage: string(models.HostStageJoined),
+			},
+			corev1.ConditionTrue, ""),
+		Entry("added to an existing cluster",
+			bootstrapv1beta1.HostInstallationProgress{State: models.HostStatusAddedToExistingCluster},
+			corev1.ConditionTrue, ""),
+	)
+
+	It("should return the failing validations sorted by category", func() {
+		Expect(getFailedValidations(common.ValidationsStatus{
+			"network": common.ValidationResults{
+				{ID: "belongs-to-machine-cidr", Status: "failure", Message: "Host does not belong to machine network CIDRs"},
+				{ID: "connected", Status: "success", Message: "Host is connected"},
+			},
+			"hardware": common.ValidationResults{
+				{ID: "has-cpu-cores-for-role", Status: "error", Message: "Insufficient CPU cores"},
+				{ID: "has-memory-for-role", Status: "pending", Message: "Missing inventory"},
+			},
+		})).To(Equal([]string{"Insufficient CPU cores", "Host does not belong to machine network CIDRs"}))
 	})
 })
 
@@ -25,7 +25,7 @@ import (
 
 	"github.com/openshift-assisted/cluster-api-agent/assistedinstaller"
 
-	bootstrapv1alpha1 "github.com/openshift-assisted/cluster-api-agent/bootstrap/api/v1alpha1"
+	bootstrapv1beta1 "github.com/openshift-assisted/cluster-api-agent/bootstrap/api/v1beta1"
 	logutil "github.com/openshift-assisted/cluster-api-agent/util/log"
 	aiv1beta1 "github.com/openshift/assisted-service/api/v1beta1"
 
@@ -51,7 +51,7 @@ type InfraEnvReconciler struct {
 }
 
 func filterRefName(rawObj client.Object) []string {
-	oac, ok := rawObj.(*bootstrapv1alpha1.OpenshiftAssistedConfig)
+	oac, ok := rawObj.(*bootstrapv1beta1.OpenshiftAssistedConfig)
 	if !ok || oac.Status.InfraEnvRef == nil {
 		return nil
 	}
@@ -59,7 +59,7 @@ func filterRefName(rawObj client.Object) []string {
 }
 
 func filterRefNamespace(rawObj client.Object) []string {
-	oac, ok := rawObj.(*bootstrapv1alpha1.OpenshiftAssistedConfig)
+	oac, ok := rawObj.(*bootstrapv1beta1.OpenshiftAssistedConfig)
 	if !ok || oac.Status.InfraEnvRef == nil {
 		return nil
 	}
@@ -68,10 +68,10 @@ func filterRefNamespace(rawObj client.Object) []string {
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *InfraEnvReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	if err := mgr.GetFieldIndexer().IndexField(context.TODO(), &bootstrapv1alpha1.OpenshiftAssistedConfig{}, oacInfraEnvRefFieldNamespace, filterRefNamespace); err != nil {
+	if err := mgr.GetFieldIndexer().IndexField(context.TODO(), &bootstrapv1beta1.OpenshiftAssistedConfig{}, oacInfraEnvRefFieldNamespace, filterRefNamespace); err != nil {
 		return err
 	}
-	if err := mgr.GetFieldIndexer().IndexField(context.TODO(), &bootstrapv1alpha1.OpenshiftAssistedConfig{}, oacInfraEnvRefFieldName, filterRefName); err != nil {
+	if err := mgr.GetFieldIndexer().IndexField(context.TODO(), &bootstrapv1beta1.OpenshiftAssistedConfig{}, oacInfraEnvRefFieldName, filterRefName); err != nil {
 		return err
 	}
 	return ctrl.NewControllerManagedBy(mgr).
@@ -105,7 +105,7 @@ func (r *InfraEnvReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 func (r *InfraEnvReconciler) attachISOToOpenshiftAssistedConfigs(ctx context.Context, infraEnv *aiv1beta1.InfraEnv) error {
 	log := ctrl.LoggerFrom(ctx)
 
-	openshiftAssistedConfig := &bootstrapv1alpha1.OpenshiftAssistedConfigList{}
+	openshiftAssistedConfig := &bootstrapv1beta1.OpenshiftAssistedConfigList{}
 
 	if err := r.Client.List(ctx, openshiftAssistedConfig, client.MatchingFields{oacInfraEnvRefFieldName: infraEnv.Name, oacInfraEnvRefFieldNamespace: infraEnv.Namespace}); err != nil {
 		return errors.Wrap(err, "failed to list Openshift Assisted configs")
@@ -23,7 +23,7 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
-	bootstrapv1alpha1 "github.com/openshift-assisted/cluster-api-agent/bootstrap/api/v1alpha1"
+	bootstrapv1beta1 "github.com/openshift-assisted/cluster-api-agent/bootstrap/api/v1beta1"
 	testutils "github.com/openshift-assisted/cluster-api-agent/test/utils"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -47,14 +47,14 @@ var _ = Describe("InfraEnv Controller", func() {
 		BeforeEach(func() {
 
 			k8sClient = fakeclient.NewClientBuilder().WithScheme(testScheme).
-				WithStatusSubresource(&bootstrapv1alpha1.OpenshiftAssistedConfig{}).
+				WithStatusSubresource(&bootstrapv1beta1.OpenshiftAssistedConfig{}).
 				WithIndex(
-					&bootstrapv1alpha1.OpenshiftAssistedConfig{},
+					&bootstrapv1beta1.OpenshiftAssistedConfig{},
 					oacInfraEnvRefFieldName,
 					filterRefName,
 				).
 				WithIndex(
-					&bootstrapv1alpha1.OpenshiftAssistedConfig{},
+					&bootstrapv1beta1.OpenshiftAssistedConfig{},
 					oacInfraEnvRefFieldNamespace,
 					filterRefNamespace,
 				).
@@ -181,19 +181,19 @@ var _ = Describe("InfraEnv Controller", func() {
 			ctx                  = context.Background()
 			controllerReconciler *InfraEnvReconciler
 			k8sClient            client.Client
-			oac                  *bootstrapv1alpha1.OpenshiftAssistedConfig
+			oac                  *bootstrapv1beta1.OpenshiftAssistedConfig
 		)
 
 		BeforeEach(func() {
 			k8sClient = fakeclient.NewClientBuilder().WithScheme(testScheme).
-				WithStatusSubresource(&bootstrapv1alpha1.OpenshiftAssistedConfig{}).
+				WithStatusSubresource(&bootstrapv1beta1.OpenshiftAssistedConfig{}).
 				WithIndex(
-					&bootstrapv1alpha1.OpenshiftAssistedConfig{},
+					&bootstrapv1beta1.OpenshiftAssistedConfig{},
 					oacInfraEnvRefFieldName,
 					filterRefName,
 				).
 				WithIndex(
-					&bootstrapv1alpha1.OpenshiftAssistedConfig{},
+					&bootstrapv1beta1.OpenshiftAssistedConfig{},
 					oacInfraEnvRefFieldNamespace,
 					filterRefNamespace,
 				).
@@ -0,0 +1,150 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	bootstrapv1beta1 "github.com/openshift-assisted/cluster-api-agent/bootstrap/api/v1beta1"
+	"github.com/openshift-assisted/cluster-api-agent/bootstrap/internal/ignition"
+	"github.com/openshift-assisted/cluster-api-agent/pkg/workloadclient"
+	logutil "github.com/openshift-assisted/cluster-api-agent/util/log"
+	hiveext "github.com/openshift/assisted-service/api/hiveextension/v1beta1"
+	aimodels "github.com/openshift/assisted-service/models"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	capiutil "sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	machineConfigServerPort  = 22623
+	defaultMachineConfigPool = "worker"
+)
+
+// machineConfigServerCAs are the config maps of the workload cluster holding the CA of the machine-config-server,
+// in order of preference: the CA managed by the machine-config-operator, then the root CA created at install time.
+var machineConfigServerCAs = []struct {
+	namespace string
+	name      string
+	key       string
+}{
+	{namespace: "openshift-machine-config-operator", name: "machine-config-server-ca", key: "ca-bundle.crt"},
+	{namespace: "kube-system", name: "root-ca", key: "ca.crt"},
+}
+
+// useMachineConfigServer returns true when the machine should boot with a pointer ignition to the workload cluster
+// machine-config-server: only workers joining an installed cluster, that did not start the discovery flow, can skip it.
+func useMachineConfigServer(config *bootstrapv1beta1.OpenshiftAssistedConfig, machine *clusterv1.Machine, aci *hiveext.AgentClusterInstall) bool {
+	return config.Spec.BootstrapMode == bootstrapv1beta1.MachineConfigServerBootstrapMode &&
+		!capiutil.IsControlPlaneMachine(machine) &&
+		aci.Status.DebugInfo.State == aimodels.ClusterStatusAddingHosts &&
+		config.Status.InfraEnvRef == nil
+}
+
+// reconcileMachineConfigServerUserData writes a pointer ignition to the workload cluster machine-config-server
+// in the bootstrap data secret, so that the machine boots RHCOS and joins the cluster without discovery.
+func (r *OpenshiftAssistedConfigReconciler) reconcileMachineConfigServerUserData(
+	ctx context.Context,
+	config *bootstrapv1beta1.OpenshiftAssistedConfig,
+	cluster *clusterv1.Cluster,
+	clusterDeployment *hivev1.ClusterDeployment,
+) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	workloadClient, err := workloadclient.GetWorkloadClientFromClusterName(ctx, r.Client, r.WorkloadClientGenerator, cluster.Name, cluster.Namespace)
+	if err != nil {
+		log.V(logutil.InfoLevel).Info("workload cluster is not reachable yet, requeuing", "error", err.Error())
+		conditions.MarkFalse(
+			config,
+			bootstrapv1beta1.DataSecretAvailableCondition,
+			bootstrapv1beta1.WaitingForMachineConfigServerReason,
+			clusterv1.ConditionSeverityInfo,
+			"waiting for the workload cluster kubeconfig",
+		)
+		return ctrl.Result{Requeue: true, RequeueAfter: retryAfter}, nil
+	}
+	caCert, err := getMachineConfigServerCA(ctx, workloadClient)
+	if err != nil {
+		conditions.MarkFalse(
+			config,
+			bootstrapv1beta1.DataSecretAvailableCondition,
+			bootstrapv1beta1.WaitingForMachineConfigServerReason,
+			clusterv1.ConditionSeverityWarning,
+			"%s", err.Error(),
+		)
+		return ctrl.Result{}, err
+	}
+
+	pointerIgnition, err := ignition.GetPointerIgnitionConfig(
+		getMachineConfigServerURL(config, clusterDeployment), caCert, getIgnitionFiles(config)...,
+	)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	secret, err := r.createUserDataSecret(ctx, config, pointerIgnition)
+	if err != nil {
+		log.Error(err, "couldn't create user data secret", "name", config.Name)
+		conditions.MarkFalse(
+			config,
+			bootstrapv1beta1.DataSecretAvailableCondition,
+			bootstrapv1beta1.CreatingSecretFailedReason,
+			clusterv1.ConditionSeverityWarning,
+			"",
+		)
+		return ctrl.Result{}, err
+	}
+	log.V(logutil.TraceLevel).Info("machine-config-server user data secret created", "secret", secret.Name)
+
+	config.Status.Ready = true
+	config.Status.DataSecretName = &secret.Name
+	conditions.MarkTrue(config, bootstrapv1beta1.DataSecretAvailableCondition)
+	return ctrl.Result{}, nil
+}
+
+// getMachineConfigServerURL returns the URL serving the machine config pool of the machine, on the internal API
+func getMachineConfigServerURL(config *bootstrapv1beta1.OpenshiftAssistedConfig, clusterDeployment *hivev1.ClusterDeployment) string {
+	pool := config.Spec.MachineConfigPool
+	if pool == "" {
+		pool = defaultMachineConfigPool
+	}
+	return fmt.Sprintf("https://api-int.%s.%s:%d/config/%s",
+		clusterDeployment.Spec.ClusterName, clusterDeployment.Spec.BaseDomain, machineConfigServerPort, pool)
+}
+
+// getMachineConfigServerCA returns the CA of the workload cluster machine-config-server
+func getMachineConfigServerCA(ctx context.Context, workloadClient client.Client) ([]byte, error) {
+	for _, ca := range machineConfigServerCAs {
+		configMap := &corev1.ConfigMap{}
+		if err := workloadClient.Get(ctx, client.ObjectKey{Namespace: ca.namespace, Name: ca.name}, configMap); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get machine-config-server CA: %w", err)
+		}
+		if data, ok := configMap.Data[ca.key]; ok && data != "" {
+			return []byte(data), nil
+		}
+	}
+	return nil, fmt.Errorf("machine-config-server CA not found on the workload cluster")
+}
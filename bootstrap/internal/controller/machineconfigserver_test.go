@@ -0,0 +1,165 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	config_31 "github.com/coreos/ignition/v2/config/v3_1"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	bootstrapv1beta1 "github.com/openshift-assisted/cluster-api-agent/bootstrap/api/v1beta1"
+	"github.com/openshift-assisted/cluster-api-agent/pkg/workloadclient"
+	hiveext "github.com/openshift/assisted-service/api/hiveextension/v1beta1"
+	"github.com/openshift/assisted-service/api/v1beta1"
+	"github.com/openshift/assisted-service/models"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ = Describe("OpenshiftAssistedConfig MachineConfigServer bootstrap mode", func() {
+	var (
+		ctx                  = context.Background()
+		controllerReconciler *OpenshiftAssistedConfigReconciler
+		k8sClient            client.Client
+		workloadClient       client.Client
+		mockCtrl             *gomock.Controller
+		oac                  *bootstrapv1beta1.OpenshiftAssistedConfig
+	)
+
+	reconcileConfig := func() {
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(oac)})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(oac), oac)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		k8sClient = fakeclient.NewClientBuilder().WithScheme(testScheme).
+			WithStatusSubresource(&bootstrapv1beta1.OpenshiftAssistedConfig{}, &v1beta1.InfraEnv{}).
+			Build()
+		workloadClient = fakeclient.NewClientBuilder().WithScheme(testScheme).Build()
+		mockClientGenerator := workloadclient.NewMockClientGenerator(mockCtrl)
+		mockClientGenerator.EXPECT().GetWorkloadClusterClient(gomock.Any()).Return(workloadClient, nil).AnyTimes()
+		controllerReconciler = &OpenshiftAssistedConfigReconciler{
+			Client:                  k8sClient,
+			Scheme:                  k8sClient.Scheme(),
+			WorkloadClientGenerator: mockClientGenerator,
+		}
+		Expect(k8sClient.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}})).To(Succeed())
+
+		oac = setupControlPlaneOpenshiftAssistedConfig(ctx, k8sClient)
+		oac.Spec.BootstrapMode = bootstrapv1beta1.MachineConfigServerBootstrapMode
+		Expect(k8sClient.Update(ctx, oac)).To(Succeed())
+		mockControlPlaneInitialization(ctx, k8sClient)
+
+		By("installing the cluster")
+		cd := &hivev1.ClusterDeployment{}
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: clusterName}, cd)).To(Succeed())
+		cd.Spec.ClusterName = clusterName
+		cd.Spec.BaseDomain = "example.com"
+		Expect(k8sClient.Update(ctx, cd)).To(Succeed())
+		aci := &hiveext.AgentClusterInstall{}
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: clusterName}, aci)).To(Succeed())
+		aci.Status.DebugInfo.State = models.ClusterStatusAddingHosts
+		Expect(k8sClient.Update(ctx, aci)).To(Succeed())
+		Expect(k8sClient.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: clusterName + "-kubeconfig", Namespace: namespace},
+			Data:       map[string][]byte{"value": []byte("kubeconfig")},
+		})).To(Succeed())
+		Expect(workloadClient.Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "root-ca", Namespace: "kube-system"},
+			Data:       map[string]string{"ca.crt": "root-ca"},
+		})).To(Succeed())
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	It("should write a pointer ignition to the machine-config-server for workers", func() {
+		reconcileConfig()
+
+		Expect(oac.Status.Ready).To(BeTrue())
+		Expect(oac.Status.DataSecretName).NotTo(BeNil())
+		Expect(oac.Status.InfraEnvRef).To(BeNil())
+		Expect(conditions.IsTrue(oac, bootstrapv1beta1.DataSecretAvailableCondition)).To(BeTrue())
+		err := k8sClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: machineName}, &v1beta1.InfraEnv{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+		secret := &corev1.Secret{}
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: *oac.Status.DataSecretName}, secret)).To(Succeed())
+		cfg, _, err := config_31.Parse(secret.Data["value"])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Ignition.Config.Merge).To(HaveLen(1))
+		Expect(*cfg.Ignition.Config.Merge[0].Source).To(Equal("https://api-int.test-cluster.example.com:22623/config/worker"))
+		Expect(cfg.Ignition.Security.TLS.CertificateAuthorities).To(HaveLen(1))
+		Expect(*cfg.Ignition.Security.TLS.CertificateAuthorities[0].Source).To(Equal("data:text/plain;charset=utf-8;base64,cm9vdC1jYQ=="))
+	})
+
+	It("should use the machine-config-server CA and the configured machine config pool", func() {
+		Expect(workloadClient.Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "machine-config-server-ca", Namespace: "openshift-machine-config-operator"},
+			Data:       map[string]string{"ca-bundle.crt": "mcs-ca"},
+		})).To(Succeed())
+		oac.Spec.MachineConfigPool = "infra"
+		Expect(k8sClient.Update(ctx, oac)).To(Succeed())
+
+		reconcileConfig()
+
+		secret := &corev1.Secret{}
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: *oac.Status.DataSecretName}, secret)).To(Succeed())
+		cfg, _, err := config_31.Parse(secret.Data["value"])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*cfg.Ignition.Config.Merge[0].Source).To(Equal("https://api-int.test-cluster.example.com:22623/config/infra"))
+		Expect(*cfg.Ignition.Security.TLS.CertificateAuthorities[0].Source).To(Equal("data:text/plain;charset=utf-8;base64,bWNzLWNh"))
+	})
+
+	It("should wait for the machine-config-server CA", func() {
+		Expect(workloadClient.DeleteAllOf(ctx, &corev1.ConfigMap{}, client.InNamespace("kube-system"))).To(Succeed())
+
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(oac)})
+		Expect(err).To(HaveOccurred())
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(oac), oac)).To(Succeed())
+		condition := conditions.Get(oac, bootstrapv1beta1.DataSecretAvailableCondition)
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Reason).To(Equal(bootstrapv1beta1.WaitingForMachineConfigServerReason))
+	})
+
+	It("should use the discovery flow for control plane machines", func() {
+		machine := &clusterv1.Machine{}
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: machineName}, machine)).To(Succeed())
+		machine.Labels[clusterv1.MachineControlPlaneLabel] = ""
+		Expect(k8sClient.Update(ctx, machine)).To(Succeed())
+
+		reconcileConfig()
+
+		Expect(oac.Status.Ready).To(BeFalse())
+		condition := conditions.Get(oac, bootstrapv1beta1.DataSecretAvailableCondition)
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Reason).To(Equal(bootstrapv1beta1.WaitingForLiveISOURLReason))
+		assertInfraEnvWithEmptyISOURL(ctx, k8sClient, oac)
+	})
+})
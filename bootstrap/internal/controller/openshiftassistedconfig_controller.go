@@ -33,6 +33,7 @@ import (
 	aimodels "github.com/openshift/assisted-service/models"
 	"github.com/pkg/errors"
 
+	"github.com/openshift-assisted/cluster-api-agent/pkg/workloadclient"
 	logutil "github.com/openshift-assisted/cluster-api-agent/util/log"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
@@ -44,7 +45,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
-	bootstrapv1alpha1 "github.com/openshift-assisted/cluster-api-agent/bootstrap/api/v1alpha1"
+	bootstrapv1beta1 "github.com/openshift-assisted/cluster-api-agent/bootstrap/api/v1beta1"
 	aiv1beta1 "github.com/openshift/assisted-service/api/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -58,7 +59,7 @@ import (
 )
 
 const (
-	openshiftAssistedConfigFinalizer = "openshiftassistedconfig." + bootstrapv1alpha1.Group + "/deprovision"
+	openshiftAssistedConfigFinalizer = "openshiftassistedconfig." + bootstrapv1beta1.Group + "/deprovision"
 )
 
 // OpenshiftAssistedConfigReconciler reconciles a OpenshiftAssistedConfig object
@@ -67,6 +68,7 @@ type OpenshiftAssistedConfigReconciler struct {
 	Scheme                  *runtime.Scheme
 	AssistedInstallerConfig assistedinstaller.ServiceConfig
 	HttpClient              *http.Client
+	WorkloadClientGenerator workloadclient.ClientGenerator
 }
 
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=*,verbs=create;delete;get;list;patch;update;watch
@@ -93,7 +95,7 @@ func (r *OpenshiftAssistedConfigReconciler) Reconcile(ctx context.Context, req c
 
 	log.V(logutil.TraceLevel).Info("Reconciling OpenshiftAssistedConfig")
 
-	config := &bootstrapv1alpha1.OpenshiftAssistedConfig{}
+	config := &bootstrapv1beta1.OpenshiftAssistedConfig{}
 	if err := r.Client.Get(ctx, req.NamespacedName, config); err != nil {
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
@@ -109,12 +111,15 @@ func (r *OpenshiftAssistedConfigReconciler) Reconcile(ctx context.Context, req c
 		// always update the readyCondition; the summary is represented using the "1 of x completed" notation.
 		conditions.SetSummary(config,
 			conditions.WithConditions(
-				bootstrapv1alpha1.DataSecretAvailableCondition,
+				bootstrapv1beta1.DataSecretAvailableCondition,
 			),
 		)
+		if err := setV1Beta2Status(config); err != nil {
+			rerr = kerrors.NewAggregate([]error{rerr, err})
+		}
 
 		// Patch ObservedGeneration only if the reconciliation completed successfully
-		patchOpts := []patch.Option{}
+		patchOpts := []patch.Option{patch.WithOwnedV1Beta2Conditions{Conditions: v1beta2OwnedConditions}}
 		if rerr == nil {
 			patchOpts = append(patchOpts, patch.WithStatusObservedGeneration{})
 		}
@@ -168,8 +173,8 @@ func (r *OpenshiftAssistedConfigReconciler) Reconcile(ctx context.Context, req c
 		log.V(logutil.TraceLevel).Info("Cluster infrastructure is not read, waiting")
 		conditions.MarkFalse(
 			config,
-			bootstrapv1alpha1.DataSecretAvailableCondition,
-			bootstrapv1alpha1.WaitingForClusterInfrastructureReason,
+			bootstrapv1beta1.DataSecretAvailableCondition,
+			bootstrapv1beta1.WaitingForClusterInfrastructureReason,
 			clusterv1.ConditionSeverityInfo,
 			"",
 		)
@@ -188,8 +193,8 @@ func (r *OpenshiftAssistedConfigReconciler) Reconcile(ctx context.Context, req c
 		log.V(logutil.InfoLevel).Info("could not retrieve ClusterDeployment... requeuing", "cluster", cluster.GetName())
 		conditions.MarkFalse(
 			config,
-			bootstrapv1alpha1.DataSecretAvailableCondition,
-			bootstrapv1alpha1.WaitingForAssistedInstallerReason,
+			bootstrapv1beta1.DataSecretAvailableCondition,
+			bootstrapv1beta1.WaitingForAssistedInstallerReason,
 			clusterv1.ConditionSeverityInfo,
 			"",
 		)
@@ -201,33 +206,38 @@ func (r *OpenshiftAssistedConfigReconciler) Reconcile(ctx context.Context, req c
 		log.V(logutil.InfoLevel).Info("could not retrieve AgentClusterInstall... requeuing")
 		conditions.MarkFalse(
 			config,
-			bootstrapv1alpha1.DataSecretAvailableCondition,
-			bootstrapv1alpha1.WaitingForAssistedInstallerReason,
+			bootstrapv1beta1.DataSecretAvailableCondition,
+			bootstrapv1beta1.WaitingForAssistedInstallerReason,
 			clusterv1.ConditionSeverityInfo,
 			"",
 		)
 		return ctrl.Result{Requeue: true}, nil
 	}
 
-	// if added worker after start install, will be treated as day2
-	if !capiutil.IsControlPlaneMachine(machine) &&
-		!(aci.Status.DebugInfo.State == aimodels.ClusterStatusAddingHosts || aci.Status.DebugInfo.State == aimodels.ClusterStatusPendingForInput || aci.Status.DebugInfo.State == aimodels.ClusterStatusInsufficient || aci.Status.DebugInfo.State == "") {
-		log.V(logutil.DebugLevel).Info("not controlplane machine and installation already started, requeuing")
+	// machines added after the installation started, workers or control plane machines scaling up an installed
+	// control plane, will be treated as day2 once the installation completes. Machines whose bootstrap data was
+	// already served, e.g. the control plane machines being installed, are not held back.
+	if !isBootstrapDataServed(config) && !canJoinCluster(aci) {
+		log.V(logutil.DebugLevel).Info("installation already started, requeuing", "controlPlane", capiutil.IsControlPlaneMachine(machine))
 		conditions.MarkFalse(
 			config,
-			bootstrapv1alpha1.DataSecretAvailableCondition,
-			bootstrapv1alpha1.WaitingForInstallCompleteReason,
+			bootstrapv1beta1.DataSecretAvailableCondition,
+			bootstrapv1beta1.WaitingForInstallCompleteReason,
 			clusterv1.ConditionSeverityInfo,
 			"",
 		)
 		return ctrl.Result{Requeue: true, RequeueAfter: 60 * time.Second}, nil
 	}
 
+	if useMachineConfigServer(config, machine, aci) {
+		return r.reconcileMachineConfigServerUserData(ctx, config, cluster, clusterDeployment)
+	}
+
 	if err := r.ensureInfraEnv(ctx, config, machine, clusterDeployment); err != nil {
 		conditions.MarkFalse(
 			config,
-			bootstrapv1alpha1.DataSecretAvailableCondition,
-			bootstrapv1alpha1.InfraEnvFailedReason,
+			bootstrapv1beta1.DataSecretAvailableCondition,
+			bootstrapv1beta1.InfraEnvFailedReason,
 			clusterv1.ConditionSeverityWarning,
 			"",
 		)
@@ -237,8 +247,8 @@ func (r *OpenshiftAssistedConfigReconciler) Reconcile(ctx context.Context, req c
 	if config.Status.ISODownloadURL == "" {
 		conditions.MarkFalse(
 			config,
-			bootstrapv1alpha1.DataSecretAvailableCondition,
-			bootstrapv1alpha1.WaitingForLiveISOURLReason,
+			bootstrapv1beta1.DataSecretAvailableCondition,
+			bootstrapv1beta1.WaitingForLiveISOURLReason,
 			clusterv1.ConditionSeverityInfo,
 			"",
 		)
@@ -258,8 +268,8 @@ func (r *OpenshiftAssistedConfigReconciler) Reconcile(ctx context.Context, req c
 		log.Error(err, "couldn't create user data secret", "name", config.Name)
 		conditions.MarkFalse(
 			config,
-			bootstrapv1alpha1.DataSecretAvailableCondition,
-			bootstrapv1alpha1.CreatingSecretFailedReason,
+			bootstrapv1beta1.DataSecretAvailableCondition,
+			bootstrapv1beta1.CreatingSecretFailedReason,
 			clusterv1.ConditionSeverityWarning,
 			"",
 		)
@@ -269,10 +279,25 @@ func (r *OpenshiftAssistedConfigReconciler) Reconcile(ctx context.Context, req c
 
 	config.Status.Ready = true
 	config.Status.DataSecretName = &secret.Name
-	conditions.MarkTrue(config, bootstrapv1alpha1.DataSecretAvailableCondition)
+	conditions.MarkTrue(config, bootstrapv1beta1.DataSecretAvailableCondition)
 	return ctrl.Result{}, rerr
 }
 
+// isBootstrapDataServed returns true when the bootstrap data secret of the config was already created
+func isBootstrapDataServed(config *bootstrapv1beta1.OpenshiftAssistedConfig) bool {
+	return config.Status.Ready && config.Status.DataSecretName != nil
+}
+
+// canJoinCluster returns true when machines can be added to the cluster: either the installation did not start yet,
+// or the cluster is installed and new hosts join it through the day2 flow
+func canJoinCluster(aci *v1beta1.AgentClusterInstall) bool {
+	switch aci.Status.DebugInfo.State {
+	case aimodels.ClusterStatusAddingHosts, aimodels.ClusterStatusPendingForInput, aimodels.ClusterStatusInsufficient, "":
+		return true
+	}
+	return false
+}
+
 func (r *OpenshiftAssistedConfigReconciler) getIgnition(ctx context.Context, machine *clusterv1.Machine, log logr.Logger) ([]byte, error) {
 	infraEnvName := getInfraEnvName(machine)
 	infraEnv := aiv1beta1.InfraEnv{}
@@ -319,7 +344,7 @@ func (r *OpenshiftAssistedConfigReconciler) getIgnitionBytes(ctx context.Context
 }
 
 // Ensures InfraEnv exists
-func (r *OpenshiftAssistedConfigReconciler) ensureInfraEnv(ctx context.Context, config *bootstrapv1alpha1.OpenshiftAssistedConfig, machine *clusterv1.Machine, clusterDeployment *hivev1.ClusterDeployment) error {
+func (r *OpenshiftAssistedConfigReconciler) ensureInfraEnv(ctx context.Context, config *bootstrapv1beta1.OpenshiftAssistedConfig, machine *clusterv1.Machine, clusterDeployment *hivev1.ClusterDeployment) error {
 	log := ctrl.LoggerFrom(ctx)
 
 	infraEnvName := getInfraEnvName(machine)
@@ -385,7 +410,7 @@ func (r *OpenshiftAssistedConfigReconciler) getClusterDeployment(
 }
 
 // Creates UserData secret
-func (r *OpenshiftAssistedConfigReconciler) createUserDataSecret(ctx context.Context, config *bootstrapv1alpha1.OpenshiftAssistedConfig, ignition []byte) (*corev1.Secret, error) {
+func (r *OpenshiftAssistedConfigReconciler) createUserDataSecret(ctx context.Context, config *bootstrapv1beta1.OpenshiftAssistedConfig, ignition []byte) (*corev1.Secret, error) {
 	secret := &corev1.Secret{}
 	if err := r.Client.Get(ctx, client.ObjectKey{Namespace: config.Namespace, Name: config.Name}, secret); err != nil {
 		if !apierrors.IsNotFound(err) {
@@ -410,7 +435,7 @@ func (r *OpenshiftAssistedConfigReconciler) createUserDataSecret(ctx context.Con
 }
 
 // Deletes child resources (Agent) and removes finalizer
-func (r *OpenshiftAssistedConfigReconciler) handleDeletion(ctx context.Context, config *bootstrapv1alpha1.OpenshiftAssistedConfig, owner *bsutil.ConfigOwner) error {
+func (r *OpenshiftAssistedConfigReconciler) handleDeletion(ctx context.Context, config *bootstrapv1beta1.OpenshiftAssistedConfig, owner *bsutil.ConfigOwner) error {
 	log := ctrl.LoggerFrom(ctx)
 	if controllerutil.ContainsFinalizer(config, openshiftAssistedConfigFinalizer) {
 		// Check if it's a control plane node and if that cluster is being deleted
@@ -452,7 +477,7 @@ func getInfraEnvName(machine *clusterv1.Machine) string {
 // SetupWithManager sets up the controller with the Manager.
 func (r *OpenshiftAssistedConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&bootstrapv1alpha1.OpenshiftAssistedConfig{}).
+		For(&bootstrapv1beta1.OpenshiftAssistedConfig{}).
 		Watches(
 			&clusterv1.Machine{},
 			handler.EnqueueRequestsFromMapFunc(r.FilterMachine),
@@ -474,7 +499,7 @@ func (r *OpenshiftAssistedConfigReconciler) FilterMachine(_ context.Context, o c
 	// m.Spec.ClusterName
 
 	if m.Spec.Bootstrap.ConfigRef != nil &&
-		m.Spec.Bootstrap.ConfigRef.GroupVersionKind() == bootstrapv1alpha1.GroupVersion.WithKind(
+		m.Spec.Bootstrap.ConfigRef.GroupVersionKind() == bootstrapv1beta1.GroupVersion.WithKind(
 			"OpenshiftAssistedConfig",
 		) {
 		name := client.ObjectKey{Namespace: m.Namespace, Name: m.Spec.Bootstrap.ConfigRef.Name}
@@ -0,0 +1,59 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	bootstrapv1beta1 "github.com/openshift-assisted/cluster-api-agent/bootstrap/api/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	v1beta2conditions "sigs.k8s.io/cluster-api/util/conditions/v1beta2"
+)
+
+// v1beta2OwnedConditions are the v1beta2 conditions set by the OpenshiftAssistedConfig controller
+var v1beta2OwnedConditions = []string{
+	bootstrapv1beta1.OpenshiftAssistedConfigReadyV1Beta2Condition,
+	bootstrapv1beta1.OpenshiftAssistedConfigDataSecretAvailableV1Beta2Condition,
+}
+
+// setV1Beta2Status sets the initialization status and the v1beta2 conditions of the OpenshiftAssistedConfig.
+// The DataSecretAvailable condition keeps the reason and the message of its v1beta1 counterpart.
+func setV1Beta2Status(config *bootstrapv1beta1.OpenshiftAssistedConfig) error {
+	if config.Status.Ready && config.Status.DataSecretName != nil {
+		config.Status.Initialization = &bootstrapv1beta1.OpenshiftAssistedConfigInitializationStatus{
+			DataSecretCreated: true,
+		}
+		v1beta2conditions.Set(config, metav1.Condition{
+			Type:   bootstrapv1beta1.OpenshiftAssistedConfigDataSecretAvailableV1Beta2Condition,
+			Status: metav1.ConditionTrue,
+			Reason: bootstrapv1beta1.OpenshiftAssistedConfigDataSecretAvailableV1Beta2Reason,
+		})
+	} else {
+		reason := conditions.GetReason(config, bootstrapv1beta1.DataSecretAvailableCondition)
+		if reason == "" {
+			reason = bootstrapv1beta1.OpenshiftAssistedConfigDataSecretNotAvailableV1Beta2Reason
+		}
+		v1beta2conditions.Set(config, metav1.Condition{
+			Type:    bootstrapv1beta1.OpenshiftAssistedConfigDataSecretAvailableV1Beta2Condition,
+			Status:  metav1.ConditionFalse,
+			Reason:  reason,
+			Message: conditions.GetMessage(config, bootstrapv1beta1.DataSecretAvailableCondition),
+		})
+	}
+	return v1beta2conditions.SetSummaryCondition(config, config, bootstrapv1beta1.OpenshiftAssistedConfigReadyV1Beta2Condition,
+		v1beta2conditions.ForConditionTypes{bootstrapv1beta1.OpenshiftAssistedConfigDataSecretAvailableV1Beta2Condition},
+	)
+}
@@ -1,6 +1,7 @@
 package ignition
 
 import (
+	"encoding/base64"
 	"encoding/json"
 
 	config_types "github.com/coreos/ignition/v2/config/v3_1/types"
@@ -58,6 +59,29 @@ func getSystemdUnits() []config_types.Unit {
 }
 
 func GetIgnitionConfigOverrides(files ...config_types.File) (string, error) {
+	ignition, err := json.Marshal(getConfig(files))
+	if err != nil {
+		return "", err
+	}
+	return string(ignition), nil
+}
+
+// GetPointerIgnitionConfig returns an ignition config merging the config served at the machine-config-server URL,
+// trusting the given CA, with the files and units applied to agents, so that nodes booting it without discovery
+// are configured the same way as agents.
+func GetPointerIgnitionConfig(machineConfigServerURL string, caCert []byte, files ...config_types.File) ([]byte, error) {
+	config := getConfig(files)
+	config.Ignition.Config.Merge = []config_types.Resource{
+		{Source: &machineConfigServerURL},
+	}
+	caSource := "data:text/plain;charset=utf-8;base64," + base64.StdEncoding.EncodeToString(caCert)
+	config.Ignition.Security.TLS.CertificateAuthorities = []config_types.Resource{
+		{Source: &caSource},
+	}
+	return json.Marshal(config)
+}
+
+func getConfig(files []config_types.File) config_types.Config {
 	configdriveMetadataEnv := CreateIgnitionFile("/usr/local/bin/configdrive_metadata",
 		"root", "data:text/plain;charset=utf-8;base64,IyEvYmluL2Jhc2gKCmVudl9maWxlPS9ldGMvbWV0YWRhdGFfZW52CmNvbmZpZ19kaXI9JChta3RlbXAgLWQpCnN1ZG8gbW91bnQgLUwgY29uZmlnLTIgJGNvbmZpZ19kaXIKY2F0ICRjb25maWdfZGlyL29wZW5zdGFjay9sYXRlc3QvbWV0YV9kYXRhLmpzb24gfCBqcSAtciAnLiB8IGtleXNbXScgfCB3aGlsZSByZWFkIGtleTsgZG8gdmFsdWU9JChqcSAtciAiLltcIiRrZXlcIl0iICRjb25maWdfZGlyL29wZW5zdGFjay9sYXRlc3QvbWV0YV9kYXRhLmpzb24pOyBlY2hvICJNRVRBREFUQV8kKGVjaG8gJHtrZXl9IHwgdHIgYS16IEEtWiB8IHRyIC0gXyk9JHt2YWx1ZX0iOyBkb25lIHwgc29ydCB8IHVuaXEgfCBzdWRvIHRlZSAkZW52X2ZpbGUK", 493, true)
 	files = append(files, configdriveMetadataEnv)
@@ -73,12 +97,7 @@ func GetIgnitionConfigOverrides(files ...config_types.File) (string, error) {
 	if len(units) > 0 {
 		config.Systemd.Units = units
 	}
-
-	ignition, err := json.Marshal(config)
-	if err != nil {
-		return "", err
-	}
-	return string(ignition), nil
+	return config
 }
 
 func CreateIgnitionFile(path, user, content string, mode int, overwrite bool) config_types.File {
@@ -34,5 +34,19 @@ var _ = Describe("Ignition utils", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(cfg).NotTo(BeNil())
 		})
+		It("should generate a pointer ignition to the machine-config-server", func() {
+			capiSuccessFile := CreateIgnitionFile("/run/cluster-api/bootstrap-success.complete",
+				"root", "data:text/plain;charset=utf-8;base64,c3VjY2Vzcw==", 420, true)
+			i, err := GetPointerIgnitionConfig("https://api-int.test.example.com:22623/config/worker", []byte("ca"), capiSuccessFile)
+			Expect(err).NotTo(HaveOccurred())
+			cfg, rep, err := config_31.Parse(i)
+			Expect(rep.Entries).To(BeNil())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.Ignition.Config.Merge).To(HaveLen(1))
+			Expect(*cfg.Ignition.Config.Merge[0].Source).To(Equal("https://api-int.test.example.com:22623/config/worker"))
+			Expect(cfg.Ignition.Security.TLS.CertificateAuthorities).To(HaveLen(1))
+			Expect(*cfg.Ignition.Security.TLS.CertificateAuthorities[0].Source).To(Equal("data:text/plain;charset=utf-8;base64,Y2E="))
+			Expect(cfg.Storage.Files).To(ContainElement(HaveField("Path", "/run/cluster-api/bootstrap-success.complete")))
+		})
 	})
 })
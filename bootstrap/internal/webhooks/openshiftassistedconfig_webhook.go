@@ -0,0 +1,216 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/pem"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+
+	bootstrapv1beta1 "github.com/openshift-assisted/cluster-api-agent/bootstrap/api/v1beta1"
+	"github.com/openshift/assisted-service/models"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// supportedCPUArchitectures are the CPU architectures accepted by assisted-service when creating an InfraEnv
+var supportedCPUArchitectures = []string{
+	models.InfraEnvCreateParamsCPUArchitectureX8664,
+	models.InfraEnvCreateParamsCPUArchitectureAarch64,
+	models.InfraEnvCreateParamsCPUArchitectureArm64,
+	models.InfraEnvCreateParamsCPUArchitecturePpc64le,
+	models.InfraEnvCreateParamsCPUArchitectureS390x,
+}
+
+// supportedKernelArgumentOperations are the operations accepted by assisted-service for kernel arguments
+var supportedKernelArgumentOperations = []string{
+	models.KernelArgumentOperationAppend,
+	models.KernelArgumentOperationReplace,
+	models.KernelArgumentOperationDelete,
+}
+
+// kernelArgumentRegexp matches the kernel argument value format accepted by assisted-service
+var kernelArgumentRegexp = regexp.MustCompile(`^(?:(?:[^ \t\n\r"]+)|(?:"[^"]*"))+$`)
+
+// supportedSSHKeyTypes are the public key algorithms accepted in an authorized_keys file
+var supportedSSHKeyTypes = []string{
+	"ssh-rsa",
+	"ssh-dss",
+	"ssh-ed25519",
+	"ecdsa-sha2-nistp256",
+	"ecdsa-sha2-nistp384",
+	"ecdsa-sha2-nistp521",
+	"sk-ssh-ed25519@openssh.com",
+	"sk-ecdsa-sha2-nistp256@openssh.com",
+}
+
+func (webhook *OpenshiftAssistedConfig) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&bootstrapv1beta1.OpenshiftAssistedConfig{}).
+		WithValidator(webhook).
+		Complete()
+}
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-bootstrap-cluster-x-k8s-io-v1beta1-openshiftassistedconfig,mutating=false,failurePolicy=fail,groups=bootstrap.cluster.x-k8s.io,resources=openshiftassistedconfigs,versions=v1beta1,name=validation.openshiftassistedconfig.bootstrap.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1
+
+// OpenshiftAssistedConfig implements a validation webhook for OpenshiftAssistedConfig.
+type OpenshiftAssistedConfig struct{}
+
+var _ webhook.CustomValidator = &OpenshiftAssistedConfig{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type.
+func (webhook *OpenshiftAssistedConfig) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	config, ok := obj.(*bootstrapv1beta1.OpenshiftAssistedConfig)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected an OpenshiftAssistedConfig but got a %T", obj))
+	}
+	return nil, validateOpenshiftAssistedConfig(config)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type.
+// Configs being deleted, and updates not modifying the spec, e.g. of the status or the finalizers, are not validated,
+// so that configs created before a validation was introduced can still be updated and deleted.
+func (webhook *OpenshiftAssistedConfig) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldConfig, ok := oldObj.(*bootstrapv1beta1.OpenshiftAssistedConfig)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected an OpenshiftAssistedConfig but got a %T", oldObj))
+	}
+	config, ok := newObj.(*bootstrapv1beta1.OpenshiftAssistedConfig)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected an OpenshiftAssistedConfig but got a %T", newObj))
+	}
+	if !config.DeletionTimestamp.IsZero() || reflect.DeepEqual(oldConfig.Spec, config.Spec) {
+		return nil, nil
+	}
+	return nil, validateOpenshiftAssistedConfig(config)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type.
+func (webhook *OpenshiftAssistedConfig) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func validateOpenshiftAssistedConfig(config *bootstrapv1beta1.OpenshiftAssistedConfig) error {
+	allErrs := validateSpec(&config.Spec, field.NewPath("spec"))
+	if len(allErrs) > 0 {
+		return apierrors.NewInvalid(bootstrapv1beta1.GroupVersion.WithKind("OpenshiftAssistedConfig").GroupKind(), config.Name, allErrs)
+	}
+	return nil
+}
+
+func validateSpec(spec *bootstrapv1beta1.OpenshiftAssistedConfigSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if spec.CpuArchitecture != "" && !slices.Contains(supportedCPUArchitectures, spec.CpuArchitecture) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("cpuArchitecture"), spec.CpuArchitecture, supportedCPUArchitectures))
+	}
+
+	for i, kernelArgument := range spec.KernelArguments {
+		argPath := fldPath.Child("kernelArguments").Index(i)
+		if !slices.Contains(supportedKernelArgumentOperations, kernelArgument.Operation) {
+			allErrs = append(allErrs, field.NotSupported(argPath.Child("operation"), kernelArgument.Operation, supportedKernelArgumentOperations))
+		}
+		if !kernelArgumentRegexp.MatchString(kernelArgument.Value) {
+			allErrs = append(allErrs, field.Invalid(argPath.Child("value"), kernelArgument.Value,
+				"must have the form <parameter> or <parameter>=<value>"))
+		}
+	}
+
+	if spec.AdditionalTrustBundle != "" {
+		if err := validatePEMCertificateBundle(spec.AdditionalTrustBundle); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("additionalTrustBundle"), spec.AdditionalTrustBundle, err.Error()))
+		}
+	}
+
+	if spec.SSHAuthorizedKey != "" {
+		if err := validateSSHAuthorizedKey(spec.SSHAuthorizedKey); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("sshAuthorizedKey"), spec.SSHAuthorizedKey, err.Error()))
+		}
+	}
+	return allErrs
+}
+
+// validatePEMCertificateBundle ensures the bundle only contains PEM encoded X.509 certificates
+func validatePEMCertificateBundle(bundle string) error {
+	rest := []byte(bundle)
+	certificates := 0
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			return fmt.Errorf("unexpected PEM block of type %s, only certificates are allowed", block.Type)
+		}
+		if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+			return fmt.Errorf("failed to parse certificate %d: %w", certificates+1, err)
+		}
+		certificates++
+	}
+	if certificates == 0 {
+		return fmt.Errorf("no PEM encoded certificate found")
+	}
+	if len(bytes.TrimSpace(rest)) > 0 {
+		return fmt.Errorf("unexpected data found after the last certificate")
+	}
+	return nil
+}
+
+// validateSSHAuthorizedKey ensures every non-empty line is a public key in the authorized_keys format,
+// i.e. <type> <base64 encoded key> [comment]
+func validateSSHAuthorizedKey(authorizedKeys string) error {
+	for _, line := range strings.Split(authorizedKeys, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			return fmt.Errorf("invalid public key, expected format is <type> <key> [comment]")
+		}
+		keyType := fields[0]
+		if !slices.Contains(supportedSSHKeyTypes, keyType) {
+			return fmt.Errorf("unsupported public key type %s", keyType)
+		}
+		key, err := base64.StdEncoding.DecodeString(fields[1])
+		if err != nil {
+			return fmt.Errorf("invalid base64 encoding for public key: %w", err)
+		}
+		// the wire format of the key starts with its length-prefixed type, which must match the declared one
+		if len(key) < 4 {
+			return fmt.Errorf("invalid public key: too short")
+		}
+		typeLength := binary.BigEndian.Uint32(key[:4])
+		if uint64(len(key)-4) < uint64(typeLength) || string(key[4:4+typeLength]) != keyType {
+			return fmt.Errorf("invalid public key: key data does not match type %s", keyType)
+		}
+	}
+	return nil
+}
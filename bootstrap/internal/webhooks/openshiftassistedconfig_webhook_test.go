@@ -0,0 +1,176 @@
+package webhooks_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/pem"
+	"math/big"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	bootstrapv1beta1 "github.com/openshift-assisted/cluster-api-agent/bootstrap/api/v1beta1"
+	"github.com/openshift-assisted/cluster-api-agent/bootstrap/internal/webhooks"
+	aiv1beta1 "github.com/openshift/assisted-service/api/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("OpenshiftAssistedConfig webhook", func() {
+	var (
+		ctx     = context.Background()
+		webhook *webhooks.OpenshiftAssistedConfig
+		config  *bootstrapv1beta1.OpenshiftAssistedConfig
+	)
+
+	BeforeEach(func() {
+		webhook = &webhooks.OpenshiftAssistedConfig{}
+		config = &bootstrapv1beta1.OpenshiftAssistedConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-config", Namespace: "test"},
+			Spec: bootstrapv1beta1.OpenshiftAssistedConfigSpec{
+				CpuArchitecture: "x86_64",
+			},
+		}
+	})
+
+	It("accepts a valid config", func() {
+		config.Spec.KernelArguments = []aiv1beta1.KernelArgument{
+			{Operation: "append", Value: "rd.net.timeout.carrier=60"},
+			{Operation: "delete", Value: "quiet"},
+		}
+		config.Spec.AdditionalTrustBundle = generateCertificate() + generateCertificate()
+		config.Spec.SSHAuthorizedKey = generateSSHAuthorizedKey() + "\n" + generateSSHAuthorizedKey()
+		_, err := webhook.ValidateCreate(ctx, config)
+		Expect(err).NotTo(HaveOccurred())
+	})
+	It("rejects an unsupported cpu architecture", func() {
+		config.Spec.CpuArchitecture = "mips"
+		_, err := webhook.ValidateCreate(ctx, config)
+		Expect(err).To(MatchError(ContainSubstring("spec.cpuArchitecture")))
+	})
+	It("rejects an unsupported kernel argument operation", func() {
+		config.Spec.KernelArguments = []aiv1beta1.KernelArgument{{Operation: "remove", Value: "quiet"}}
+		_, err := webhook.ValidateCreate(ctx, config)
+		Expect(err).To(MatchError(ContainSubstring("spec.kernelArguments[0].operation")))
+	})
+	It("rejects an invalid kernel argument value", func() {
+		config.Spec.KernelArguments = []aiv1beta1.KernelArgument{{Operation: "append", Value: "a b"}}
+		_, err := webhook.ValidateCreate(ctx, config)
+		Expect(err).To(MatchError(ContainSubstring("spec.kernelArguments[0].value")))
+	})
+	It("rejects an additional trust bundle that is not PEM encoded", func() {
+		config.Spec.AdditionalTrustBundle = "not a certificate"
+		_, err := webhook.ValidateCreate(ctx, config)
+		Expect(err).To(MatchError(ContainSubstring("spec.additionalTrustBundle")))
+	})
+	It("rejects an additional trust bundle containing an invalid certificate", func() {
+		config.Spec.AdditionalTrustBundle = "-----BEGIN CERTIFICATE-----\nY2VydGlmaWNhdGU=\n-----END CERTIFICATE-----\n"
+		_, err := webhook.ValidateCreate(ctx, config)
+		Expect(err).To(MatchError(ContainSubstring("spec.additionalTrustBundle")))
+	})
+	It("rejects an invalid ssh authorized key", func() {
+		config.Spec.SSHAuthorizedKey = "ssh-rsa not-a-key"
+		_, err := webhook.ValidateCreate(ctx, config)
+		Expect(err).To(MatchError(ContainSubstring("spec.sshAuthorizedKey")))
+	})
+	It("rejects an ssh authorized key with mismatching type", func() {
+		config.Spec.SSHAuthorizedKey = "ssh-rsa " + generateSSHAuthorizedKey()[len("ssh-ed25519 "):]
+		_, err := webhook.ValidateCreate(ctx, config)
+		Expect(err).To(MatchError(ContainSubstring("spec.sshAuthorizedKey")))
+	})
+	It("validates updates", func() {
+		newConfig := config.DeepCopy()
+		newConfig.Spec.CpuArchitecture = "mips"
+		_, err := webhook.ValidateUpdate(ctx, config, newConfig)
+		Expect(err).To(MatchError(ContainSubstring("spec.cpuArchitecture")))
+	})
+	It("does not validate updates that do not modify the spec", func() {
+		config.Spec.CpuArchitecture = "mips"
+		newConfig := config.DeepCopy()
+		newConfig.Finalizers = []string{"test-finalizer"}
+		newConfig.Status.Ready = true
+		_, err := webhook.ValidateUpdate(ctx, config, newConfig)
+		Expect(err).NotTo(HaveOccurred())
+	})
+	It("does not validate a config being deleted", func() {
+		config.Spec.CpuArchitecture = "mips"
+		newConfig := config.DeepCopy()
+		newConfig.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+		_, err := webhook.ValidateUpdate(ctx, config, newConfig)
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
+
+var _ = Describe("OpenshiftAssistedConfigTemplate webhook", func() {
+	var (
+		ctx      = context.Background()
+		webhook  *webhooks.OpenshiftAssistedConfigTemplate
+		template *bootstrapv1beta1.OpenshiftAssistedConfigTemplate
+	)
+
+	BeforeEach(func() {
+		webhook = &webhooks.OpenshiftAssistedConfigTemplate{}
+		template = &bootstrapv1beta1.OpenshiftAssistedConfigTemplate{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-template", Namespace: "test"},
+			Spec: bootstrapv1beta1.OpenshiftAssistedConfigTemplateSpec{
+				Template: bootstrapv1beta1.OpenshiftAssistedConfigTemplateResource{
+					Spec: bootstrapv1beta1.OpenshiftAssistedConfigSpec{CpuArchitecture: "aarch64"},
+				},
+			},
+		}
+	})
+
+	It("validates the template spec on create", func() {
+		_, err := webhook.ValidateCreate(ctx, template)
+		Expect(err).NotTo(HaveOccurred())
+
+		template.Spec.Template.Spec.CpuArchitecture = "mips"
+		_, err = webhook.ValidateCreate(ctx, template)
+		Expect(err).To(MatchError(ContainSubstring("spec.template.spec.cpuArchitecture")))
+	})
+	It("rejects updates to the template spec", func() {
+		newTemplate := template.DeepCopy()
+		newTemplate.Spec.Template.Spec.CpuArchitecture = "x86_64"
+		_, err := webhook.ValidateUpdate(ctx, template, newTemplate)
+		Expect(err).To(MatchError(ContainSubstring("spec.template.spec")))
+	})
+	It("allows metadata updates", func() {
+		newTemplate := template.DeepCopy()
+		newTemplate.Labels = map[string]string{"foo": "bar"}
+		_, err := webhook.ValidateUpdate(ctx, template, newTemplate)
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
+
+func generateCertificate() string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).NotTo(HaveOccurred())
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	Expect(err).NotTo(HaveOccurred())
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+// generateSSHAuthorizedKey returns an ed25519 public key in the authorized_keys format
+func generateSSHAuthorizedKey() string {
+	keyType := "ssh-ed25519"
+	publicKey := make([]byte, 32)
+	_, err := rand.Read(publicKey)
+	Expect(err).NotTo(HaveOccurred())
+
+	wire := binary.BigEndian.AppendUint32(nil, uint32(len(keyType)))
+	wire = append(wire, keyType...)
+	wire = binary.BigEndian.AppendUint32(wire, uint32(len(publicKey)))
+	wire = append(wire, publicKey...)
+	return keyType + " " + base64.StdEncoding.EncodeToString(wire) + " user@example.com"
+}
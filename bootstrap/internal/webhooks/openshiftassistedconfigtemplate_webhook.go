@@ -0,0 +1,86 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	bootstrapv1beta1 "github.com/openshift-assisted/cluster-api-agent/bootstrap/api/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func (webhook *OpenshiftAssistedConfigTemplate) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&bootstrapv1beta1.OpenshiftAssistedConfigTemplate{}).
+		WithValidator(webhook).
+		Complete()
+}
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-bootstrap-cluster-x-k8s-io-v1beta1-openshiftassistedconfigtemplate,mutating=false,failurePolicy=fail,groups=bootstrap.cluster.x-k8s.io,resources=openshiftassistedconfigtemplates,versions=v1beta1,name=validation.openshiftassistedconfigtemplate.bootstrap.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1
+
+// OpenshiftAssistedConfigTemplate implements a validation webhook for OpenshiftAssistedConfigTemplate.
+type OpenshiftAssistedConfigTemplate struct{}
+
+var _ webhook.CustomValidator = &OpenshiftAssistedConfigTemplate{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type.
+func (webhook *OpenshiftAssistedConfigTemplate) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	template, ok := obj.(*bootstrapv1beta1.OpenshiftAssistedConfigTemplate)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected an OpenshiftAssistedConfigTemplate but got a %T", obj))
+	}
+
+	allErrs := validateSpec(&template.Spec.Template.Spec, field.NewPath("spec", "template", "spec"))
+	if len(allErrs) > 0 {
+		return nil, apierrors.NewInvalid(bootstrapv1beta1.GroupVersion.WithKind("OpenshiftAssistedConfigTemplate").GroupKind(), template.Name, allErrs)
+	}
+	return nil, nil
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type.
+// Templates are immutable: a new template has to be created and referenced in order to roll out changes.
+func (webhook *OpenshiftAssistedConfigTemplate) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldTemplate, ok := oldObj.(*bootstrapv1beta1.OpenshiftAssistedConfigTemplate)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected an OpenshiftAssistedConfigTemplate but got a %T", oldObj))
+	}
+	newTemplate, ok := newObj.(*bootstrapv1beta1.OpenshiftAssistedConfigTemplate)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected an OpenshiftAssistedConfigTemplate but got a %T", newObj))
+	}
+
+	var allErrs field.ErrorList
+	if !reflect.DeepEqual(oldTemplate.Spec.Template.Spec, newTemplate.Spec.Template.Spec) {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "template", "spec"), "OpenshiftAssistedConfigTemplate spec.template.spec field is immutable. Please create a new resource instead."))
+	}
+	if len(allErrs) > 0 {
+		return nil, apierrors.NewInvalid(bootstrapv1beta1.GroupVersion.WithKind("OpenshiftAssistedConfigTemplate").GroupKind(), newTemplate.Name, allErrs)
+	}
+	return nil, nil
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type.
+func (webhook *OpenshiftAssistedConfigTemplate) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
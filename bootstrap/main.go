@@ -25,7 +25,7 @@ import (
 
 	"github.com/kelseyhightower/envconfig"
 
-	controlplanev1alpha2 "github.com/openshift-assisted/cluster-api-agent/controlplane/api/v1alpha2"
+	controlplanev1beta1 "github.com/openshift-assisted/cluster-api-agent/controlplane/api/v1beta1"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 
@@ -50,7 +50,10 @@ import (
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 
 	bootstrapv1alpha1 "github.com/openshift-assisted/cluster-api-agent/bootstrap/api/v1alpha1"
+	bootstrapv1beta1 "github.com/openshift-assisted/cluster-api-agent/bootstrap/api/v1beta1"
 	"github.com/openshift-assisted/cluster-api-agent/bootstrap/internal/controller"
+	"github.com/openshift-assisted/cluster-api-agent/bootstrap/internal/webhooks"
+	"github.com/openshift-assisted/cluster-api-agent/pkg/workloadclient"
 	//+kubebuilder:scaffold:imports
 )
 
@@ -68,7 +71,8 @@ func init() {
 	utilruntime.Must(metal3.AddToScheme(scheme))
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 	utilruntime.Must(bootstrapv1alpha1.AddToScheme(scheme))
-	utilruntime.Must(controlplanev1alpha2.AddToScheme(scheme))
+	utilruntime.Must(bootstrapv1beta1.AddToScheme(scheme))
+	utilruntime.Must(controlplanev1beta1.AddToScheme(scheme))
 	utilruntime.Must(aiv1beta1.AddToScheme(scheme))
 	utilruntime.Must(hivev1.AddToScheme(scheme))
 	utilruntime.Must(hiveext.AddToScheme(scheme))
@@ -82,6 +86,10 @@ func main() {
 	var probeAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
+	var webhookPort int
+	var webhookCertDir string
+	var webhookCertName string
+	var webhookKeyName string
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
@@ -91,6 +99,14 @@ func main() {
 		"If set the metrics endpoint is served securely")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.IntVar(&webhookPort, "webhook-port", 9443,
+		"Webhook Server port")
+	flag.StringVar(&webhookCertDir, "webhook-cert-dir", "/tmp/k8s-webhook-server/serving-certs/",
+		"Webhook cert dir, only used when webhook-port is specified.")
+	flag.StringVar(&webhookCertName, "webhook-cert-name", "tls.crt",
+		"Webhook cert name, as found in the Secret generated by cert-manager.")
+	flag.StringVar(&webhookKeyName, "webhook-key-name", "tls.key",
+		"Webhook key name, as found in the Secret generated by cert-manager.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -116,7 +132,11 @@ func main() {
 	}
 
 	webhookServer := webhook.NewServer(webhook.Options{
-		TLSOpts: tlsOpts,
+		Port:     webhookPort,
+		CertDir:  webhookCertDir,
+		CertName: webhookCertName,
+		KeyName:  webhookKeyName,
+		TLSOpts:  tlsOpts,
 	})
 
 	clientConfig := ctrl.GetConfigOrDie()
@@ -168,6 +188,7 @@ func main() {
 		Scheme:                  mgr.GetScheme(),
 		AssistedInstallerConfig: Options.AssistedInstallerServiceConfig,
 		HttpClient:              httpClient,
+		WorkloadClientGenerator: workloadclient.NewWorkloadClusterClientGenerator(),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "OpenshiftAssistedConfig")
 		os.Exit(1)
@@ -187,6 +208,18 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "Agent")
 		os.Exit(1)
 	}
+	// webhooks can be disabled to run the manager locally without certificates.
+	// The conversion webhook is served for all the types registered in the scheme.
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		if err = (&webhooks.OpenshiftAssistedConfig{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "OpenshiftAssistedConfig")
+			os.Exit(1)
+		}
+		if err = (&webhooks.OpenshiftAssistedConfigTemplate{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "OpenshiftAssistedConfigTemplate")
+			os.Exit(1)
+		}
+	}
 	//+kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
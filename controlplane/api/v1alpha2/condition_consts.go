@@ -30,6 +30,10 @@ const (
 	// from the OpenShift version.
 	KubernetesVersionUnavailableFailedReason = "KubernetesVersionUnavailable"
 
+	// KubernetesVersionMismatchReason (Severity=Warning) documents that the Kubernetes version requested in spec.version
+	// does not match the Kubernetes version shipped with the requested OpenShift version.
+	KubernetesVersionMismatchReason = "KubernetesVersionMismatch"
+
 	// ControlPlaneInstallingCOndition (Severity=Info) documents that the workload cluster kubeconfig is not yet available.
 	KubeconfigUnavailableFailedReason = "KubeconfigUnavailable"
 
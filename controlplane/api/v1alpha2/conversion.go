@@ -0,0 +1,151 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	controlplanev1beta1 "github.com/openshift-assisted/cluster-api-agent/controlplane/api/v1beta1"
+	apiconversion "k8s.io/apimachinery/pkg/conversion"
+	utilconversion "sigs.k8s.io/cluster-api/util/conversion"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// ConvertTo converts this OpenshiftAssistedControlPlane to the Hub version (v1beta1).
+func (src *OpenshiftAssistedControlPlane) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*controlplanev1beta1.OpenshiftAssistedControlPlane)
+	if err := Convert_v1alpha2_OpenshiftAssistedControlPlane_To_v1beta1_OpenshiftAssistedControlPlane(src, dst, nil); err != nil {
+		return err
+	}
+
+	// Restore the fields that only exist in the Hub version
+	restored := &controlplanev1beta1.OpenshiftAssistedControlPlane{}
+	if ok, err := utilconversion.UnmarshalData(src, restored); err != nil || !ok {
+		return err
+	}
+	dst.Spec.RolloutAfter = restored.Spec.RolloutAfter
+	dst.Spec.RolloutStrategy = restored.Spec.RolloutStrategy
+	dst.Spec.RemediationStrategy = restored.Spec.RemediationStrategy
+	dst.Spec.UpgradeStrategy = restored.Spec.UpgradeStrategy
+	dst.Spec.ControlPlaneEndpoint = restored.Spec.ControlPlaneEndpoint
+	dst.Spec.Config.Networking = restored.Spec.Config.Networking
+	dst.Spec.OpenshiftAssistedConfigSpec.BootstrapMode = restored.Spec.OpenshiftAssistedConfigSpec.BootstrapMode
+	dst.Spec.OpenshiftAssistedConfigSpec.MachineConfigPool = restored.Spec.OpenshiftAssistedConfigSpec.MachineConfigPool
+	dst.Status.LastRemediation = restored.Status.LastRemediation
+	dst.Status.UpgradeHistory = restored.Status.UpgradeHistory
+	dst.Status.Initialization = restored.Status.Initialization
+	dst.Status.V1Beta2 = restored.Status.V1Beta2
+	dst.Status.InstallationProgress = restored.Status.InstallationProgress
+	dst.Status.ClusterID = restored.Status.ClusterID
+	dst.Status.InfraID = restored.Status.InfraID
+	dst.Status.APIURL = restored.Status.APIURL
+	dst.Status.ConsoleURL = restored.Status.ConsoleURL
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1beta1) to this version.
+func (dst *OpenshiftAssistedControlPlane) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*controlplanev1beta1.OpenshiftAssistedControlPlane)
+	if err := Convert_v1beta1_OpenshiftAssistedControlPlane_To_v1alpha2_OpenshiftAssistedControlPlane(src, dst, nil); err != nil {
+		return err
+	}
+
+	// Preserve the Hub data in an annotation, so it is not lost on a round trip
+	return utilconversion.MarshalData(src, dst)
+}
+
+// ConvertTo converts this OpenshiftAssistedControlPlaneList to the Hub version (v1beta1).
+func (src *OpenshiftAssistedControlPlaneList) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*controlplanev1beta1.OpenshiftAssistedControlPlaneList)
+	return Convert_v1alpha2_OpenshiftAssistedControlPlaneList_To_v1beta1_OpenshiftAssistedControlPlaneList(src, dst, nil)
+}
+
+// ConvertFrom converts from the Hub version (v1beta1) to this version.
+func (dst *OpenshiftAssistedControlPlaneList) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*controlplanev1beta1.OpenshiftAssistedControlPlaneList)
+	return Convert_v1beta1_OpenshiftAssistedControlPlaneList_To_v1alpha2_OpenshiftAssistedControlPlaneList(src, dst, nil)
+}
+
+// ConvertTo converts this OpenshiftAssistedControlPlaneTemplate to the Hub version (v1beta1).
+func (src *OpenshiftAssistedControlPlaneTemplate) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*controlplanev1beta1.OpenshiftAssistedControlPlaneTemplate)
+	if err := Convert_v1alpha2_OpenshiftAssistedControlPlaneTemplate_To_v1beta1_OpenshiftAssistedControlPlaneTemplate(src, dst, nil); err != nil {
+		return err
+	}
+
+	// Restore the fields that only exist in the Hub version
+	restored := &controlplanev1beta1.OpenshiftAssistedControlPlaneTemplate{}
+	if ok, err := utilconversion.UnmarshalData(src, restored); err != nil || !ok {
+		return err
+	}
+	dst.Spec.Template.Spec.RolloutAfter = restored.Spec.Template.Spec.RolloutAfter
+	dst.Spec.Template.Spec.RolloutStrategy = restored.Spec.Template.Spec.RolloutStrategy
+	dst.Spec.Template.Spec.RemediationStrategy = restored.Spec.Template.Spec.RemediationStrategy
+	dst.Spec.Template.Spec.UpgradeStrategy = restored.Spec.Template.Spec.UpgradeStrategy
+	dst.Spec.Template.Spec.Config.Networking = restored.Spec.Template.Spec.Config.Networking
+	dst.Spec.Template.Spec.OpenshiftAssistedConfigSpec.BootstrapMode = restored.Spec.Template.Spec.OpenshiftAssistedConfigSpec.BootstrapMode
+	dst.Spec.Template.Spec.OpenshiftAssistedConfigSpec.MachineConfigPool = restored.Spec.Template.Spec.OpenshiftAssistedConfigSpec.MachineConfigPool
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1beta1) to this version.
+func (dst *OpenshiftAssistedControlPlaneTemplate) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*controlplanev1beta1.OpenshiftAssistedControlPlaneTemplate)
+	if err := Convert_v1beta1_OpenshiftAssistedControlPlaneTemplate_To_v1alpha2_OpenshiftAssistedControlPlaneTemplate(src, dst, nil); err != nil {
+		return err
+	}
+
+	// Preserve the Hub data in an annotation, so it is not lost on a round trip
+	return utilconversion.MarshalData(src, dst)
+}
+
+// ConvertTo converts this OpenshiftAssistedControlPlaneTemplateList to the Hub version (v1beta1).
+func (src *OpenshiftAssistedControlPlaneTemplateList) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*controlplanev1beta1.OpenshiftAssistedControlPlaneTemplateList)
+	return Convert_v1alpha2_OpenshiftAssistedControlPlaneTemplateList_To_v1beta1_OpenshiftAssistedControlPlaneTemplateList(src, dst, nil)
+}
+
+// ConvertFrom converts from the Hub version (v1beta1) to this version.
+func (dst *OpenshiftAssistedControlPlaneTemplateList) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*controlplanev1beta1.OpenshiftAssistedControlPlaneTemplateList)
+	return Convert_v1beta1_OpenshiftAssistedControlPlaneTemplateList_To_v1alpha2_OpenshiftAssistedControlPlaneTemplateList(src, dst, nil)
+}
+
+// Convert_v1beta1_OpenshiftAssistedControlPlaneSpec_To_v1alpha2_OpenshiftAssistedControlPlaneSpec drops the rollout,
+// remediation, upgrade strategy and control plane endpoint fields, which are preserved in the conversion data
+// annotation by ConvertFrom.
+func Convert_v1beta1_OpenshiftAssistedControlPlaneSpec_To_v1alpha2_OpenshiftAssistedControlPlaneSpec(in *controlplanev1beta1.OpenshiftAssistedControlPlaneSpec, out *OpenshiftAssistedControlPlaneSpec, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_OpenshiftAssistedControlPlaneSpec_To_v1alpha2_OpenshiftAssistedControlPlaneSpec(in, out, s)
+}
+
+// Convert_v1beta1_OpenshiftAssistedControlPlaneTemplateResourceSpec_To_v1alpha2_OpenshiftAssistedControlPlaneTemplateResourceSpec
+// drops the rollout, remediation and upgrade strategy fields, which are preserved in the conversion data annotation
+// by ConvertFrom.
+func Convert_v1beta1_OpenshiftAssistedControlPlaneTemplateResourceSpec_To_v1alpha2_OpenshiftAssistedControlPlaneTemplateResourceSpec(in *controlplanev1beta1.OpenshiftAssistedControlPlaneTemplateResourceSpec, out *OpenshiftAssistedControlPlaneTemplateResourceSpec, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_OpenshiftAssistedControlPlaneTemplateResourceSpec_To_v1alpha2_OpenshiftAssistedControlPlaneTemplateResourceSpec(in, out, s)
+}
+
+// Convert_v1beta1_OpenshiftAssistedControlPlaneConfigSpec_To_v1alpha2_OpenshiftAssistedControlPlaneConfigSpec drops
+// the networking configuration, which is preserved in the conversion data annotation by ConvertFrom.
+func Convert_v1beta1_OpenshiftAssistedControlPlaneConfigSpec_To_v1alpha2_OpenshiftAssistedControlPlaneConfigSpec(in *controlplanev1beta1.OpenshiftAssistedControlPlaneConfigSpec, out *OpenshiftAssistedControlPlaneConfigSpec, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_OpenshiftAssistedControlPlaneConfigSpec_To_v1alpha2_OpenshiftAssistedControlPlaneConfigSpec(in, out, s)
+}
+
+// Convert_v1beta1_OpenshiftAssistedControlPlaneStatus_To_v1alpha2_OpenshiftAssistedControlPlaneStatus drops the last
+// remediation, the upgrade history, the initialization status, the v1beta2 conditions, the installation progress, and
+// the cluster identifiers and URLs, which are preserved in the conversion data annotation by ConvertFrom.
+func Convert_v1beta1_OpenshiftAssistedControlPlaneStatus_To_v1alpha2_OpenshiftAssistedControlPlaneStatus(in *controlplanev1beta1.OpenshiftAssistedControlPlaneStatus, out *OpenshiftAssistedControlPlaneStatus, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_OpenshiftAssistedControlPlaneStatus_To_v1alpha2_OpenshiftAssistedControlPlaneStatus(in, out, s)
+}
@@ -67,6 +67,11 @@ type OpenshiftAssistedControlPlaneSpec struct {
 	Replicas                    int32                                        `json:"replicas,omitempty"`
 	// DistributionVersion describes the targeted OpenShift version
 	DistributionVersion string `json:"distributionVersion"`
+	// Version is the Kubernetes version of the control plane. It is set by the Cluster topology controller
+	// when the control plane is created from a ClusterClass. As OpenShift upgrades are driven by DistributionVersion,
+	// this field is only checked against the Kubernetes version shipped with the DistributionVersion release image.
+	// +optional
+	Version string `json:"version,omitempty"`
 }
 
 // OpenshiftAssistedControlPlaneConfigSpec defines configuration for the agent-provisioned cluster
@@ -215,6 +220,10 @@ type OpenshiftAssistedControlPlaneStatus struct {
 	// +optional
 	FailureMessage *string `json:"failureMessage,omitempty"`
 
+	// ObservedGeneration is the latest generation observed by the controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
 	// Conditions defines current service state of the KubeadmControlPlane.
 	// +optional
 	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
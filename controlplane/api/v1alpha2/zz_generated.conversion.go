@@ -0,0 +1,607 @@
+//go:build !ignore_autogenerated_controlplane
+// +build !ignore_autogenerated_controlplane
+
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by conversion-gen. DO NOT EDIT.
+
+package v1alpha2
+
+import (
+	unsafe "unsafe"
+
+	v1alpha1 "github.com/openshift-assisted/cluster-api-agent/bootstrap/api/v1alpha1"
+	v1beta1 "github.com/openshift-assisted/cluster-api-agent/controlplane/api/v1beta1"
+	hiveextensionv1beta1 "github.com/openshift/assisted-service/api/hiveextension/v1beta1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	conversion "k8s.io/apimachinery/pkg/conversion"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func init() {
+	localSchemeBuilder.Register(RegisterConversions)
+}
+
+// RegisterConversions adds conversion functions to the given scheme.
+// Public to allow building arbitrary schemes.
+func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*Capabilities)(nil), (*v1beta1.Capabilities)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_Capabilities_To_v1beta1_Capabilities(a.(*Capabilities), b.(*v1beta1.Capabilities), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.Capabilities)(nil), (*Capabilities)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_Capabilities_To_v1alpha2_Capabilities(a.(*v1beta1.Capabilities), b.(*Capabilities), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*OpenshiftAssistedControlPlane)(nil), (*v1beta1.OpenshiftAssistedControlPlane)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_OpenshiftAssistedControlPlane_To_v1beta1_OpenshiftAssistedControlPlane(a.(*OpenshiftAssistedControlPlane), b.(*v1beta1.OpenshiftAssistedControlPlane), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.OpenshiftAssistedControlPlane)(nil), (*OpenshiftAssistedControlPlane)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_OpenshiftAssistedControlPlane_To_v1alpha2_OpenshiftAssistedControlPlane(a.(*v1beta1.OpenshiftAssistedControlPlane), b.(*OpenshiftAssistedControlPlane), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*OpenshiftAssistedControlPlaneConfigSpec)(nil), (*v1beta1.OpenshiftAssistedControlPlaneConfigSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_OpenshiftAssistedControlPlaneConfigSpec_To_v1beta1_OpenshiftAssistedControlPlaneConfigSpec(a.(*OpenshiftAssistedControlPlaneConfigSpec), b.(*v1beta1.OpenshiftAssistedControlPlaneConfigSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.OpenshiftAssistedControlPlaneConfigSpec)(nil), (*OpenshiftAssistedControlPlaneConfigSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_OpenshiftAssistedControlPlaneConfigSpec_To_v1alpha2_OpenshiftAssistedControlPlaneConfigSpec(a.(*v1beta1.OpenshiftAssistedControlPlaneConfigSpec), b.(*OpenshiftAssistedControlPlaneConfigSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*OpenshiftAssistedControlPlaneList)(nil), (*v1beta1.OpenshiftAssistedControlPlaneList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_OpenshiftAssistedControlPlaneList_To_v1beta1_OpenshiftAssistedControlPlaneList(a.(*OpenshiftAssistedControlPlaneList), b.(*v1beta1.OpenshiftAssistedControlPlaneList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.OpenshiftAssistedControlPlaneList)(nil), (*OpenshiftAssistedControlPlaneList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_OpenshiftAssistedControlPlaneList_To_v1alpha2_OpenshiftAssistedControlPlaneList(a.(*v1beta1.OpenshiftAssistedControlPlaneList), b.(*OpenshiftAssistedControlPlaneList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*OpenshiftAssistedControlPlaneMachineTemplate)(nil), (*v1beta1.OpenshiftAssistedControlPlaneMachineTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_OpenshiftAssistedControlPlaneMachineTemplate_To_v1beta1_OpenshiftAssistedControlPlaneMachineTemplate(a.(*OpenshiftAssistedControlPlaneMachineTemplate), b.(*v1beta1.OpenshiftAssistedControlPlaneMachineTemplate), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.OpenshiftAssistedControlPlaneMachineTemplate)(nil), (*OpenshiftAssistedControlPlaneMachineTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_OpenshiftAssistedControlPlaneMachineTemplate_To_v1alpha2_OpenshiftAssistedControlPlaneMachineTemplate(a.(*v1beta1.OpenshiftAssistedControlPlaneMachineTemplate), b.(*OpenshiftAssistedControlPlaneMachineTemplate), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*OpenshiftAssistedControlPlaneSpec)(nil), (*v1beta1.OpenshiftAssistedControlPlaneSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_OpenshiftAssistedControlPlaneSpec_To_v1beta1_OpenshiftAssistedControlPlaneSpec(a.(*OpenshiftAssistedControlPlaneSpec), b.(*v1beta1.OpenshiftAssistedControlPlaneSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*OpenshiftAssistedControlPlaneStatus)(nil), (*v1beta1.OpenshiftAssistedControlPlaneStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_OpenshiftAssistedControlPlaneStatus_To_v1beta1_OpenshiftAssistedControlPlaneStatus(a.(*OpenshiftAssistedControlPlaneStatus), b.(*v1beta1.OpenshiftAssistedControlPlaneStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*OpenshiftAssistedControlPlaneTemplate)(nil), (*v1beta1.OpenshiftAssistedControlPlaneTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_OpenshiftAssistedControlPlaneTemplate_To_v1beta1_OpenshiftAssistedControlPlaneTemplate(a.(*OpenshiftAssistedControlPlaneTemplate), b.(*v1beta1.OpenshiftAssistedControlPlaneTemplate), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.OpenshiftAssistedControlPlaneTemplate)(nil), (*OpenshiftAssistedControlPlaneTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_OpenshiftAssistedControlPlaneTemplate_To_v1alpha2_OpenshiftAssistedControlPlaneTemplate(a.(*v1beta1.OpenshiftAssistedControlPlaneTemplate), b.(*OpenshiftAssistedControlPlaneTemplate), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*OpenshiftAssistedControlPlaneTemplateList)(nil), (*v1beta1.OpenshiftAssistedControlPlaneTemplateList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_OpenshiftAssistedControlPlaneTemplateList_To_v1beta1_OpenshiftAssistedControlPlaneTemplateList(a.(*OpenshiftAssistedControlPlaneTemplateList), b.(*v1beta1.OpenshiftAssistedControlPlaneTemplateList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.OpenshiftAssistedControlPlaneTemplateList)(nil), (*OpenshiftAssistedControlPlaneTemplateList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_OpenshiftAssistedControlPlaneTemplateList_To_v1alpha2_OpenshiftAssistedControlPlaneTemplateList(a.(*v1beta1.OpenshiftAssistedControlPlaneTemplateList), b.(*OpenshiftAssistedControlPlaneTemplateList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*OpenshiftAssistedControlPlaneTemplateMachineTemplate)(nil), (*v1beta1.OpenshiftAssistedControlPlaneTemplateMachineTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_OpenshiftAssistedControlPlaneTemplateMachineTemplate_To_v1beta1_OpenshiftAssistedControlPlaneTemplateMachineTemplate(a.(*OpenshiftAssistedControlPlaneTemplateMachineTemplate), b.(*v1beta1.OpenshiftAssistedControlPlaneTemplateMachineTemplate), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.OpenshiftAssistedControlPlaneTemplateMachineTemplate)(nil), (*OpenshiftAssistedControlPlaneTemplateMachineTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_OpenshiftAssistedControlPlaneTemplateMachineTemplate_To_v1alpha2_OpenshiftAssistedControlPlaneTemplateMachineTemplate(a.(*v1beta1.OpenshiftAssistedControlPlaneTemplateMachineTemplate), b.(*OpenshiftAssistedControlPlaneTemplateMachineTemplate), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*OpenshiftAssistedControlPlaneTemplateResource)(nil), (*v1beta1.OpenshiftAssistedControlPlaneTemplateResource)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_OpenshiftAssistedControlPlaneTemplateResource_To_v1beta1_OpenshiftAssistedControlPlaneTemplateResource(a.(*OpenshiftAssistedControlPlaneTemplateResource), b.(*v1beta1.OpenshiftAssistedControlPlaneTemplateResource), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.OpenshiftAssistedControlPlaneTemplateResource)(nil), (*OpenshiftAssistedControlPlaneTemplateResource)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_OpenshiftAssistedControlPlaneTemplateResource_To_v1alpha2_OpenshiftAssistedControlPlaneTemplateResource(a.(*v1beta1.OpenshiftAssistedControlPlaneTemplateResource), b.(*OpenshiftAssistedControlPlaneTemplateResource), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*OpenshiftAssistedControlPlaneTemplateResourceSpec)(nil), (*v1beta1.OpenshiftAssistedControlPlaneTemplateResourceSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_OpenshiftAssistedControlPlaneTemplateResourceSpec_To_v1beta1_OpenshiftAssistedControlPlaneTemplateResourceSpec(a.(*OpenshiftAssistedControlPlaneTemplateResourceSpec), b.(*v1beta1.OpenshiftAssistedControlPlaneTemplateResourceSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*OpenshiftAssistedControlPlaneTemplateSpec)(nil), (*v1beta1.OpenshiftAssistedControlPlaneTemplateSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_OpenshiftAssistedControlPlaneTemplateSpec_To_v1beta1_OpenshiftAssistedControlPlaneTemplateSpec(a.(*OpenshiftAssistedControlPlaneTemplateSpec), b.(*v1beta1.OpenshiftAssistedControlPlaneTemplateSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.OpenshiftAssistedControlPlaneTemplateSpec)(nil), (*OpenshiftAssistedControlPlaneTemplateSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_OpenshiftAssistedControlPlaneTemplateSpec_To_v1alpha2_OpenshiftAssistedControlPlaneTemplateSpec(a.(*v1beta1.OpenshiftAssistedControlPlaneTemplateSpec), b.(*OpenshiftAssistedControlPlaneTemplateSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1beta1.OpenshiftAssistedControlPlaneSpec)(nil), (*OpenshiftAssistedControlPlaneSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_OpenshiftAssistedControlPlaneSpec_To_v1alpha2_OpenshiftAssistedControlPlaneSpec(a.(*v1beta1.OpenshiftAssistedControlPlaneSpec), b.(*OpenshiftAssistedControlPlaneSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1beta1.OpenshiftAssistedControlPlaneStatus)(nil), (*OpenshiftAssistedControlPlaneStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_OpenshiftAssistedControlPlaneStatus_To_v1alpha2_OpenshiftAssistedControlPlaneStatus(a.(*v1beta1.OpenshiftAssistedControlPlaneStatus), b.(*OpenshiftAssistedControlPlaneStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1beta1.OpenshiftAssistedControlPlaneTemplateResourceSpec)(nil), (*OpenshiftAssistedControlPlaneTemplateResourceSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_OpenshiftAssistedControlPlaneTemplateResourceSpec_To_v1alpha2_OpenshiftAssistedControlPlaneTemplateResourceSpec(a.(*v1beta1.OpenshiftAssistedControlPlaneTemplateResourceSpec), b.(*OpenshiftAssistedControlPlaneTemplateResourceSpec), scope)
+	}); err != nil {
+		return err
+	}
+	return nil
+}
+
+func autoConvert_v1alpha2_Capabilities_To_v1beta1_Capabilities(in *Capabilities, out *v1beta1.Capabilities, s conversion.Scope) error {
+	out.BaselineCapability = in.BaselineCapability
+	out.AdditionalEnabledCapabilities = *(*[]string)(unsafe.Pointer(&in.AdditionalEnabledCapabilities))
+	return nil
+}
+
+// Convert_v1alpha2_Capabilities_To_v1beta1_Capabilities is an autogenerated conversion function.
+func Convert_v1alpha2_Capabilities_To_v1beta1_Capabilities(in *Capabilities, out *v1beta1.Capabilities, s conversion.Scope) error {
+	return autoConvert_v1alpha2_Capabilities_To_v1beta1_Capabilities(in, out, s)
+}
+
+func autoConvert_v1beta1_Capabilities_To_v1alpha2_Capabilities(in *v1beta1.Capabilities, out *Capabilities, s conversion.Scope) error {
+	out.BaselineCapability = in.BaselineCapability
+	out.AdditionalEnabledCapabilities = *(*[]string)(unsafe.Pointer(&in.AdditionalEnabledCapabilities))
+	return nil
+}
+
+// Convert_v1beta1_Capabilities_To_v1alpha2_Capabilities is an autogenerated conversion function.
+func Convert_v1beta1_Capabilities_To_v1alpha2_Capabilities(in *v1beta1.Capabilities, out *Capabilities, s conversion.Scope) error {
+	return autoConvert_v1beta1_Capabilities_To_v1alpha2_Capabilities(in, out, s)
+}
+
+func autoConvert_v1alpha2_OpenshiftAssistedControlPlane_To_v1beta1_OpenshiftAssistedControlPlane(in *OpenshiftAssistedControlPlane, out *v1beta1.OpenshiftAssistedControlPlane, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1alpha2_OpenshiftAssistedControlPlaneSpec_To_v1beta1_OpenshiftAssistedControlPlaneSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha2_OpenshiftAssistedControlPlaneStatus_To_v1beta1_OpenshiftAssistedControlPlaneStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha2_OpenshiftAssistedControlPlane_To_v1beta1_OpenshiftAssistedControlPlane is an autogenerated conversion function.
+func Convert_v1alpha2_OpenshiftAssistedControlPlane_To_v1beta1_OpenshiftAssistedControlPlane(in *OpenshiftAssistedControlPlane, out *v1beta1.OpenshiftAssistedControlPlane, s conversion.Scope) error {
+	return autoConvert_v1alpha2_OpenshiftAssistedControlPlane_To_v1beta1_OpenshiftAssistedControlPlane(in, out, s)
+}
+
+func autoConvert_v1beta1_OpenshiftAssistedControlPlane_To_v1alpha2_OpenshiftAssistedControlPlane(in *v1beta1.OpenshiftAssistedControlPlane, out *OpenshiftAssistedControlPlane, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1beta1_OpenshiftAssistedControlPlaneSpec_To_v1alpha2_OpenshiftAssistedControlPlaneSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_v1beta1_OpenshiftAssistedControlPlaneStatus_To_v1alpha2_OpenshiftAssistedControlPlaneStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_OpenshiftAssistedControlPlane_To_v1alpha2_OpenshiftAssistedControlPlane is an autogenerated conversion function.
+func Convert_v1beta1_OpenshiftAssistedControlPlane_To_v1alpha2_OpenshiftAssistedControlPlane(in *v1beta1.OpenshiftAssistedControlPlane, out *OpenshiftAssistedControlPlane, s conversion.Scope) error {
+	return autoConvert_v1beta1_OpenshiftAssistedControlPlane_To_v1alpha2_OpenshiftAssistedControlPlane(in, out, s)
+}
+
+func autoConvert_v1alpha2_OpenshiftAssistedControlPlaneConfigSpec_To_v1beta1_OpenshiftAssistedControlPlaneConfigSpec(in *OpenshiftAssistedControlPlaneConfigSpec, out *v1beta1.OpenshiftAssistedControlPlaneConfigSpec, s conversion.Scope) error {
+	out.APIVIPs = *(*[]string)(unsafe.Pointer(&in.APIVIPs))
+	out.IngressVIPs = *(*[]string)(unsafe.Pointer(&in.IngressVIPs))
+	out.ManifestsConfigMapRefs = *(*[]hiveextensionv1beta1.ManifestsConfigMapReference)(unsafe.Pointer(&in.ManifestsConfigMapRefs))
+	out.DiskEncryption = (*hiveextensionv1beta1.DiskEncryption)(unsafe.Pointer(in.DiskEncryption))
+	out.Proxy = (*hiveextensionv1beta1.Proxy)(unsafe.Pointer(in.Proxy))
+	out.MastersSchedulable = in.MastersSchedulable
+	out.SSHAuthorizedKey = in.SSHAuthorizedKey
+	out.ClusterName = in.ClusterName
+	out.BaseDomain = in.BaseDomain
+	out.PullSecretRef = (*v1.LocalObjectReference)(unsafe.Pointer(in.PullSecretRef))
+	out.ImageRegistryRef = (*v1.LocalObjectReference)(unsafe.Pointer(in.ImageRegistryRef))
+	if err := Convert_v1alpha2_Capabilities_To_v1beta1_Capabilities(&in.Capabilities, &out.Capabilities, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha2_OpenshiftAssistedControlPlaneConfigSpec_To_v1beta1_OpenshiftAssistedControlPlaneConfigSpec is an autogenerated conversion function.
+func Convert_v1alpha2_OpenshiftAssistedControlPlaneConfigSpec_To_v1beta1_OpenshiftAssistedControlPlaneConfigSpec(in *OpenshiftAssistedControlPlaneConfigSpec, out *v1beta1.OpenshiftAssistedControlPlaneConfigSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_OpenshiftAssistedControlPlaneConfigSpec_To_v1beta1_OpenshiftAssistedControlPlaneConfigSpec(in, out, s)
+}
+
+func autoConvert_v1beta1_OpenshiftAssistedControlPlaneConfigSpec_To_v1alpha2_OpenshiftAssistedControlPlaneConfigSpec(in *v1beta1.OpenshiftAssistedControlPlaneConfigSpec, out *OpenshiftAssistedControlPlaneConfigSpec, s conversion.Scope) error {
+	out.APIVIPs = *(*[]string)(unsafe.Pointer(&in.APIVIPs))
+	out.IngressVIPs = *(*[]string)(unsafe.Pointer(&in.IngressVIPs))
+	out.ManifestsConfigMapRefs = *(*[]hiveextensionv1beta1.ManifestsConfigMapReference)(unsafe.Pointer(&in.ManifestsConfigMapRefs))
+	out.DiskEncryption = (*hiveextensionv1beta1.DiskEncryption)(unsafe.Pointer(in.DiskEncryption))
+	out.Proxy = (*hiveextensionv1beta1.Proxy)(unsafe.Pointer(in.Proxy))
+	out.MastersSchedulable = in.MastersSchedulable
+	out.SSHAuthorizedKey = in.SSHAuthorizedKey
+	out.ClusterName = in.ClusterName
+	out.BaseDomain = in.BaseDomain
+	out.PullSecretRef = (*v1.LocalObjectReference)(unsafe.Pointer(in.PullSecretRef))
+	out.ImageRegistryRef = (*v1.LocalObjectReference)(unsafe.Pointer(in.ImageRegistryRef))
+	if err := Convert_v1beta1_Capabilities_To_v1alpha2_Capabilities(&in.Capabilities, &out.Capabilities, s); err != nil {
+		return err
+	}
+	// WARNING: in.Networking requires manual conversion: does not exist in peer-type
+	return nil
+}
+
+func autoConvert_v1alpha2_OpenshiftAssistedControlPlaneList_To_v1beta1_OpenshiftAssistedControlPlaneList(in *OpenshiftAssistedControlPlaneList, out *v1beta1.OpenshiftAssistedControlPlaneList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]v1beta1.OpenshiftAssistedControlPlane, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha2_OpenshiftAssistedControlPlane_To_v1beta1_OpenshiftAssistedControlPlane(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha2_OpenshiftAssistedControlPlaneList_To_v1beta1_OpenshiftAssistedControlPlaneList is an autogenerated conversion function.
+func Convert_v1alpha2_OpenshiftAssistedControlPlaneList_To_v1beta1_OpenshiftAssistedControlPlaneList(in *OpenshiftAssistedControlPlaneList, out *v1beta1.OpenshiftAssistedControlPlaneList, s conversion.Scope) error {
+	return autoConvert_v1alpha2_OpenshiftAssistedControlPlaneList_To_v1beta1_OpenshiftAssistedControlPlaneList(in, out, s)
+}
+
+func autoConvert_v1beta1_OpenshiftAssistedControlPlaneList_To_v1alpha2_OpenshiftAssistedControlPlaneList(in *v1beta1.OpenshiftAssistedControlPlaneList, out *OpenshiftAssistedControlPlaneList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OpenshiftAssistedControlPlane, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_OpenshiftAssistedControlPlane_To_v1alpha2_OpenshiftAssistedControlPlane(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+// Convert_v1beta1_OpenshiftAssistedControlPlaneList_To_v1alpha2_OpenshiftAssistedControlPlaneList is an autogenerated conversion function.
+func Convert_v1beta1_OpenshiftAssistedControlPlaneList_To_v1alpha2_OpenshiftAssistedControlPlaneList(in *v1beta1.OpenshiftAssistedControlPlaneList, out *OpenshiftAssistedControlPlaneList, s conversion.Scope) error {
+	return autoConvert_v1beta1_OpenshiftAssistedControlPlaneList_To_v1alpha2_OpenshiftAssistedControlPlaneList(in, out, s)
+}
+
+func autoConvert_v1alpha2_OpenshiftAssistedControlPlaneMachineTemplate_To_v1beta1_OpenshiftAssistedControlPlaneMachineTemplate(in *OpenshiftAssistedControlPlaneMachineTemplate, out *v1beta1.OpenshiftAssistedControlPlaneMachineTemplate, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	out.InfrastructureRef = in.InfrastructureRef
+	out.NodeDrainTimeout = (*metav1.Duration)(unsafe.Pointer(in.NodeDrainTimeout))
+	out.NodeVolumeDetachTimeout = (*metav1.Duration)(unsafe.Pointer(in.NodeVolumeDetachTimeout))
+	out.NodeDeletionTimeout = (*metav1.Duration)(unsafe.Pointer(in.NodeDeletionTimeout))
+	return nil
+}
+
+// Convert_v1alpha2_OpenshiftAssistedControlPlaneMachineTemplate_To_v1beta1_OpenshiftAssistedControlPlaneMachineTemplate is an autogenerated conversion function.
+func Convert_v1alpha2_OpenshiftAssistedControlPlaneMachineTemplate_To_v1beta1_OpenshiftAssistedControlPlaneMachineTemplate(in *OpenshiftAssistedControlPlaneMachineTemplate, out *v1beta1.OpenshiftAssistedControlPlaneMachineTemplate, s conversion.Scope) error {
+	return autoConvert_v1alpha2_OpenshiftAssistedControlPlaneMachineTemplate_To_v1beta1_OpenshiftAssistedControlPlaneMachineTemplate(in, out, s)
+}
+
+func autoConvert_v1beta1_OpenshiftAssistedControlPlaneMachineTemplate_To_v1alpha2_OpenshiftAssistedControlPlaneMachineTemplate(in *v1beta1.OpenshiftAssistedControlPlaneMachineTemplate, out *OpenshiftAssistedControlPlaneMachineTemplate, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	out.InfrastructureRef = in.InfrastructureRef
+	out.NodeDrainTimeout = (*metav1.Duration)(unsafe.Pointer(in.NodeDrainTimeout))
+	out.NodeVolumeDetachTimeout = (*metav1.Duration)(unsafe.Pointer(in.NodeVolumeDetachTimeout))
+	out.NodeDeletionTimeout = (*metav1.Duration)(unsafe.Pointer(in.NodeDeletionTimeout))
+	return nil
+}
+
+// Convert_v1beta1_OpenshiftAssistedControlPlaneMachineTemplate_To_v1alpha2_OpenshiftAssistedControlPlaneMachineTemplate is an autogenerated conversion function.
+func Convert_v1beta1_OpenshiftAssistedControlPlaneMachineTemplate_To_v1alpha2_OpenshiftAssistedControlPlaneMachineTemplate(in *v1beta1.OpenshiftAssistedControlPlaneMachineTemplate, out *OpenshiftAssistedControlPlaneMachineTemplate, s conversion.Scope) error {
+	return autoConvert_v1beta1_OpenshiftAssistedControlPlaneMachineTemplate_To_v1alpha2_OpenshiftAssistedControlPlaneMachineTemplate(in, out, s)
+}
+
+func autoConvert_v1alpha2_OpenshiftAssistedControlPlaneSpec_To_v1beta1_OpenshiftAssistedControlPlaneSpec(in *OpenshiftAssistedControlPlaneSpec, out *v1beta1.OpenshiftAssistedControlPlaneSpec, s conversion.Scope) error {
+	if err := Convert_v1alpha2_OpenshiftAssistedControlPlaneConfigSpec_To_v1beta1_OpenshiftAssistedControlPlaneConfigSpec(&in.Config, &out.Config, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha2_OpenshiftAssistedControlPlaneMachineTemplate_To_v1beta1_OpenshiftAssistedControlPlaneMachineTemplate(&in.MachineTemplate, &out.MachineTemplate, s); err != nil {
+		return err
+	}
+	if err := v1alpha1.Convert_v1alpha1_OpenshiftAssistedConfigSpec_To_v1beta1_OpenshiftAssistedConfigSpec(&in.OpenshiftAssistedConfigSpec, &out.OpenshiftAssistedConfigSpec, s); err != nil {
+		return err
+	}
+	out.Replicas = in.Replicas
+	out.DistributionVersion = in.DistributionVersion
+	out.Version = in.Version
+	return nil
+}
+
+// Convert_v1alpha2_OpenshiftAssistedControlPlaneSpec_To_v1beta1_OpenshiftAssistedControlPlaneSpec is an autogenerated conversion function.
+func Convert_v1alpha2_OpenshiftAssistedControlPlaneSpec_To_v1beta1_OpenshiftAssistedControlPlaneSpec(in *OpenshiftAssistedControlPlaneSpec, out *v1beta1.OpenshiftAssistedControlPlaneSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_OpenshiftAssistedControlPlaneSpec_To_v1beta1_OpenshiftAssistedControlPlaneSpec(in, out, s)
+}
+
+func autoConvert_v1beta1_OpenshiftAssistedControlPlaneSpec_To_v1alpha2_OpenshiftAssistedControlPlaneSpec(in *v1beta1.OpenshiftAssistedControlPlaneSpec, out *OpenshiftAssistedControlPlaneSpec, s conversion.Scope) error {
+	if err := Convert_v1beta1_OpenshiftAssistedControlPlaneConfigSpec_To_v1alpha2_OpenshiftAssistedControlPlaneConfigSpec(&in.Config, &out.Config, s); err != nil {
+		return err
+	}
+	if err := Convert_v1beta1_OpenshiftAssistedControlPlaneMachineTemplate_To_v1alpha2_OpenshiftAssistedControlPlaneMachineTemplate(&in.MachineTemplate, &out.MachineTemplate, s); err != nil {
+		return err
+	}
+	if err := v1alpha1.Convert_v1beta1_OpenshiftAssistedConfigSpec_To_v1alpha1_OpenshiftAssistedConfigSpec(&in.OpenshiftAssistedConfigSpec, &out.OpenshiftAssistedConfigSpec, s); err != nil {
+		return err
+	}
+	out.Replicas = in.Replicas
+	out.DistributionVersion = in.DistributionVersion
+	out.Version = in.Version
+	// WARNING: in.RolloutAfter requires manual conversion: does not exist in peer-type
+	// WARNING: in.RolloutStrategy requires manual conversion: does not exist in peer-type
+	// WARNING: in.RemediationStrategy requires manual conversion: does not exist in peer-type
+	// WARNING: in.UpgradeStrategy requires manual conversion: does not exist in peer-type
+	// WARNING: in.ControlPlaneEndpoint requires manual conversion: does not exist in peer-type
+	return nil
+}
+
+func autoConvert_v1alpha2_OpenshiftAssistedControlPlaneStatus_To_v1beta1_OpenshiftAssistedControlPlaneStatus(in *OpenshiftAssistedControlPlaneStatus, out *v1beta1.OpenshiftAssistedControlPlaneStatus, s conversion.Scope) error {
+	out.ClusterDeploymentRef = (*v1.ObjectReference)(unsafe.Pointer(in.ClusterDeploymentRef))
+	out.Selector = in.Selector
+	out.Replicas = in.Replicas
+	out.Version = (*string)(unsafe.Pointer(in.Version))
+	out.DistributionVersion = in.DistributionVersion
+	out.UpdatedReplicas = in.UpdatedReplicas
+	out.ReadyReplicas = in.ReadyReplicas
+	out.UnavailableReplicas = in.UnavailableReplicas
+	out.Initialized = in.Initialized
+	out.Ready = in.Ready
+	out.FailureReason = (*string)(unsafe.Pointer(in.FailureReason))
+	out.FailureMessage = (*string)(unsafe.Pointer(in.FailureMessage))
+	out.ObservedGeneration = in.ObservedGeneration
+	out.Conditions = *(*apiv1beta1.Conditions)(unsafe.Pointer(&in.Conditions))
+	return nil
+}
+
+// Convert_v1alpha2_OpenshiftAssistedControlPlaneStatus_To_v1beta1_OpenshiftAssistedControlPlaneStatus is an autogenerated conversion function.
+func Convert_v1alpha2_OpenshiftAssistedControlPlaneStatus_To_v1beta1_OpenshiftAssistedControlPlaneStatus(in *OpenshiftAssistedControlPlaneStatus, out *v1beta1.OpenshiftAssistedControlPlaneStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha2_OpenshiftAssistedControlPlaneStatus_To_v1beta1_OpenshiftAssistedControlPlaneStatus(in, out, s)
+}
+
+func autoConvert_v1beta1_OpenshiftAssistedControlPlaneStatus_To_v1alpha2_OpenshiftAssistedControlPlaneStatus(in *v1beta1.OpenshiftAssistedControlPlaneStatus, out *OpenshiftAssistedControlPlaneStatus, s conversion.Scope) error {
+	out.ClusterDeploymentRef = (*v1.ObjectReference)(unsafe.Pointer(in.ClusterDeploymentRef))
+	out.Selector = in.Selector
+	out.Replicas = in.Replicas
+	out.Version = (*string)(unsafe.Pointer(in.Version))
+	out.DistributionVersion = in.DistributionVersion
+	out.UpdatedReplicas = in.UpdatedReplicas
+	out.ReadyReplicas = in.ReadyReplicas
+	out.UnavailableReplicas = in.UnavailableReplicas
+	out.Initialized = in.Initialized
+	out.Ready = in.Ready
+	out.FailureReason = (*string)(unsafe.Pointer(in.FailureReason))
+	out.FailureMessage = (*string)(unsafe.Pointer(in.FailureMessage))
+	out.ObservedGeneration = in.ObservedGeneration
+	out.Conditions = *(*apiv1beta1.Conditions)(unsafe.Pointer(&in.Conditions))
+	// WARNING: in.LastRemediation requires manual conversion: does not exist in peer-type
+	// WARNING: in.UpgradeHistory requires manual conversion: does not exist in peer-type
+	// WARNING: in.ClusterID requires manual conversion: does not exist in peer-type
+	// WARNING: in.InfraID requires manual conversion: does not exist in peer-type
+	// WARNING: in.APIURL requires manual conversion: does not exist in peer-type
+	// WARNING: in.ConsoleURL requires manual conversion: does not exist in peer-type
+	// WARNING: in.InstallationProgress requires manual conversion: does not exist in peer-type
+	// WARNING: in.Initialization requires manual conversion: does not exist in peer-type
+	// WARNING: in.V1Beta2 requires manual conversion: does not exist in peer-type
+	return nil
+}
+
+func autoConvert_v1alpha2_OpenshiftAssistedControlPlaneTemplate_To_v1beta1_OpenshiftAssistedControlPlaneTemplate(in *OpenshiftAssistedControlPlaneTemplate, out *v1beta1.OpenshiftAssistedControlPlaneTemplate, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1alpha2_OpenshiftAssistedControlPlaneTemplateSpec_To_v1beta1_OpenshiftAssistedControlPlaneTemplateSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha2_OpenshiftAssistedControlPlaneTemplate_To_v1beta1_OpenshiftAssistedControlPlaneTemplate is an autogenerated conversion function.
+func Convert_v1alpha2_OpenshiftAssistedControlPlaneTemplate_To_v1beta1_OpenshiftAssistedControlPlaneTemplate(in *OpenshiftAssistedControlPlaneTemplate, out *v1beta1.OpenshiftAssistedControlPlaneTemplate, s conversion.Scope) error {
+	return autoConvert_v1alpha2_OpenshiftAssistedControlPlaneTemplate_To_v1beta1_OpenshiftAssistedControlPlaneTemplate(in, out, s)
+}
+
+func autoConvert_v1beta1_OpenshiftAssistedControlPlaneTemplate_To_v1alpha2_OpenshiftAssistedControlPlaneTemplate(in *v1beta1.OpenshiftAssistedControlPlaneTemplate, out *OpenshiftAssistedControlPlaneTemplate, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1beta1_OpenshiftAssistedControlPlaneTemplateSpec_To_v1alpha2_OpenshiftAssistedControlPlaneTemplateSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_OpenshiftAssistedControlPlaneTemplate_To_v1alpha2_OpenshiftAssistedControlPlaneTemplate is an autogenerated conversion function.
+func Convert_v1beta1_OpenshiftAssistedControlPlaneTemplate_To_v1alpha2_OpenshiftAssistedControlPlaneTemplate(in *v1beta1.OpenshiftAssistedControlPlaneTemplate, out *OpenshiftAssistedControlPlaneTemplate, s conversion.Scope) error {
+	return autoConvert_v1beta1_OpenshiftAssistedControlPlaneTemplate_To_v1alpha2_OpenshiftAssistedControlPlaneTemplate(in, out, s)
+}
+
+func autoConvert_v1alpha2_OpenshiftAssistedControlPlaneTemplateList_To_v1beta1_OpenshiftAssistedControlPlaneTemplateList(in *OpenshiftAssistedControlPlaneTemplateList, out *v1beta1.OpenshiftAssistedControlPlaneTemplateList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]v1beta1.OpenshiftAssistedControlPlaneTemplate, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha2_OpenshiftAssistedControlPlaneTemplate_To_v1beta1_OpenshiftAssistedControlPlaneTemplate(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha2_OpenshiftAssistedControlPlaneTemplateList_To_v1beta1_OpenshiftAssistedControlPlaneTemplateList is an autogenerated conversion function.
+func Convert_v1alpha2_OpenshiftAssistedControlPlaneTemplateList_To_v1beta1_OpenshiftAssistedControlPlaneTemplateList(in *OpenshiftAssistedControlPlaneTemplateList, out *v1beta1.OpenshiftAssistedControlPlaneTemplateList, s conversion.Scope) error {
+	return autoConvert_v1alpha2_OpenshiftAssistedControlPlaneTemplateList_To_v1beta1_OpenshiftAssistedControlPlaneTemplateList(in, out, s)
+}
+
+func autoConvert_v1beta1_OpenshiftAssistedControlPlaneTemplateList_To_v1alpha2_OpenshiftAssistedControlPlaneTemplateList(in *v1beta1.OpenshiftAssistedControlPlaneTemplateList, out *OpenshiftAssistedControlPlaneTemplateList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OpenshiftAssistedControlPlaneTemplate, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_OpenshiftAssistedControlPlaneTemplate_To_v1alpha2_OpenshiftAssistedControlPlaneTemplate(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+// Convert_v1beta1_OpenshiftAssistedControlPlaneTemplateList_To_v1alpha2_OpenshiftAssistedControlPlaneTemplateList is an autogenerated conversion function.
+func Convert_v1beta1_OpenshiftAssistedControlPlaneTemplateList_To_v1alpha2_OpenshiftAssistedControlPlaneTemplateList(in *v1beta1.OpenshiftAssistedControlPlaneTemplateList, out *OpenshiftAssistedControlPlaneTemplateList, s conversion.Scope) error {
+	return autoConvert_v1beta1_OpenshiftAssistedControlPlaneTemplateList_To_v1alpha2_OpenshiftAssistedControlPlaneTemplateList(in, out, s)
+}
+
+func autoConvert_v1alpha2_OpenshiftAssistedControlPlaneTemplateMachineTemplate_To_v1beta1_OpenshiftAssistedControlPlaneTemplateMachineTemplate(in *OpenshiftAssistedControlPlaneTemplateMachineTemplate, out *v1beta1.OpenshiftAssistedControlPlaneTemplateMachineTemplate, s conversion.Scope) error {
+	out.NodeDrainTimeout = (*metav1.Duration)(unsafe.Pointer(in.NodeDrainTimeout))
+	out.NodeVolumeDetachTimeout = (*metav1.Duration)(unsafe.Pointer(in.NodeVolumeDetachTimeout))
+	out.NodeDeletionTimeout = (*metav1.Duration)(unsafe.Pointer(in.NodeDeletionTimeout))
+	return nil
+}
+
+// Convert_v1alpha2_OpenshiftAssistedControlPlaneTemplateMachineTemplate_To_v1beta1_OpenshiftAssistedControlPlaneTemplateMachineTemplate is an autogenerated conversion function.
+func Convert_v1alpha2_OpenshiftAssistedControlPlaneTemplateMachineTemplate_To_v1beta1_OpenshiftAssistedControlPlaneTemplateMachineTemplate(in *OpenshiftAssistedControlPlaneTemplateMachineTemplate, out *v1beta1.OpenshiftAssistedControlPlaneTemplateMachineTemplate, s conversion.Scope) error {
+	return autoConvert_v1alpha2_OpenshiftAssistedControlPlaneTemplateMachineTemplate_To_v1beta1_OpenshiftAssistedControlPlaneTemplateMachineTemplate(in, out, s)
+}
+
+func autoConvert_v1beta1_OpenshiftAssistedControlPlaneTemplateMachineTemplate_To_v1alpha2_OpenshiftAssistedControlPlaneTemplateMachineTemplate(in *v1beta1.OpenshiftAssistedControlPlaneTemplateMachineTemplate, out *OpenshiftAssistedControlPlaneTemplateMachineTemplate, s conversion.Scope) error {
+	out.NodeDrainTimeout = (*metav1.Duration)(unsafe.Pointer(in.NodeDrainTimeout))
+	out.NodeVolumeDetachTimeout = (*metav1.Duration)(unsafe.Pointer(in.NodeVolumeDetachTimeout))
+	out.NodeDeletionTimeout = (*metav1.Duration)(unsafe.Pointer(in.NodeDeletionTimeout))
+	return nil
+}
+
+// Convert_v1beta1_OpenshiftAssistedControlPlaneTemplateMachineTemplate_To_v1alpha2_OpenshiftAssistedControlPlaneTemplateMachineTemplate is an autogenerated conversion function.
+func Convert_v1beta1_OpenshiftAssistedControlPlaneTemplateMachineTemplate_To_v1alpha2_OpenshiftAssistedControlPlaneTemplateMachineTemplate(in *v1beta1.OpenshiftAssistedControlPlaneTemplateMachineTemplate, out *OpenshiftAssistedControlPlaneTemplateMachineTemplate, s conversion.Scope) error {
+	return autoConvert_v1beta1_OpenshiftAssistedControlPlaneTemplateMachineTemplate_To_v1alpha2_OpenshiftAssistedControlPlaneTemplateMachineTemplate(in, out, s)
+}
+
+func autoConvert_v1alpha2_OpenshiftAssistedControlPlaneTemplateResource_To_v1beta1_OpenshiftAssistedControlPlaneTemplateResource(in *OpenshiftAssistedControlPlaneTemplateResource, out *v1beta1.OpenshiftAssistedControlPlaneTemplateResource, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1alpha2_OpenshiftAssistedControlPlaneTemplateResourceSpec_To_v1beta1_OpenshiftAssistedControlPlaneTemplateResourceSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha2_OpenshiftAssistedControlPlaneTemplateResource_To_v1beta1_OpenshiftAssistedControlPlaneTemplateResource is an autogenerated conversion function.
+func Convert_v1alpha2_OpenshiftAssistedControlPlaneTemplateResource_To_v1beta1_OpenshiftAssistedControlPlaneTemplateResource(in *OpenshiftAssistedControlPlaneTemplateResource, out *v1beta1.OpenshiftAssistedControlPlaneTemplateResource, s conversion.Scope) error {
+	return autoConvert_v1alpha2_OpenshiftAssistedControlPlaneTemplateResource_To_v1beta1_OpenshiftAssistedControlPlaneTemplateResource(in, out, s)
+}
+
+func autoConvert_v1beta1_OpenshiftAssistedControlPlaneTemplateResource_To_v1alpha2_OpenshiftAssistedControlPlaneTemplateResource(in *v1beta1.OpenshiftAssistedControlPlaneTemplateResource, out *OpenshiftAssistedControlPlaneTemplateResource, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1beta1_OpenshiftAssistedControlPlaneTemplateResourceSpec_To_v1alpha2_OpenshiftAssistedControlPlaneTemplateResourceSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_OpenshiftAssistedControlPlaneTemplateResource_To_v1alpha2_OpenshiftAssistedControlPlaneTemplateResource is an autogenerated conversion function.
+func Convert_v1beta1_OpenshiftAssistedControlPlaneTemplateResource_To_v1alpha2_OpenshiftAssistedControlPlaneTemplateResource(in *v1beta1.OpenshiftAssistedControlPlaneTemplateResource, out *OpenshiftAssistedControlPlaneTemplateResource, s conversion.Scope) error {
+	return autoConvert_v1beta1_OpenshiftAssistedControlPlaneTemplateResource_To_v1alpha2_OpenshiftAssistedControlPlaneTemplateResource(in, out, s)
+}
+
+func autoConvert_v1alpha2_OpenshiftAssistedControlPlaneTemplateResourceSpec_To_v1beta1_OpenshiftAssistedControlPlaneTemplateResourceSpec(in *OpenshiftAssistedControlPlaneTemplateResourceSpec, out *v1beta1.OpenshiftAssistedControlPlaneTemplateResourceSpec, s conversion.Scope) error {
+	if err := Convert_v1alpha2_OpenshiftAssistedControlPlaneConfigSpec_To_v1beta1_OpenshiftAssistedControlPlaneConfigSpec(&in.Config, &out.Config, s); err != nil {
+		return err
+	}
+	out.MachineTemplate = (*v1beta1.OpenshiftAssistedControlPlaneTemplateMachineTemplate)(unsafe.Pointer(in.MachineTemplate))
+	if err := v1alpha1.Convert_v1alpha1_OpenshiftAssistedConfigSpec_To_v1beta1_OpenshiftAssistedConfigSpec(&in.OpenshiftAssistedConfigSpec, &out.OpenshiftAssistedConfigSpec, s); err != nil {
+		return err
+	}
+	out.DistributionVersion = in.DistributionVersion
+	return nil
+}
+
+// Convert_v1alpha2_OpenshiftAssistedControlPlaneTemplateResourceSpec_To_v1beta1_OpenshiftAssistedControlPlaneTemplateResourceSpec is an autogenerated conversion function.
+func Convert_v1alpha2_OpenshiftAssistedControlPlaneTemplateResourceSpec_To_v1beta1_OpenshiftAssistedControlPlaneTemplateResourceSpec(in *OpenshiftAssistedControlPlaneTemplateResourceSpec, out *v1beta1.OpenshiftAssistedControlPlaneTemplateResourceSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_OpenshiftAssistedControlPlaneTemplateResourceSpec_To_v1beta1_OpenshiftAssistedControlPlaneTemplateResourceSpec(in, out, s)
+}
+
+func autoConvert_v1beta1_OpenshiftAssistedControlPlaneTemplateResourceSpec_To_v1alpha2_OpenshiftAssistedControlPlaneTemplateResourceSpec(in *v1beta1.OpenshiftAssistedControlPlaneTemplateResourceSpec, out *OpenshiftAssistedControlPlaneTemplateResourceSpec, s conversion.Scope) error {
+	if err := Convert_v1beta1_OpenshiftAssistedControlPlaneConfigSpec_To_v1alpha2_OpenshiftAssistedControlPlaneConfigSpec(&in.Config, &out.Config, s); err != nil {
+		return err
+	}
+	out.MachineTemplate = (*OpenshiftAssistedControlPlaneTemplateMachineTemplate)(unsafe.Pointer(in.MachineTemplate))
+	if err := v1alpha1.Convert_v1beta1_OpenshiftAssistedConfigSpec_To_v1alpha1_OpenshiftAssistedConfigSpec(&in.OpenshiftAssistedConfigSpec, &out.OpenshiftAssistedConfigSpec, s); err != nil {
+		return err
+	}
+	out.DistributionVersion = in.DistributionVersion
+	// WARNING: in.RolloutAfter requires manual conversion: does not exist in peer-type
+	// WARNING: in.RolloutStrategy requires manual conversion: does not exist in peer-type
+	// WARNING: in.RemediationStrategy requires manual conversion: does not exist in peer-type
+	// WARNING: in.UpgradeStrategy requires manual conversion: does not exist in peer-type
+	return nil
+}
+
+func autoConvert_v1alpha2_OpenshiftAssistedControlPlaneTemplateSpec_To_v1beta1_OpenshiftAssistedControlPlaneTemplateSpec(in *OpenshiftAssistedControlPlaneTemplateSpec, out *v1beta1.OpenshiftAssistedControlPlaneTemplateSpec, s conversion.Scope) error {
+	if err := Convert_v1alpha2_OpenshiftAssistedControlPlaneTemplateResource_To_v1beta1_OpenshiftAssistedControlPlaneTemplateResource(&in.Template, &out.Template, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha2_OpenshiftAssistedControlPlaneTemplateSpec_To_v1beta1_OpenshiftAssistedControlPlaneTemplateSpec is an autogenerated conversion function.
+func Convert_v1alpha2_OpenshiftAssistedControlPlaneTemplateSpec_To_v1beta1_OpenshiftAssistedControlPlaneTemplateSpec(in *OpenshiftAssistedControlPlaneTemplateSpec, out *v1beta1.OpenshiftAssistedControlPlaneTemplateSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_OpenshiftAssistedControlPlaneTemplateSpec_To_v1beta1_OpenshiftAssistedControlPlaneTemplateSpec(in, out, s)
+}
+
+func autoConvert_v1beta1_OpenshiftAssistedControlPlaneTemplateSpec_To_v1alpha2_OpenshiftAssistedControlPlaneTemplateSpec(in *v1beta1.OpenshiftAssistedControlPlaneTemplateSpec, out *OpenshiftAssistedControlPlaneTemplateSpec, s conversion.Scope) error {
+	if err := Convert_v1beta1_OpenshiftAssistedControlPlaneTemplateResource_To_v1alpha2_OpenshiftAssistedControlPlaneTemplateResource(&in.Template, &out.Template, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_OpenshiftAssistedControlPlaneTemplateSpec_To_v1alpha2_OpenshiftAssistedControlPlaneTemplateSpec is an autogenerated conversion function.
+func Convert_v1beta1_OpenshiftAssistedControlPlaneTemplateSpec_To_v1alpha2_OpenshiftAssistedControlPlaneTemplateSpec(in *v1beta1.OpenshiftAssistedControlPlaneTemplateSpec, out *OpenshiftAssistedControlPlaneTemplateSpec, s conversion.Scope) error {
+	return autoConvert_v1beta1_OpenshiftAssistedControlPlaneTemplateSpec_To_v1alpha2_OpenshiftAssistedControlPlaneTemplateSpec(in, out, s)
+}
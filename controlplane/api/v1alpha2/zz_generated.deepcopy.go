@@ -24,7 +24,7 @@ import (
 	"github.com/openshift/assisted-service/api/hiveextension/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
-	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime"
 	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
@@ -249,3 +249,146 @@ func (in *OpenshiftAssistedControlPlaneStatus) DeepCopy() *OpenshiftAssistedCont
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenshiftAssistedControlPlaneTemplate) DeepCopyInto(out *OpenshiftAssistedControlPlaneTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenshiftAssistedControlPlaneTemplate.
+func (in *OpenshiftAssistedControlPlaneTemplate) DeepCopy() *OpenshiftAssistedControlPlaneTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenshiftAssistedControlPlaneTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OpenshiftAssistedControlPlaneTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenshiftAssistedControlPlaneTemplateList) DeepCopyInto(out *OpenshiftAssistedControlPlaneTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OpenshiftAssistedControlPlaneTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenshiftAssistedControlPlaneTemplateList.
+func (in *OpenshiftAssistedControlPlaneTemplateList) DeepCopy() *OpenshiftAssistedControlPlaneTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenshiftAssistedControlPlaneTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OpenshiftAssistedControlPlaneTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenshiftAssistedControlPlaneTemplateMachineTemplate) DeepCopyInto(out *OpenshiftAssistedControlPlaneTemplateMachineTemplate) {
+	*out = *in
+	if in.NodeDrainTimeout != nil {
+		in, out := &in.NodeDrainTimeout, &out.NodeDrainTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.NodeVolumeDetachTimeout != nil {
+		in, out := &in.NodeVolumeDetachTimeout, &out.NodeVolumeDetachTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.NodeDeletionTimeout != nil {
+		in, out := &in.NodeDeletionTimeout, &out.NodeDeletionTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenshiftAssistedControlPlaneTemplateMachineTemplate.
+func (in *OpenshiftAssistedControlPlaneTemplateMachineTemplate) DeepCopy() *OpenshiftAssistedControlPlaneTemplateMachineTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenshiftAssistedControlPlaneTemplateMachineTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenshiftAssistedControlPlaneTemplateResource) DeepCopyInto(out *OpenshiftAssistedControlPlaneTemplateResource) {
+	*out = *in
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenshiftAssistedControlPlaneTemplateResource.
+func (in *OpenshiftAssistedControlPlaneTemplateResource) DeepCopy() *OpenshiftAssistedControlPlaneTemplateResource {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenshiftAssistedControlPlaneTemplateResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenshiftAssistedControlPlaneTemplateResourceSpec) DeepCopyInto(out *OpenshiftAssistedControlPlaneTemplateResourceSpec) {
+	*out = *in
+	in.Config.DeepCopyInto(&out.Config)
+	if in.MachineTemplate != nil {
+		in, out := &in.MachineTemplate, &out.MachineTemplate
+		*out = new(OpenshiftAssistedControlPlaneTemplateMachineTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+	in.OpenshiftAssistedConfigSpec.DeepCopyInto(&out.OpenshiftAssistedConfigSpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenshiftAssistedControlPlaneTemplateResourceSpec.
+func (in *OpenshiftAssistedControlPlaneTemplateResourceSpec) DeepCopy() *OpenshiftAssistedControlPlaneTemplateResourceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenshiftAssistedControlPlaneTemplateResourceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenshiftAssistedControlPlaneTemplateSpec) DeepCopyInto(out *OpenshiftAssistedControlPlaneTemplateSpec) {
+	*out = *in
+	in.Template.DeepCopyInto(&out.Template)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenshiftAssistedControlPlaneTemplateSpec.
+func (in *OpenshiftAssistedControlPlaneTemplateSpec) DeepCopy() *OpenshiftAssistedControlPlaneTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenshiftAssistedControlPlaneTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,185 @@
+package v1beta1
+
+import clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+const (
+	// ControlPlaneReadyCondition documents that the OpenshiftAssistedControlplane is ready.
+	ControlPlaneReadyCondition clusterv1.ConditionType = "ControlPlaneReady"
+
+	// KubeconfigAvailableCondition documents that the kubeconfig for the workload cluster is available.
+	KubeconfigAvailableCondition clusterv1.ConditionType = "KubeconfigAvailable"
+
+	// UpgradeCompletedCondition documents wether an upgrade run successfully
+	UpgradeCompletedCondition clusterv1.ConditionType = "UpgradeCompleted"
+
+	// UpgradeAvailableCondition documents wether an upgrade is available
+	UpgradeAvailableCondition clusterv1.ConditionType = "UpgradeAvailable"
+
+	// MachinesCreatedCondition documents that the machines controlled by the OpenshiftAssistedControlplane are created.
+	// When this condition is false, it indicates that there was an error when cloning the infrastructure/bootstrap template or
+	// when generating the machine object.
+	MachinesCreatedCondition clusterv1.ConditionType = "MachinesCreated"
+
+	// MachinesSpecUpToDateCondition documents that the spec of the machines controlled by the OpenshiftAssistedControlPlane
+	// is up to date. When this condition is false, the OpenshiftAssistedControlPlane is executing a rolling upgrade.
+	MachinesSpecUpToDateCondition clusterv1.ConditionType = "MachinesSpecUpToDate"
+
+	// ScaleDownAllowedCondition documents whether control plane machines can be removed without impacting etcd.
+	// When this condition is false, a scale down is blocked until the etcd cluster of the workload cluster is healthy.
+	ScaleDownAllowedCondition clusterv1.ConditionType = "ScaleDownAllowed"
+
+	// ControlPlaneTopologyUpToDateCondition documents that the control plane topology of the workload cluster matches
+	// the number of replicas. When this condition is false, a Single Node OpenShift control plane is being scaled up to
+	// a highly available control plane.
+	ControlPlaneTopologyUpToDateCondition clusterv1.ConditionType = "ControlPlaneTopologyUpToDate"
+
+	// KubernetesVersionAvailableCondition documents that the Kubernetes version could be extracted from the OpenShift version.
+	KubernetesVersionAvailableCondition clusterv1.ConditionType = "KubernetesVersionAvailableCondition"
+
+	// ControlPlaneInstallingCOndition (Severity=Info) documents that the OpenshiftAssistedControlplane is installing.
+	ControlPlaneInstallingReason = "ControlPlaneInstalling"
+
+	// ControlPlaneInstallationFailedReason (Severity=Error) documents that the installation of the workload cluster
+	// failed. It is also the status.failureReason of the OpenshiftAssistedControlPlane.
+	ControlPlaneInstallationFailedReason = "ControlPlaneInstallationFailed"
+
+	// ControlPlaneInstallationStoppedReason (Severity=Error) documents that the installation of the workload cluster
+	// was stopped before completing, e.g. when it was cancelled. It is also the status.failureReason of the
+	// OpenshiftAssistedControlPlane.
+	ControlPlaneInstallationStoppedReason = "ControlPlaneInstallationStopped"
+
+	// ControlPlaneRequirementsNotMetReason (Severity=Warning) documents that the installation of the workload cluster
+	// cannot start yet, e.g. because not enough agents are registered or approved.
+	ControlPlaneRequirementsNotMetReason = "ControlPlaneRequirementsNotMet"
+
+	// ControlPlaneValidationsFailingReason (Severity=Warning) documents that the installation of the workload cluster
+	// cannot start because some of the cluster validations are failing.
+	ControlPlaneValidationsFailingReason = "ControlPlaneValidationsFailing"
+
+	// ControlPlaneNetworkingInvalidReason (Severity=Error) documents that the installation of the workload cluster
+	// cannot start because its networking is invalid, e.g. its networks and VIPs do not list the IP stacks in the
+	// same order.
+	ControlPlaneNetworkingInvalidReason = "ControlPlaneNetworkingInvalid"
+
+	// KubernetesVersionUnavailable (Severity=Warning) documents that the Kubernetes version could not be extracted
+	// from the OpenShift version.
+	KubernetesVersionUnavailableFailedReason = "KubernetesVersionUnavailable"
+
+	// KubernetesVersionMismatchReason (Severity=Warning) documents that the Kubernetes version requested in spec.version
+	// does not match the Kubernetes version shipped with the requested OpenShift version.
+	KubernetesVersionMismatchReason = "KubernetesVersionMismatch"
+
+	// ControlPlaneInstallingCOndition (Severity=Info) documents that the workload cluster kubeconfig is not yet available.
+	KubeconfigUnavailableFailedReason = "KubeconfigUnavailable"
+
+	// KubeconfigConnectionFailedReason (Severity=Warning) documents that the API of the installed workload cluster
+	// cannot be reached with its kubeconfig, e.g. because its certificates were rotated.
+	KubeconfigConnectionFailedReason = "KubeconfigConnectionFailed"
+
+	// UpgradeInProgressReason (Severity=Info) documents that an upgrade is in progress.
+	UpgradeInProgressReason = "UpgradeInProgress"
+
+	// UpgradePendingReason (Severity=Info) documents an upgrade waiting for the next maintenance window, or held by
+	// the hold-upgrade annotation.
+	UpgradePendingReason = "UpgradePending"
+
+	// UpgradeHealthGatesFailedReason (Severity=Warning) documents an upgrade waiting for the workload cluster to pass
+	// the upgrade health gates.
+	UpgradeHealthGatesFailedReason = "UpgradeHealthGatesFailed"
+
+	// UpgradeSignatureVerificationFailedReason (Severity=Error) documents an upgrade unavailable because the release
+	// image has no valid signature from a trusted key.
+	UpgradeSignatureVerificationFailedReason = "UpgradeSignatureVerificationFailed"
+
+	// UpgradeSignatureNotFoundReason (Severity=Error) documents an upgrade unavailable because the signatures of the
+	// release image, to be pushed to the workload cluster, cannot be found.
+	UpgradeSignatureNotFoundReason = "UpgradeSignatureNotFound"
+
+	// UpgradeReleaseNotAcceptedReason (Severity=Warning) documents an upgrade blocked because the cluster-version-operator
+	// of the workload cluster could not load or verify the desired release.
+	UpgradeReleaseNotAcceptedReason = "UpgradeReleaseNotAccepted"
+
+	// UpgradeFailingReason (Severity=Warning) documents an upgrade blocked because the cluster-version-operator
+	// of the workload cluster is failing to apply the desired release.
+	UpgradeFailingReason = "UpgradeFailing"
+
+	// UpgradeClusterOperatorsDegradedReason (Severity=Warning) documents an upgrade blocked by degraded or unavailable
+	// cluster operators of the workload cluster.
+	UpgradeClusterOperatorsDegradedReason = "UpgradeClusterOperatorsDegraded"
+
+	// UpgradeRetrievingUpdatesFailedReason (Severity=Warning) documents an upgrade blocked because the workload cluster
+	// could not retrieve the available updates, which are required to upgrade to a version.
+	UpgradeRetrievingUpdatesFailedReason = "UpgradeRetrievingUpdatesFailed"
+
+	// UpgradeTimedOutReason is the status.failureReason of an OpenshiftAssistedControlPlane whose upgrade did not complete
+	// within the upgrade timeout.
+	UpgradeTimedOutReason = "UpgradeTimedOut"
+
+	// UpgradeImageUnavailableReason (Severity=Error) documents whether an upgrade image is available
+	UpgradeImageUnavailableReason = "UpgradeImageUnavailable"
+
+	// UpgradeDowngradeNotAllowedReason (Severity=Warning) documents an upgrade refused because the desired version
+	// is older than the version of the workload cluster.
+	UpgradeDowngradeNotAllowedReason = "UpgradeDowngradeNotAllowed"
+
+	// UpgradeSkipsMinorVersionReason (Severity=Warning) documents an upgrade refused because the desired version
+	// skips a minor version of the workload cluster.
+	UpgradeSkipsMinorVersionReason = "UpgradeSkipsMinorVersion"
+
+	// UpgradePathBlockedReason (Severity=Warning) documents an upgrade refused because the update graph has no
+	// recommended edge from the version of the workload cluster to the desired version.
+	UpgradePathBlockedReason = "UpgradePathBlocked"
+
+	// UpgradeInvalidVersionReason (Severity=Warning) documents an upgrade refused because the desired version, or the
+	// version of the workload cluster, is not a semantic version.
+	UpgradeInvalidVersionReason = "UpgradeInvalidVersion"
+
+	// UpgradeGraphUnavailableReason (Severity=Warning) documents an upgrade waiting for the update graph to be
+	// retrieved, to validate the upgrade path.
+	UpgradeGraphUnavailableReason = "UpgradeGraphUnavailable"
+
+	// InfrastructureTemplateCloningFailedReason (Severity=Error) documents a OpenshiftAssistedControlplane failing to
+	// clone the infrastructure template.
+	InfrastructureTemplateCloningFailedReason = "InfrastructureTemplateCloningFailed"
+
+	// BootstrapTemplateCloningFailedReason (Severity=Error) documents a OpenshiftAssistedControlplane failing to
+	// clone the bootstrap template.
+	BootstrapTemplateCloningFailedReason = "BootstrapTemplateCloningFailed"
+
+	// RollingUpdateInProgressReason (Severity=Warning) documents a OpenshiftAssistedControlPlane replacing
+	// the machines that are out of date.
+	RollingUpdateInProgressReason = "RollingUpdateInProgress"
+
+	// RollingUpdateWaitingForInstallReason (Severity=Info) documents that out of date machines cannot be replaced
+	// until the cluster is installed, as new control plane machines are added through the day-2 flow.
+	RollingUpdateWaitingForInstallReason = "RollingUpdateWaitingForInstall"
+
+	// RollingUpdateNotSupportedReason (Severity=Warning) documents that out of date machines of a single node control
+	// plane are not replaced, as replacing the only control plane node would go through a two members etcd cluster.
+	RollingUpdateNotSupportedReason = "RollingUpdateNotSupported"
+
+	// EtcdClusterUnhealthyReason (Severity=Warning) documents a scale down blocked because some etcd members
+	// of the workload cluster are not healthy.
+	EtcdClusterUnhealthyReason = "EtcdClusterUnhealthy"
+
+	// EtcdQuorumAtRiskReason (Severity=Warning) documents a scale down blocked because removing the etcd member
+	// of the machine would cause etcd to lose quorum.
+	EtcdQuorumAtRiskReason = "EtcdQuorumAtRisk"
+
+	// EtcdMemberRemovalFailedReason (Severity=Warning) documents a scale down blocked because the etcd member
+	// of the machine could not be removed from the etcd cluster.
+	EtcdMemberRemovalFailedReason = "EtcdMemberRemovalFailed"
+
+	// ControlPlaneExpansionInProgressReason (Severity=Info) documents a Single Node OpenShift control plane waiting for
+	// the new control plane machines to join the cluster and their etcd members to be healthy, before switching the
+	// workload cluster to a highly available topology.
+	ControlPlaneExpansionInProgressReason = "ControlPlaneExpansionInProgress"
+
+	// ControlPlaneTopologyUpdateFailedReason (Severity=Warning) documents a OpenshiftAssistedControlPlane failing to
+	// update the control plane topology of the workload cluster.
+	ControlPlaneTopologyUpdateFailedReason = "ControlPlaneTopologyUpdateFailed"
+
+	// MachineGenerationFailedReason (Severity=Error) documents a OpenshiftAssistedControlplane failing to
+	// generate a machine object.
+	MachineGenerationFailedReason = "MachineGenerationFailed"
+)
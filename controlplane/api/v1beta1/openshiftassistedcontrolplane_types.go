@@ -0,0 +1,644 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	bootstrapv1beta1 "github.com/openshift-assisted/cluster-api-agent/bootstrap/api/v1beta1"
+	hiveext "github.com/openshift/assisted-service/api/hiveextension/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+const (
+	// RemediationInProgressAnnotation is used to keep track that a remediation is in progress, and more
+	// specifically that an unhealthy machine has been deleted and its replacement is not created yet.
+	RemediationInProgressAnnotation = "controlplane.cluster.x-k8s.io/remediation-in-progress"
+
+	// RemediationForAnnotation is used to link a new machine to the unhealthy machine it is replacing.
+	// In case of retry, when the replacement machine fails too, the first machine of the sequence is kept.
+	RemediationForAnnotation = "controlplane.cluster.x-k8s.io/remediation-for"
+
+	// HoldUpgradeAnnotation prevents upgrades of the workload cluster from starting while it is set on the
+	// OpenshiftAssistedControlPlane. An upgrade in progress is not interrupted.
+	HoldUpgradeAnnotation = "controlplane.cluster.x-k8s.io/hold-upgrade"
+
+	// SkipUpgradeHealthGatesAnnotation is a comma separated list of the upgrade health gates that are not checked
+	// before upgrading the workload cluster, e.g. "MachineConfigPools,Nodes".
+	SkipUpgradeHealthGatesAnnotation = "controlplane.cluster.x-k8s.io/skip-upgrade-health-gates"
+
+	// UpgradeRequestedByAnnotation records who requested the upgrade to spec.distributionVersion, in the upgrade
+	// history. When not set, the field manager of spec.distributionVersion is recorded.
+	UpgradeRequestedByAnnotation = "controlplane.cluster.x-k8s.io/upgrade-requested-by"
+
+	// DistributionVersionAnnotation records on each control plane machine the OpenShift version it runs, while
+	// spec.version records its Kubernetes version.
+	DistributionVersionAnnotation = "controlplane.cluster.x-k8s.io/distribution-version"
+)
+
+type OpenshiftAssistedControlPlaneMachineTemplate struct {
+	// Standard object's metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#metadata
+	// +optional
+	ObjectMeta clusterv1.ObjectMeta `json:"metadata,omitempty"`
+
+	// InfrastructureRef is a required reference to a custom resource
+	// offered by an infrastructure provider.
+	InfrastructureRef corev1.ObjectReference `json:"infrastructureRef"`
+
+	// NodeDrainTimeout is the total amount of time that the controller will spend on draining a controlplane node
+	// The default value is 0, meaning that the node can be drained without any time limitations.
+	// NOTE: NodeDrainTimeout is different from `kubectl drain --timeout`
+	// +optional
+	NodeDrainTimeout *metav1.Duration `json:"nodeDrainTimeout,omitempty"`
+
+	// NodeVolumeDetachTimeout is the total amount of time that the controller will spend on waiting for all volumes
+	// to be detached. The default value is 0, meaning that the volumes can be detached without any time limitations.
+	// +optional
+	NodeVolumeDetachTimeout *metav1.Duration `json:"nodeVolumeDetachTimeout,omitempty"`
+
+	// NodeDeletionTimeout defines how long the machine controller will attempt to delete the Node that the Machine
+	// hosts after the Machine is marked for deletion. A duration of 0 will retry deletion indefinitely.
+	// If no value is provided, the default value for this property of the Machine resource will be used.
+	// +optional
+	NodeDeletionTimeout *metav1.Duration `json:"nodeDeletionTimeout,omitempty"`
+}
+
+// OpenshiftAssistedControlPlaneSpec defines the desired state of OpenshiftAssistedControlPlane
+type OpenshiftAssistedControlPlaneSpec struct {
+	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	// Config specs for the OpenshiftAssistedControlPlane
+	Config                      OpenshiftAssistedControlPlaneConfigSpec      `json:"config,omitempty"`
+	MachineTemplate             OpenshiftAssistedControlPlaneMachineTemplate `json:"machineTemplate"`
+	OpenshiftAssistedConfigSpec bootstrapv1beta1.OpenshiftAssistedConfigSpec `json:"openshiftAssistedConfigSpec,omitempty"`
+	Replicas                    int32                                        `json:"replicas,omitempty"`
+	// DistributionVersion describes the targeted OpenShift version
+	DistributionVersion string `json:"distributionVersion"`
+	// Version is the Kubernetes version of the control plane. It is set by the Cluster topology controller
+	// when the control plane is created from a ClusterClass. As OpenShift upgrades are driven by DistributionVersion,
+	// this field is only checked against the Kubernetes version shipped with the DistributionVersion release image.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// RolloutAfter is a field to indicate a rollout should be performed
+	// after the specified time even if no changes have been made to the
+	// OpenshiftAssistedControlPlane.
+	// +optional
+	RolloutAfter *metav1.Time `json:"rolloutAfter,omitempty"`
+
+	// RolloutStrategy is the strategy used to replace control plane machines
+	// that are out of date with new ones.
+	// +optional
+	// +kubebuilder:default={type: "RollingUpdate", rollingUpdate: {maxSurge: 1}}
+	RolloutStrategy *RolloutStrategy `json:"rolloutStrategy,omitempty"`
+
+	// RemediationStrategy controls how the control plane machines marked as unhealthy by a MachineHealthCheck
+	// are remediated.
+	// +optional
+	RemediationStrategy *RemediationStrategy `json:"remediationStrategy,omitempty"`
+
+	// UpgradeStrategy controls when the workload cluster is upgraded to the DistributionVersion.
+	// +optional
+	UpgradeStrategy *UpgradeStrategy `json:"upgradeStrategy,omitempty"`
+
+	// ControlPlaneEndpoint is the endpoint of the API of the workload cluster, published to the CAPI Cluster.
+	// When it is not set, it is set to the first API VIP or, without API VIPs, to api.<clusterName>.<baseDomain>,
+	// on port 6443.
+	// +optional
+	ControlPlaneEndpoint clusterv1.APIEndpoint `json:"controlPlaneEndpoint,omitempty"`
+}
+
+// RemediationStrategy allows to define how the control plane machine remediation happens.
+type RemediationStrategy struct {
+	// MaxRetry is the maximum number of retries while attempting to remediate an unhealthy machine.
+	// A retry happens when a machine that was created as a replacement for an unhealthy machine also fails.
+	// For example, given a control plane with three machines M1, M2, M3:
+	//
+	//	M1 become unhealthy; remediation happens, and M1-1 is created as a replacement.
+	//	If M1-1 (replacement of M1) has problems while bootstrapping it will become unhealthy, and then be
+	//	remediated; such operation is considered a retry, remediation-retry #1.
+	//	If M1-2 (replacement of M1-1) becomes unhealthy, remediation-retry #2 will happen, etc.
+	//
+	// Defaults to 3.
+	// +optional
+	MaxRetry *int32 `json:"maxRetry,omitempty"`
+
+	// RetryPeriod is the duration the controller waits before remediating a machine being created as a
+	// replacement for an unhealthy machine (a retry). The duration doubles with each retry of the same remediation.
+	// Defaults to 5 minutes.
+	// +optional
+	RetryPeriod *metav1.Duration `json:"retryPeriod,omitempty"`
+
+	// MinHealthyPeriod defines the duration after which the controller considers any failure to a machine unrelated
+	// from the previous remediation, i.e. the retry count is reset to zero.
+	// Defaults to 1 hour.
+	// +optional
+	MinHealthyPeriod *metav1.Duration `json:"minHealthyPeriod,omitempty"`
+}
+
+// UpgradeStrategy controls when the workload cluster is upgraded.
+type UpgradeStrategy struct {
+	// MaintenanceWindow restricts the start of upgrades to recurring maintenance windows.
+	// An upgrade started within a maintenance window is not interrupted at the end of the window.
+	// +optional
+	MaintenanceWindow *MaintenanceWindow `json:"maintenanceWindow,omitempty"`
+
+	// ReleaseSignatures sets how upgrades to releases that are not GA, or that are mirrored, are authorized:
+	// with Force (default), the update is forced, skipping the signature checks of the workload cluster.
+	// With Push, the signatures of the release are written to the workload cluster, which verifies the
+	// release without forcing the update. Push is meant for disconnected clusters that cannot fetch signatures.
+	// +kubebuilder:validation:Enum=Force;Push
+	// +optional
+	ReleaseSignatures ReleaseSignaturesMode `json:"releaseSignatures,omitempty"`
+}
+
+// ReleaseSignaturesMode defines how upgrades to releases that cannot be verified by the workload cluster are authorized.
+type ReleaseSignaturesMode string
+
+const (
+	// ForceReleaseSignaturesMode forces the update of the workload cluster.
+	ForceReleaseSignaturesMode ReleaseSignaturesMode = "Force"
+
+	// PushReleaseSignaturesMode writes the signatures of the release to the workload cluster.
+	PushReleaseSignaturesMode ReleaseSignaturesMode = "Push"
+)
+
+// MaintenanceWindow defines recurring time windows.
+type MaintenanceWindow struct {
+	// Schedule is the start of the maintenance windows, as a cron expression in UTC with 5 fields:
+	// minute, hour, day of month, month and day of week. For example, "0 22 * * sat" starts a window
+	// every Saturday at 22:00 UTC.
+	// +kubebuilder:validation:MinLength=1
+	Schedule string `json:"schedule"`
+
+	// Duration is the duration of each maintenance window.
+	Duration metav1.Duration `json:"duration"`
+}
+
+// LastRemediationStatus stores info about the last remediation performed.
+type LastRemediationStatus struct {
+	// Machine is the machine name of the latest machine being remediated.
+	Machine string `json:"machine"`
+
+	// Timestamp is when the last remediation happened. It is represented in RFC3339 form and is in UTC.
+	Timestamp metav1.Time `json:"timestamp"`
+
+	// RetryCount used to keep track of remediation retry for the last remediated machine.
+	// A retry happens when a machine that was created as a replacement for an unhealthy machine also fails.
+	RetryCount int32 `json:"retryCount"`
+}
+
+// UpgradeState is the state of an upgrade of the workload cluster.
+type UpgradeState string
+
+const (
+	// UpgradeStateCompleted means the workload cluster fully rolled out the version of the upgrade.
+	UpgradeStateCompleted UpgradeState = "Completed"
+
+	// UpgradeStatePartial means the upgrade is in progress, or was interrupted by another upgrade.
+	UpgradeStatePartial UpgradeState = "Partial"
+)
+
+// UpgradeHistory records an upgrade of the workload cluster, mirroring the history
+// of the workload cluster ClusterVersion.
+type UpgradeHistory struct {
+	// State is Completed once the workload cluster fully rolled out the version, Partial otherwise.
+	State UpgradeState `json:"state"`
+
+	// StartedTime is when the upgrade started.
+	StartedTime metav1.Time `json:"startedTime"`
+
+	// CompletionTime is when the upgrade completed. It is not set while the upgrade is Partial.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Version is the OpenShift version of the upgrade.
+	Version string `json:"version"`
+
+	// Image is the release image of the upgrade, by digest, as reported by the workload cluster.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// RequestedBy is who requested the upgrade: the value of the upgrade-requested-by annotation,
+	// or the field manager that last set spec.distributionVersion.
+	// +optional
+	RequestedBy string `json:"requestedBy,omitempty"`
+}
+
+// RolloutStrategyType defines the rollout strategies for an OpenshiftAssistedControlPlane.
+// +kubebuilder:validation:Enum=RollingUpdate
+type RolloutStrategyType string
+
+const (
+	// RollingUpdateStrategyType replaces the old control plane machines with new ones one at a time:
+	// a new machine is added to the cluster through the assisted installer day-2 flow and, once it
+	// joined the cluster, an old machine is removed.
+	RollingUpdateStrategyType RolloutStrategyType = "RollingUpdate"
+)
+
+// RolloutStrategy describes how to replace existing machines with new ones.
+type RolloutStrategy struct {
+	// Type of rollout. Currently the only supported strategy is "RollingUpdate".
+	// Default is RollingUpdate.
+	// +optional
+	Type RolloutStrategyType `json:"type,omitempty"`
+
+	// RollingUpdate is the rolling update config params. Present only if
+	// RolloutStrategyType = RollingUpdate.
+	// +optional
+	RollingUpdate *RollingUpdate `json:"rollingUpdate,omitempty"`
+}
+
+// RollingUpdate is used to control the desired behavior of rolling update.
+type RollingUpdate struct {
+	// MaxSurge is the maximum number of control plane machines that can be scheduled above the
+	// desired number of replicas during a rollout.
+	// Value can only be the absolute number 1 or 0. When set to 0, an old machine is removed
+	// before the new one is added, which requires at least 3 replicas to keep etcd quorum.
+	// Defaults to 1.
+	// +optional
+	MaxSurge *intstr.IntOrString `json:"maxSurge,omitempty"`
+}
+
+// OpenshiftAssistedControlPlaneConfigSpec defines configuration for the agent-provisioned cluster
+type OpenshiftAssistedControlPlaneConfigSpec struct {
+	// From AgentClusterInstall https://github.com/openshift/assisted-service/blob/master/api/hiveextension/v1beta1/agentclusterinstall_types.go
+
+	// APIVIPs are the virtual IPs used to reach the OpenShift cluster's API.
+	// Enter one IP address for single-stack clusters, or up to two for dual-stack clusters (at
+	// most one IP address per IP stack used). The order of stacks should be the same as order
+	// of subnets in Cluster Networks, Service Networks, and Machine Networks.
+	// +kubebuilder:validation:MaxItems=2
+	// +optional
+	APIVIPs []string `json:"apiVIPs,omitempty"`
+
+	// IngressVIPs are the virtual IPs used for cluster ingress traffic.
+	// Enter one IP address for single-stack clusters, or up to two for dual-stack clusters (at
+	// most one IP address per IP stack used). The order of stacks should be the same as order
+	// of subnets in Cluster Networks, Service Networks, and Machine Networks.
+	// +kubebuilder:validation:MaxItems=2
+	// +optional
+	IngressVIPs []string `json:"ingressVIPs,omitempty"`
+
+	// ManifestsConfigMapRefs is an array of references to user-provided manifests ConfigMaps to
+	// add to or replace manifests that are generated by the installer.
+	// Manifest names in each ConfigMap should be unique across all referenced ConfigMaps.
+	// +optional
+	ManifestsConfigMapRefs []hiveext.ManifestsConfigMapReference `json:"manifestsConfigMapRefs,omitempty"`
+
+	// DiskEncryption is the configuration to enable/disable disk encryption for cluster nodes.
+	// +optional
+	DiskEncryption *hiveext.DiskEncryption `json:"diskEncryption,omitempty"`
+
+	// Proxy defines the proxy settings used for the install config
+	// +optional
+	Proxy *hiveext.Proxy `json:"proxy,omitempty"`
+
+	// Set to true to allow control plane nodes to be schedulable
+	// +optional
+	MastersSchedulable bool `json:"mastersSchedulable,omitempty"`
+
+	// SSHAuthorizedKey ssh key for accessing the cluster nodes after reboot
+	SSHAuthorizedKey string `json:"sshAuthorizedKey,omitempty"`
+
+	// From ClusterDeployment
+
+	// ClusterName is the friendly name of the cluster. It is used for subdomains,
+	// some resource tagging, and other instances where a friendly name for the
+	// cluster is useful.
+	// If not defined ClusterName will be set as the CAPI ClusterName
+	// +optional
+	ClusterName string `json:"clusterName"`
+
+	// BaseDomain is the base domain to which the cluster should belong.
+	// +required
+	BaseDomain string `json:"baseDomain"`
+
+	// PullSecretRef references pull secret necessary for the cluster installation
+	PullSecretRef *corev1.LocalObjectReference `json:"pullSecretRef,omitempty"`
+
+	// ImageRegistryRef is a reference to a configmap containing both the additional
+	// image registries and their corresponding certificate bundles to be used in the spoke cluster
+	ImageRegistryRef *corev1.LocalObjectReference `json:"imageRegistryRef,omitempty"`
+
+	// Capabilities specifies the capabilities set during an OpenShift cluster installation.
+	Capabilities Capabilities `json:"capabilities,omitempty"`
+
+	// Networking is the network configuration of the workload cluster, along with the cluster and service networks
+	// defined on the CAPI Cluster.
+	// +optional
+	Networking *Networking `json:"networking,omitempty"`
+}
+
+// Networking defines the network configuration of the workload cluster.
+// With dual-stack, the order of the IP stacks must be the same in the cluster networks, service networks,
+// machine networks and VIPs.
+type Networking struct {
+	// MachineNetwork is the list of IP address pools of the machines.
+	// +optional
+	MachineNetwork []string `json:"machineNetwork,omitempty"`
+
+	// HostPrefixes are the prefix sizes allocated to each node from the cluster networks of the CAPI Cluster.
+	// Cluster networks without a host prefix default to 23 for IPv4 and 64 for IPv6.
+	// +optional
+	HostPrefixes []HostPrefix `json:"hostPrefixes,omitempty"`
+
+	// NetworkType is the Container Network Interface (CNI) plug-in to install.
+	// Defaults to the network type chosen by the assisted installer.
+	// +kubebuilder:validation:Enum=OVNKubernetes;OpenShiftSDN
+	// +optional
+	NetworkType string `json:"networkType,omitempty"`
+
+	// OVNKubernetesConfig configures the internal subnets of OVN-Kubernetes, which must not overlap with
+	// any network used by the cluster or its infrastructure.
+	// +optional
+	OVNKubernetesConfig *OVNKubernetesConfig `json:"ovnKubernetesConfig,omitempty"`
+}
+
+// HostPrefix is the prefix size allocated to each node from a cluster network.
+type HostPrefix struct {
+	// CIDR is a cluster network of the CAPI Cluster.
+	CIDR string `json:"cidr"`
+
+	// HostPrefix is the prefix size to allocate to each node from the CIDR.
+	// For example, 24 would allocate 2^8=256 addresses to each node.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=128
+	HostPrefix int32 `json:"hostPrefix"`
+}
+
+// OVNKubernetesConfig defines the internal subnets of OVN-Kubernetes for each IP stack.
+type OVNKubernetesConfig struct {
+	// IPv4 configures the IPv4 internal subnets.
+	// +optional
+	IPv4 *OVNKubernetesInternalSubnets `json:"ipv4,omitempty"`
+
+	// IPv6 configures the IPv6 internal subnets.
+	// +optional
+	IPv6 *OVNKubernetesInternalSubnets `json:"ipv6,omitempty"`
+}
+
+// OVNKubernetesInternalSubnets defines the internal subnets of OVN-Kubernetes for an IP stack.
+type OVNKubernetesInternalSubnets struct {
+	// InternalJoinSubnet is the subnet connecting the gateway routers of the nodes to the cluster router.
+	// Defaults to 100.64.0.0/16 for IPv4 and fd98::/64 for IPv6.
+	// +optional
+	InternalJoinSubnet string `json:"internalJoinSubnet,omitempty"`
+
+	// InternalTransitSwitchSubnet is the subnet of the switch connecting the cluster routers of the nodes.
+	// Defaults to 100.88.0.0/16 for IPv4 and fd97::/64 for IPv6.
+	// +optional
+	InternalTransitSwitchSubnet string `json:"internalTransitSwitchSubnet,omitempty"`
+}
+
+type Capabilities struct {
+	// BaselineCapability provides a default set of capabilities to enable during the installation.
+	// Valid values are vCurrent, v4.x, or None. See the OpenShift doc for more details.
+	// Defaults to None for baremetal platform workload clusters or vCurrent otherwise.
+	// +optional
+	BaselineCapability string `json:"baselineCapability,omitempty"`
+
+	// AdditionalEnabledCapabilities is a list of OpenShift capabilities to specifically enable
+	// during the installation of the workload cluster. It is empty by default.
+	// +optional
+	AdditionalEnabledCapabilities []string `json:"additionalEnabledCapabilities,omitempty"`
+}
+
+// OpenshiftAssistedControlPlaneStatus defines the observed state of OpenshiftAssistedControlPlane
+type OpenshiftAssistedControlPlaneStatus struct {
+	// ClusterDeploymentRef references the ClusterDeployment used to create the cluster
+	ClusterDeploymentRef *corev1.ObjectReference `json:"clusterDeploymentRef,omitempty"`
+
+	// Selector is the label selector in string format to avoid introspection
+	// by clients, and is used to provide the CRD-based integration for the
+	// scale subresource and additional integrations for things like kubectl
+	// describe.. The string will be in the same format as the query-param syntax.
+	// More info about label selectors: http://kubernetes.io/docs/user-guide/labels#label-selectors
+	// +optional
+	Selector string `json:"selector,omitempty"`
+
+	// Total number of non-terminated machines targeted by this control plane
+	// (their labels match the selector).
+	// +optional
+	Replicas int32 `json:"replicas"`
+
+	// Version represents the minimum Kubernetes version for the control plane machines
+	// in the cluster.
+	// +optional
+	Version *string `json:"version,omitempty"`
+
+	// DistributionVersion represents the current OpenShift version installed on the
+	// control plane machines in the cluster.
+	DistributionVersion string `json:"distributionVersion,omitempty"`
+
+	// Total number of non-terminated machines targeted by this control plane
+	// that have the desired template spec.
+	// +optional
+	UpdatedReplicas int32 `json:"updatedReplicas"`
+
+	// Total number of fully running and ready control plane machines.
+	// +optional
+	ReadyReplicas int32 `json:"readyReplicas"`
+
+	// Total number of unavailable machines targeted by this control plane.
+	// This is the total number of machines that are still required for
+	// the deployment to have 100% available capacity. They may either
+	// be machines that are running but not yet ready or machines
+	// that still have not been created.
+	// +optional
+	UnavailableReplicas int32 `json:"unavailableReplicas"`
+
+	// Initialized denotes whether or not the control plane has the
+	// uploaded kubeadm-config configmap.
+	// +optional
+	Initialized bool `json:"initialized"`
+
+	// Ready denotes that the OpenshiftAssistedControlPlane API Server became ready during initial provisioning
+	// to receive requests.
+	// NOTE: this field is part of the Cluster API contract and it is used to orchestrate provisioning.
+	// The value of this field is never updated after provisioning is completed. Please use conditions
+	// to check the operational state of the control plane.
+	// +optional
+	Ready bool `json:"ready"`
+
+	// FailureReason indicates that there is a terminal problem reconciling the
+	// state, and will be set to a token value suitable for
+	// programmatic interpretation.
+	// +optional
+	FailureReason *string `json:"failureReason,omitempty"`
+
+	// ErrorMessage indicates that there is a terminal problem reconciling the
+	// state, and will be set to a descriptive error message.
+	// +optional
+	FailureMessage *string `json:"failureMessage,omitempty"`
+
+	// ObservedGeneration is the latest generation observed by the controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions defines current service state of the KubeadmControlPlane.
+	// +optional
+	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
+
+	// LastRemediation stores info about the last remediation performed.
+	// +optional
+	LastRemediation *LastRemediationStatus `json:"lastRemediation,omitempty"`
+
+	// UpgradeHistory contains the upgrades of the workload cluster requested through this
+	// OpenshiftAssistedControlPlane, the most recent first. It is limited to the last 10 upgrades.
+	// +optional
+	// +kubebuilder:validation:MaxItems=10
+	UpgradeHistory []UpgradeHistory `json:"upgradeHistory,omitempty"`
+
+	// ClusterID is the unique identifier of the workload cluster, generated during the installation.
+	// +optional
+	ClusterID string `json:"clusterID,omitempty"`
+
+	// InfraID is the identifier of the workload cluster used to name its infrastructure resources, generated during
+	// the installation.
+	// +optional
+	InfraID string `json:"infraID,omitempty"`
+
+	// APIURL is the URL of the API of the workload cluster.
+	// +optional
+	APIURL string `json:"apiURL,omitempty"`
+
+	// ConsoleURL is the URL of the web console of the workload cluster.
+	// +optional
+	ConsoleURL string `json:"consoleURL,omitempty"`
+
+	// InstallationProgress mirrors the installation progress of the workload cluster, as reported by the
+	// AgentClusterInstall.
+	// +optional
+	InstallationProgress *InstallationProgress `json:"installationProgress,omitempty"`
+
+	// Initialization provides observations of the OpenshiftAssistedControlPlane initialization process.
+	// NOTE: Fields in this struct are part of the Cluster API contract and are used to orchestrate initial provisioning.
+	// +optional
+	Initialization *OpenshiftAssistedControlPlaneInitializationStatus `json:"initialization,omitempty"`
+
+	// V1Beta2 groups the fields of the status following the Cluster API v1beta2 contract.
+	// +optional
+	V1Beta2 *OpenshiftAssistedControlPlaneV1Beta2Status `json:"v1beta2,omitempty"`
+}
+
+// InstallationProgress reports the installation of the workload cluster, as reported by the AgentClusterInstall.
+type InstallationProgress struct {
+	// State is the state of the installation, e.g. insufficient, installing or adding-hosts.
+	// +optional
+	State string `json:"state,omitempty"`
+
+	// StateInfo gives details on the state of the installation.
+	// +optional
+	StateInfo string `json:"stateInfo,omitempty"`
+
+	// TotalPercentage is the estimated installation progress of the workload cluster.
+	// +optional
+	TotalPercentage int64 `json:"totalPercentage,omitempty"`
+}
+
+// OpenshiftAssistedControlPlaneInitializationStatus provides observations of the OpenshiftAssistedControlPlane
+// initialization process.
+type OpenshiftAssistedControlPlaneInitializationStatus struct {
+	// ControlPlaneInitialized is true when the control plane is functional enough to accept requests, and the
+	// kubeconfig of the workload cluster is available.
+	// +optional
+	ControlPlaneInitialized bool `json:"controlPlaneInitialized,omitempty"`
+}
+
+// OpenshiftAssistedControlPlaneV1Beta2Status groups the fields of the status following the Cluster API v1beta2 contract.
+type OpenshiftAssistedControlPlaneV1Beta2Status struct {
+	// Conditions represents the observations of the OpenshiftAssistedControlPlane current state.
+	// Known condition types are Available, RollingOut, ScalingUp, ScalingDown, Remediating and Deleting.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// +kubebuilder:validation:MaxItems=32
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:resource:shortName=oacp;oacps
+// +kubebuilder:subresource:scale:specpath=.spec.replicas,statuspath=.status.replicas,selectorpath=.status.selector
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".metadata.labels['cluster\\.x-k8s\\.io/cluster-name']",description="Cluster"
+// +kubebuilder:printcolumn:name="Initialized",type=boolean,JSONPath=".status.initialized",description="This denotes whether or not the control plane has the uploaded kubeadm-config configmap"
+// +kubebuilder:printcolumn:name="API Server Available",type=boolean,JSONPath=".status.ready",description="KubeadmControlPlane API Server is ready to receive requests"
+// +kubebuilder:printcolumn:name="Desired",type=integer,JSONPath=".spec.replicas",description="Total number of machines desired by this control plane",priority=10
+// +kubebuilder:printcolumn:name="Replicas",type=integer,JSONPath=".status.replicas",description="Total number of non-terminated machines targeted by this control plane"
+// +kubebuilder:printcolumn:name="Ready",type=integer,JSONPath=".status.readyReplicas",description="Total number of fully running and ready control plane machines"
+// +kubebuilder:printcolumn:name="Updated",type=integer,JSONPath=".status.updatedReplicas",description="Total number of non-terminated machines targeted by this control plane that have the desired template spec"
+// +kubebuilder:printcolumn:name="Unavailable",type=integer,JSONPath=".status.unavailableReplicas",description="Total number of unavailable machines targeted by this control plane"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Time duration since creation of KubeadmControlPlane"
+// +kubebuilder:printcolumn:name="API URL",type=string,JSONPath=".status.apiURL",description="URL of the API of the workload cluster",priority=10
+// +kubebuilder:printcolumn:name="Install Progress",type=integer,JSONPath=".status.installationProgress.totalPercentage",description="Estimated installation progress of the workload cluster, in percentage",priority=10
+// +kubebuilder:printcolumn:name="Distribution Version",type=string,JSONPath=".spec.distributionVersion",description="OpenShift version associated with this control plane"
+
+// OpenshiftAssistedControlPlane is the Schema for the openshiftassistedcontrolplane API
+type OpenshiftAssistedControlPlane struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OpenshiftAssistedControlPlaneSpec   `json:"spec,omitempty"`
+	Status OpenshiftAssistedControlPlaneStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// OpenshiftAssistedControlPlaneList contains a list of OpenshiftAssistedControlPlane
+type OpenshiftAssistedControlPlaneList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OpenshiftAssistedControlPlane `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OpenshiftAssistedControlPlane{}, &OpenshiftAssistedControlPlaneList{})
+}
+
+// GetConditions returns the set of conditions for this object.
+func (in *OpenshiftAssistedControlPlane) GetConditions() clusterv1.Conditions {
+	return in.Status.Conditions
+}
+
+// SetConditions sets the conditions on this object.
+func (in *OpenshiftAssistedControlPlane) SetConditions(conditions clusterv1.Conditions) {
+	in.Status.Conditions = conditions
+}
+
+// GetV1Beta2Conditions returns the set of v1beta2 conditions for this object.
+func (in *OpenshiftAssistedControlPlane) GetV1Beta2Conditions() []metav1.Condition {
+	if in.Status.V1Beta2 == nil {
+		return nil
+	}
+	return in.Status.V1Beta2.Conditions
+}
+
+// SetV1Beta2Conditions sets the v1beta2 conditions on this object.
+func (in *OpenshiftAssistedControlPlane) SetV1Beta2Conditions(conditions []metav1.Condition) {
+	if in.Status.V1Beta2 == nil {
+		in.Status.V1Beta2 = &OpenshiftAssistedControlPlaneV1Beta2Status{}
+	}
+	in.Status.V1Beta2.Conditions = conditions
+}
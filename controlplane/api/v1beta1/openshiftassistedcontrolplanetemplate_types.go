@@ -0,0 +1,135 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	bootstrapv1beta1 "github.com/openshift-assisted/cluster-api-agent/bootstrap/api/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// OpenshiftAssistedControlPlaneTemplateSpec defines the desired state of OpenshiftAssistedControlPlaneTemplate
+type OpenshiftAssistedControlPlaneTemplateSpec struct {
+	Template OpenshiftAssistedControlPlaneTemplateResource `json:"template"`
+}
+
+// OpenshiftAssistedControlPlaneTemplateResource describes the data needed to create an OpenshiftAssistedControlPlane
+// from a template.
+type OpenshiftAssistedControlPlaneTemplateResource struct {
+	// Standard object's metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#metadata
+	// +optional
+	ObjectMeta clusterv1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec OpenshiftAssistedControlPlaneTemplateResourceSpec `json:"spec"`
+}
+
+// OpenshiftAssistedControlPlaneTemplateResourceSpec defines the desired state of an OpenshiftAssistedControlPlane
+// created from a template.
+// Replicas, Version and MachineTemplate.InfrastructureRef are not part of the template, as they are set
+// by the Cluster topology controller.
+type OpenshiftAssistedControlPlaneTemplateResourceSpec struct {
+	// Config specs for the OpenshiftAssistedControlPlane
+	// +optional
+	Config OpenshiftAssistedControlPlaneConfigSpec `json:"config,omitempty"`
+
+	// MachineTemplate contains information about how machines
+	// should be shaped when creating or updating a control plane.
+	// +optional
+	MachineTemplate *OpenshiftAssistedControlPlaneTemplateMachineTemplate `json:"machineTemplate,omitempty"`
+
+	// +optional
+	OpenshiftAssistedConfigSpec bootstrapv1beta1.OpenshiftAssistedConfigSpec `json:"openshiftAssistedConfigSpec,omitempty"`
+
+	// DistributionVersion describes the targeted OpenShift version.
+	// When using a ClusterClass, this is usually set through a variable patch, as
+	// Cluster.spec.topology.version only carries the Kubernetes version.
+	// +optional
+	DistributionVersion string `json:"distributionVersion,omitempty"`
+
+	// RolloutAfter is a field to indicate a rollout should be performed
+	// after the specified time even if no changes have been made to the
+	// OpenshiftAssistedControlPlane.
+	// +optional
+	RolloutAfter *metav1.Time `json:"rolloutAfter,omitempty"`
+
+	// RolloutStrategy is the strategy used to replace control plane machines
+	// that are out of date with new ones.
+	// +optional
+	// +kubebuilder:default={type: "RollingUpdate", rollingUpdate: {maxSurge: 1}}
+	RolloutStrategy *RolloutStrategy `json:"rolloutStrategy,omitempty"`
+
+	// RemediationStrategy controls how the control plane machines marked as unhealthy by a MachineHealthCheck
+	// are remediated.
+	// +optional
+	RemediationStrategy *RemediationStrategy `json:"remediationStrategy,omitempty"`
+
+	// UpgradeStrategy controls when the workload cluster is upgraded to the DistributionVersion.
+	// +optional
+	UpgradeStrategy *UpgradeStrategy `json:"upgradeStrategy,omitempty"`
+}
+
+// OpenshiftAssistedControlPlaneTemplateMachineTemplate defines the template for Machines
+// in an OpenshiftAssistedControlPlaneTemplate object.
+// NOTE: OpenshiftAssistedControlPlaneTemplateMachineTemplate is similar to OpenshiftAssistedControlPlaneMachineTemplate but
+// omits ObjectMeta and InfrastructureRef fields. These fields do not make sense on the OpenshiftAssistedControlPlaneTemplate,
+// because they are calculated by the Cluster topology reconciler during reconciliation and thus cannot
+// be configured on the OpenshiftAssistedControlPlaneTemplate.
+type OpenshiftAssistedControlPlaneTemplateMachineTemplate struct {
+	// NodeDrainTimeout is the total amount of time that the controller will spend on draining a controlplane node
+	// The default value is 0, meaning that the node can be drained without any time limitations.
+	// NOTE: NodeDrainTimeout is different from `kubectl drain --timeout`
+	// +optional
+	NodeDrainTimeout *metav1.Duration `json:"nodeDrainTimeout,omitempty"`
+
+	// NodeVolumeDetachTimeout is the total amount of time that the controller will spend on waiting for all volumes
+	// to be detached. The default value is 0, meaning that the volumes can be detached without any time limitations.
+	// +optional
+	NodeVolumeDetachTimeout *metav1.Duration `json:"nodeVolumeDetachTimeout,omitempty"`
+
+	// NodeDeletionTimeout defines how long the machine controller will attempt to delete the Node that the Machine
+	// hosts after the Machine is marked for deletion. A duration of 0 will retry deletion indefinitely.
+	// If no value is provided, the default value for this property of the Machine resource will be used.
+	// +optional
+	NodeDeletionTimeout *metav1.Duration `json:"nodeDeletionTimeout,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=oacpt;oacpts
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Time duration since creation of OpenshiftAssistedControlPlaneTemplate"
+
+// OpenshiftAssistedControlPlaneTemplate is the Schema for the openshiftassistedcontrolplanetemplates API
+type OpenshiftAssistedControlPlaneTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec OpenshiftAssistedControlPlaneTemplateSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// OpenshiftAssistedControlPlaneTemplateList contains a list of OpenshiftAssistedControlPlaneTemplate
+type OpenshiftAssistedControlPlaneTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OpenshiftAssistedControlPlaneTemplate `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OpenshiftAssistedControlPlaneTemplate{}, &OpenshiftAssistedControlPlaneTemplateList{})
+}
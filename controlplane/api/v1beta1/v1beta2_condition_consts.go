@@ -0,0 +1,81 @@
+package v1beta1
+
+import clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+// Conditions that will be used for the OpenshiftAssistedControlPlane object in v1Beta2 API version.
+const (
+	// OpenshiftAssistedControlPlaneAvailableV1Beta2Condition is true when the control plane is initialized and a
+	// majority of its machines, keeping etcd quorum, are ready.
+	OpenshiftAssistedControlPlaneAvailableV1Beta2Condition = clusterv1.AvailableV1Beta2Condition
+
+	// OpenshiftAssistedControlPlaneAvailableV1Beta2Reason surfaces when the OpenshiftAssistedControlPlane is available.
+	OpenshiftAssistedControlPlaneAvailableV1Beta2Reason = clusterv1.AvailableV1Beta2Reason
+
+	// OpenshiftAssistedControlPlaneNotAvailableV1Beta2Reason surfaces when the OpenshiftAssistedControlPlane is not available.
+	OpenshiftAssistedControlPlaneNotAvailableV1Beta2Reason = clusterv1.NotAvailableV1Beta2Reason
+
+	// OpenshiftAssistedControlPlaneNotInitializedV1Beta2Reason surfaces when the control plane is not initialized yet.
+	OpenshiftAssistedControlPlaneNotInitializedV1Beta2Reason = "NotInitialized"
+)
+
+// OpenshiftAssistedControlPlane's RollingOut condition and corresponding reasons that will be used in v1Beta2 API version.
+const (
+	// OpenshiftAssistedControlPlaneRollingOutV1Beta2Condition is true if there is at least one machine not up to date.
+	OpenshiftAssistedControlPlaneRollingOutV1Beta2Condition = clusterv1.RollingOutV1Beta2Condition
+
+	// OpenshiftAssistedControlPlaneRollingOutV1Beta2Reason surfaces when there is at least one machine not up to date.
+	OpenshiftAssistedControlPlaneRollingOutV1Beta2Reason = clusterv1.RollingOutV1Beta2Reason
+
+	// OpenshiftAssistedControlPlaneNotRollingOutV1Beta2Reason surfaces when all the machines are up to date.
+	OpenshiftAssistedControlPlaneNotRollingOutV1Beta2Reason = clusterv1.NotRollingOutV1Beta2Reason
+)
+
+// OpenshiftAssistedControlPlane's ScalingUp condition and corresponding reasons that will be used in v1Beta2 API version.
+const (
+	// OpenshiftAssistedControlPlaneScalingUpV1Beta2Condition is true if actual replicas < desired replicas.
+	OpenshiftAssistedControlPlaneScalingUpV1Beta2Condition = clusterv1.ScalingUpV1Beta2Condition
+
+	// OpenshiftAssistedControlPlaneScalingUpV1Beta2Reason surfaces when actual replicas < desired replicas.
+	OpenshiftAssistedControlPlaneScalingUpV1Beta2Reason = clusterv1.ScalingUpV1Beta2Reason
+
+	// OpenshiftAssistedControlPlaneNotScalingUpV1Beta2Reason surfaces when actual replicas >= desired replicas.
+	OpenshiftAssistedControlPlaneNotScalingUpV1Beta2Reason = clusterv1.NotScalingUpV1Beta2Reason
+)
+
+// OpenshiftAssistedControlPlane's ScalingDown condition and corresponding reasons that will be used in v1Beta2 API version.
+const (
+	// OpenshiftAssistedControlPlaneScalingDownV1Beta2Condition is true if actual replicas > desired replicas.
+	OpenshiftAssistedControlPlaneScalingDownV1Beta2Condition = clusterv1.ScalingDownV1Beta2Condition
+
+	// OpenshiftAssistedControlPlaneScalingDownV1Beta2Reason surfaces when actual replicas > desired replicas.
+	OpenshiftAssistedControlPlaneScalingDownV1Beta2Reason = clusterv1.ScalingDownV1Beta2Reason
+
+	// OpenshiftAssistedControlPlaneNotScalingDownV1Beta2Reason surfaces when actual replicas <= desired replicas.
+	OpenshiftAssistedControlPlaneNotScalingDownV1Beta2Reason = clusterv1.NotScalingDownV1Beta2Reason
+)
+
+// OpenshiftAssistedControlPlane's Remediating condition and corresponding reasons that will be used in v1Beta2 API version.
+const (
+	// OpenshiftAssistedControlPlaneRemediatingV1Beta2Condition is true if a machine marked as unhealthy by a
+	// MachineHealthCheck is being remediated, or waits to be remediated.
+	OpenshiftAssistedControlPlaneRemediatingV1Beta2Condition = clusterv1.RemediatingV1Beta2Condition
+
+	// OpenshiftAssistedControlPlaneRemediatingV1Beta2Reason surfaces when a machine is being remediated.
+	OpenshiftAssistedControlPlaneRemediatingV1Beta2Reason = clusterv1.RemediatingV1Beta2Reason
+
+	// OpenshiftAssistedControlPlaneNotRemediatingV1Beta2Reason surfaces when no machine has to be remediated.
+	OpenshiftAssistedControlPlaneNotRemediatingV1Beta2Reason = clusterv1.NotRemediatingV1Beta2Reason
+)
+
+// OpenshiftAssistedControlPlane's Deleting condition and corresponding reasons that will be used in v1Beta2 API version.
+const (
+	// OpenshiftAssistedControlPlaneDeletingV1Beta2Condition surfaces details about the deletion of the
+	// OpenshiftAssistedControlPlane.
+	OpenshiftAssistedControlPlaneDeletingV1Beta2Condition = clusterv1.DeletingV1Beta2Condition
+
+	// OpenshiftAssistedControlPlaneDeletingV1Beta2Reason surfaces when the OpenshiftAssistedControlPlane is being deleted.
+	OpenshiftAssistedControlPlaneDeletingV1Beta2Reason = clusterv1.DeletingV1Beta2Reason
+
+	// OpenshiftAssistedControlPlaneNotDeletingV1Beta2Reason surfaces when the OpenshiftAssistedControlPlane is not deleting.
+	OpenshiftAssistedControlPlaneNotDeletingV1Beta2Reason = clusterv1.NotDeletingV1Beta2Reason
+)
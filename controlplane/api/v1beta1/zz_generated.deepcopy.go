@@ -0,0 +1,745 @@
+//go:build !ignore_autogenerated
+
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by controller-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	hiveextensionv1beta1 "github.com/openshift/assisted-service/api/hiveextension/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Capabilities) DeepCopyInto(out *Capabilities) {
+	*out = *in
+	if in.AdditionalEnabledCapabilities != nil {
+		in, out := &in.AdditionalEnabledCapabilities, &out.AdditionalEnabledCapabilities
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Capabilities.
+func (in *Capabilities) DeepCopy() *Capabilities {
+	if in == nil {
+		return nil
+	}
+	out := new(Capabilities)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostPrefix) DeepCopyInto(out *HostPrefix) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostPrefix.
+func (in *HostPrefix) DeepCopy() *HostPrefix {
+	if in == nil {
+		return nil
+	}
+	out := new(HostPrefix)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstallationProgress) DeepCopyInto(out *InstallationProgress) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstallationProgress.
+func (in *InstallationProgress) DeepCopy() *InstallationProgress {
+	if in == nil {
+		return nil
+	}
+	out := new(InstallationProgress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LastRemediationStatus) DeepCopyInto(out *LastRemediationStatus) {
+	*out = *in
+	in.Timestamp.DeepCopyInto(&out.Timestamp)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LastRemediationStatus.
+func (in *LastRemediationStatus) DeepCopy() *LastRemediationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(LastRemediationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Networking) DeepCopyInto(out *Networking) {
+	*out = *in
+	if in.MachineNetwork != nil {
+		in, out := &in.MachineNetwork, &out.MachineNetwork
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.HostPrefixes != nil {
+		in, out := &in.HostPrefixes, &out.HostPrefixes
+		*out = make([]HostPrefix, len(*in))
+		copy(*out, *in)
+	}
+	if in.OVNKubernetesConfig != nil {
+		in, out := &in.OVNKubernetesConfig, &out.OVNKubernetesConfig
+		*out = new(OVNKubernetesConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Networking.
+func (in *Networking) DeepCopy() *Networking {
+	if in == nil {
+		return nil
+	}
+	out := new(Networking)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNKubernetesConfig) DeepCopyInto(out *OVNKubernetesConfig) {
+	*out = *in
+	if in.IPv4 != nil {
+		in, out := &in.IPv4, &out.IPv4
+		*out = new(OVNKubernetesInternalSubnets)
+		**out = **in
+	}
+	if in.IPv6 != nil {
+		in, out := &in.IPv6, &out.IPv6
+		*out = new(OVNKubernetesInternalSubnets)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNKubernetesConfig.
+func (in *OVNKubernetesConfig) DeepCopy() *OVNKubernetesConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNKubernetesConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNKubernetesInternalSubnets) DeepCopyInto(out *OVNKubernetesInternalSubnets) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNKubernetesInternalSubnets.
+func (in *OVNKubernetesInternalSubnets) DeepCopy() *OVNKubernetesInternalSubnets {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNKubernetesInternalSubnets)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenshiftAssistedControlPlane) DeepCopyInto(out *OpenshiftAssistedControlPlane) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenshiftAssistedControlPlane.
+func (in *OpenshiftAssistedControlPlane) DeepCopy() *OpenshiftAssistedControlPlane {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenshiftAssistedControlPlane)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OpenshiftAssistedControlPlane) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenshiftAssistedControlPlaneConfigSpec) DeepCopyInto(out *OpenshiftAssistedControlPlaneConfigSpec) {
+	*out = *in
+	if in.APIVIPs != nil {
+		in, out := &in.APIVIPs, &out.APIVIPs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IngressVIPs != nil {
+		in, out := &in.IngressVIPs, &out.IngressVIPs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ManifestsConfigMapRefs != nil {
+		in, out := &in.ManifestsConfigMapRefs, &out.ManifestsConfigMapRefs
+		*out = make([]hiveextensionv1beta1.ManifestsConfigMapReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.DiskEncryption != nil {
+		in, out := &in.DiskEncryption, &out.DiskEncryption
+		*out = new(hiveextensionv1beta1.DiskEncryption)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(hiveextensionv1beta1.Proxy)
+		**out = **in
+	}
+	if in.PullSecretRef != nil {
+		in, out := &in.PullSecretRef, &out.PullSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.ImageRegistryRef != nil {
+		in, out := &in.ImageRegistryRef, &out.ImageRegistryRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	in.Capabilities.DeepCopyInto(&out.Capabilities)
+	if in.Networking != nil {
+		in, out := &in.Networking, &out.Networking
+		*out = new(Networking)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenshiftAssistedControlPlaneConfigSpec.
+func (in *OpenshiftAssistedControlPlaneConfigSpec) DeepCopy() *OpenshiftAssistedControlPlaneConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenshiftAssistedControlPlaneConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenshiftAssistedControlPlaneInitializationStatus) DeepCopyInto(out *OpenshiftAssistedControlPlaneInitializationStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenshiftAssistedControlPlaneInitializationStatus.
+func (in *OpenshiftAssistedControlPlaneInitializationStatus) DeepCopy() *OpenshiftAssistedControlPlaneInitializationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenshiftAssistedControlPlaneInitializationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenshiftAssistedControlPlaneList) DeepCopyInto(out *OpenshiftAssistedControlPlaneList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OpenshiftAssistedControlPlane, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenshiftAssistedControlPlaneList.
+func (in *OpenshiftAssistedControlPlaneList) DeepCopy() *OpenshiftAssistedControlPlaneList {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenshiftAssistedControlPlaneList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OpenshiftAssistedControlPlaneList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenshiftAssistedControlPlaneMachineTemplate) DeepCopyInto(out *OpenshiftAssistedControlPlaneMachineTemplate) {
+	*out = *in
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.InfrastructureRef = in.InfrastructureRef
+	if in.NodeDrainTimeout != nil {
+		in, out := &in.NodeDrainTimeout, &out.NodeDrainTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.NodeVolumeDetachTimeout != nil {
+		in, out := &in.NodeVolumeDetachTimeout, &out.NodeVolumeDetachTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.NodeDeletionTimeout != nil {
+		in, out := &in.NodeDeletionTimeout, &out.NodeDeletionTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenshiftAssistedControlPlaneMachineTemplate.
+func (in *OpenshiftAssistedControlPlaneMachineTemplate) DeepCopy() *OpenshiftAssistedControlPlaneMachineTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenshiftAssistedControlPlaneMachineTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenshiftAssistedControlPlaneSpec) DeepCopyInto(out *OpenshiftAssistedControlPlaneSpec) {
+	*out = *in
+	in.Config.DeepCopyInto(&out.Config)
+	in.MachineTemplate.DeepCopyInto(&out.MachineTemplate)
+	in.OpenshiftAssistedConfigSpec.DeepCopyInto(&out.OpenshiftAssistedConfigSpec)
+	if in.RolloutAfter != nil {
+		in, out := &in.RolloutAfter, &out.RolloutAfter
+		*out = (*in).DeepCopy()
+	}
+	if in.RolloutStrategy != nil {
+		in, out := &in.RolloutStrategy, &out.RolloutStrategy
+		*out = new(RolloutStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RemediationStrategy != nil {
+		in, out := &in.RemediationStrategy, &out.RemediationStrategy
+		*out = new(RemediationStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpgradeStrategy != nil {
+		in, out := &in.UpgradeStrategy, &out.UpgradeStrategy
+		*out = new(UpgradeStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	out.ControlPlaneEndpoint = in.ControlPlaneEndpoint
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenshiftAssistedControlPlaneSpec.
+func (in *OpenshiftAssistedControlPlaneSpec) DeepCopy() *OpenshiftAssistedControlPlaneSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenshiftAssistedControlPlaneSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenshiftAssistedControlPlaneStatus) DeepCopyInto(out *OpenshiftAssistedControlPlaneStatus) {
+	*out = *in
+	if in.ClusterDeploymentRef != nil {
+		in, out := &in.ClusterDeploymentRef, &out.ClusterDeploymentRef
+		*out = new(corev1.ObjectReference)
+		**out = **in
+	}
+	if in.Version != nil {
+		in, out := &in.Version, &out.Version
+		*out = new(string)
+		**out = **in
+	}
+	if in.FailureReason != nil {
+		in, out := &in.FailureReason, &out.FailureReason
+		*out = new(string)
+		**out = **in
+	}
+	if in.FailureMessage != nil {
+		in, out := &in.FailureMessage, &out.FailureMessage
+		*out = new(string)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(apiv1beta1.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastRemediation != nil {
+		in, out := &in.LastRemediation, &out.LastRemediation
+		*out = new(LastRemediationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpgradeHistory != nil {
+		in, out := &in.UpgradeHistory, &out.UpgradeHistory
+		*out = make([]UpgradeHistory, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.InstallationProgress != nil {
+		in, out := &in.InstallationProgress, &out.InstallationProgress
+		*out = new(InstallationProgress)
+		**out = **in
+	}
+	if in.Initialization != nil {
+		in, out := &in.Initialization, &out.Initialization
+		*out = new(OpenshiftAssistedControlPlaneInitializationStatus)
+		**out = **in
+	}
+	if in.V1Beta2 != nil {
+		in, out := &in.V1Beta2, &out.V1Beta2
+		*out = new(OpenshiftAssistedControlPlaneV1Beta2Status)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenshiftAssistedControlPlaneStatus.
+func (in *OpenshiftAssistedControlPlaneStatus) DeepCopy() *OpenshiftAssistedControlPlaneStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenshiftAssistedControlPlaneStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenshiftAssistedControlPlaneTemplate) DeepCopyInto(out *OpenshiftAssistedControlPlaneTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenshiftAssistedControlPlaneTemplate.
+func (in *OpenshiftAssistedControlPlaneTemplate) DeepCopy() *OpenshiftAssistedControlPlaneTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenshiftAssistedControlPlaneTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OpenshiftAssistedControlPlaneTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenshiftAssistedControlPlaneTemplateList) DeepCopyInto(out *OpenshiftAssistedControlPlaneTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OpenshiftAssistedControlPlaneTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenshiftAssistedControlPlaneTemplateList.
+func (in *OpenshiftAssistedControlPlaneTemplateList) DeepCopy() *OpenshiftAssistedControlPlaneTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenshiftAssistedControlPlaneTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OpenshiftAssistedControlPlaneTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenshiftAssistedControlPlaneTemplateMachineTemplate) DeepCopyInto(out *OpenshiftAssistedControlPlaneTemplateMachineTemplate) {
+	*out = *in
+	if in.NodeDrainTimeout != nil {
+		in, out := &in.NodeDrainTimeout, &out.NodeDrainTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.NodeVolumeDetachTimeout != nil {
+		in, out := &in.NodeVolumeDetachTimeout, &out.NodeVolumeDetachTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.NodeDeletionTimeout != nil {
+		in, out := &in.NodeDeletionTimeout, &out.NodeDeletionTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenshiftAssistedControlPlaneTemplateMachineTemplate.
+func (in *OpenshiftAssistedControlPlaneTemplateMachineTemplate) DeepCopy() *OpenshiftAssistedControlPlaneTemplateMachineTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenshiftAssistedControlPlaneTemplateMachineTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenshiftAssistedControlPlaneTemplateResource) DeepCopyInto(out *OpenshiftAssistedControlPlaneTemplateResource) {
+	*out = *in
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenshiftAssistedControlPlaneTemplateResource.
+func (in *OpenshiftAssistedControlPlaneTemplateResource) DeepCopy() *OpenshiftAssistedControlPlaneTemplateResource {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenshiftAssistedControlPlaneTemplateResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenshiftAssistedControlPlaneTemplateResourceSpec) DeepCopyInto(out *OpenshiftAssistedControlPlaneTemplateResourceSpec) {
+	*out = *in
+	in.Config.DeepCopyInto(&out.Config)
+	if in.MachineTemplate != nil {
+		in, out := &in.MachineTemplate, &out.MachineTemplate
+		*out = new(OpenshiftAssistedControlPlaneTemplateMachineTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+	in.OpenshiftAssistedConfigSpec.DeepCopyInto(&out.OpenshiftAssistedConfigSpec)
+	if in.RolloutAfter != nil {
+		in, out := &in.RolloutAfter, &out.RolloutAfter
+		*out = (*in).DeepCopy()
+	}
+	if in.RolloutStrategy != nil {
+		in, out := &in.RolloutStrategy, &out.RolloutStrategy
+		*out = new(RolloutStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RemediationStrategy != nil {
+		in, out := &in.RemediationStrategy, &out.RemediationStrategy
+		*out = new(RemediationStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpgradeStrategy != nil {
+		in, out := &in.UpgradeStrategy, &out.UpgradeStrategy
+		*out = new(UpgradeStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenshiftAssistedControlPlaneTemplateResourceSpec.
+func (in *OpenshiftAssistedControlPlaneTemplateResourceSpec) DeepCopy() *OpenshiftAssistedControlPlaneTemplateResourceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenshiftAssistedControlPlaneTemplateResourceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenshiftAssistedControlPlaneTemplateSpec) DeepCopyInto(out *OpenshiftAssistedControlPlaneTemplateSpec) {
+	*out = *in
+	in.Template.DeepCopyInto(&out.Template)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenshiftAssistedControlPlaneTemplateSpec.
+func (in *OpenshiftAssistedControlPlaneTemplateSpec) DeepCopy() *OpenshiftAssistedControlPlaneTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenshiftAssistedControlPlaneTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenshiftAssistedControlPlaneV1Beta2Status) DeepCopyInto(out *OpenshiftAssistedControlPlaneV1Beta2Status) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenshiftAssistedControlPlaneV1Beta2Status.
+func (in *OpenshiftAssistedControlPlaneV1Beta2Status) DeepCopy() *OpenshiftAssistedControlPlaneV1Beta2Status {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenshiftAssistedControlPlaneV1Beta2Status)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemediationStrategy) DeepCopyInto(out *RemediationStrategy) {
+	*out = *in
+	if in.MaxRetry != nil {
+		in, out := &in.MaxRetry, &out.MaxRetry
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RetryPeriod != nil {
+		in, out := &in.RetryPeriod, &out.RetryPeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MinHealthyPeriod != nil {
+		in, out := &in.MinHealthyPeriod, &out.MinHealthyPeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemediationStrategy.
+func (in *RemediationStrategy) DeepCopy() *RemediationStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(RemediationStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RollingUpdate) DeepCopyInto(out *RollingUpdate) {
+	*out = *in
+	if in.MaxSurge != nil {
+		in, out := &in.MaxSurge, &out.MaxSurge
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollingUpdate.
+func (in *RollingUpdate) DeepCopy() *RollingUpdate {
+	if in == nil {
+		return nil
+	}
+	out := new(RollingUpdate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutStrategy) DeepCopyInto(out *RolloutStrategy) {
+	*out = *in
+	if in.RollingUpdate != nil {
+		in, out := &in.RollingUpdate, &out.RollingUpdate
+		*out = new(RollingUpdate)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutStrategy.
+func (in *RolloutStrategy) DeepCopy() *RolloutStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeHistory) DeepCopyInto(out *UpgradeHistory) {
+	*out = *in
+	in.StartedTime.DeepCopyInto(&out.StartedTime)
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeHistory.
+func (in *UpgradeHistory) DeepCopy() *UpgradeHistory {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeHistory)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeStrategy) DeepCopyInto(out *UpgradeStrategy) {
+	*out = *in
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(MaintenanceWindow)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeStrategy.
+func (in *UpgradeStrategy) DeepCopy() *UpgradeStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
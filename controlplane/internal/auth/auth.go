@@ -4,7 +4,7 @@ import (
 	"context"
 	"fmt"
 
-	controlplanev1alpha2 "github.com/openshift-assisted/cluster-api-agent/controlplane/api/v1alpha2"
+	controlplanev1beta1 "github.com/openshift-assisted/cluster-api-agent/controlplane/api/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -32,7 +32,7 @@ func GenerateFakePullSecret(name, namespace string) *corev1.Secret {
 	}
 }
 
-func GetPullSecret(c client.Client, ctx context.Context, oacp *controlplanev1alpha2.OpenshiftAssistedControlPlane) ([]byte, error) {
+func GetPullSecret(c client.Client, ctx context.Context, oacp *controlplanev1beta1.OpenshiftAssistedControlPlane) ([]byte, error) {
 	secret := &corev1.Secret{}
 	if err := c.Get(ctx, types.NamespacedName{Namespace: oacp.Namespace, Name: oacp.Spec.Config.PullSecretRef.Name}, secret); err != nil {
 		return nil, err
@@ -17,40 +17,96 @@ limitations under the License.
 package controller
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"time"
 
 	"sigs.k8s.io/cluster-api/util/conditions"
 
-	controlplanev1alpha2 "github.com/openshift-assisted/cluster-api-agent/controlplane/api/v1alpha2"
+	controlplanev1beta1 "github.com/openshift-assisted/cluster-api-agent/controlplane/api/v1beta1"
+	"github.com/openshift-assisted/cluster-api-agent/pkg/workloadclient"
 	"github.com/openshift-assisted/cluster-api-agent/util"
 	logutil "github.com/openshift-assisted/cluster-api-agent/util/log"
 	hiveext "github.com/openshift/assisted-service/api/hiveextension/v1beta1"
 	aimodels "github.com/openshift/assisted-service/models"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 )
 
-const kubeconfigSecretKey = "kubeconfig"
+const (
+	kubeconfigSecretKey = "kubeconfig"
+	// kubeconfigSecretValueKey is the key of the kubeconfig in the <cluster-name>-kubeconfig secret read by CAPI
+	kubeconfigSecretValueKey = "value"
+	// kubeconfigValidationInterval is the interval between two connections to an installed workload cluster,
+	// checking that its kubeconfig still works
+	kubeconfigValidationInterval = 5 * time.Minute
+)
 
 // AgentClusterInstallReconciler reconciles a AgentClusterInstall object
 type AgentClusterInstallReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
+	Scheme                  *runtime.Scheme
+	WorkloadClientGenerator workloadclient.ClientGenerator
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *AgentClusterInstallReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&hiveext.AgentClusterInstall{}).
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.mapAdminSecretToAgentClusterInstall),
+		).
+		Watches(
+			&hivev1.ClusterDeployment{},
+			handler.EnqueueRequestsFromMapFunc(mapClusterDeploymentToAgentClusterInstall),
+		).
 		Complete(r)
 }
 
+// mapAdminSecretToAgentClusterInstall enqueues the AgentClusterInstalls referencing the secret as their admin
+// kubeconfig or admin password, so that the secrets of the cluster are updated when the admin secrets change
+func (r *AgentClusterInstallReconciler) mapAdminSecretToAgentClusterInstall(ctx context.Context, o client.Object) []ctrl.Request {
+	acis := &hiveext.AgentClusterInstallList{}
+	if err := r.Client.List(ctx, acis, client.InNamespace(o.GetNamespace())); err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "failed to list AgentClusterInstalls", "namespace", o.GetNamespace())
+		return nil
+	}
+	requests := []ctrl.Request{}
+	for _, aci := range acis.Items {
+		if aci.Spec.ClusterMetadata == nil {
+			continue
+		}
+		if aci.Spec.ClusterMetadata.AdminKubeconfigSecretRef.Name == o.GetName() ||
+			(hasAdminPasswordRef(&aci) && aci.Spec.ClusterMetadata.AdminPasswordSecretRef.Name == o.GetName()) {
+			requests = append(requests, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&aci)})
+		}
+	}
+	return requests
+}
+
+// mapClusterDeploymentToAgentClusterInstall enqueues the AgentClusterInstall of the ClusterDeployment, so that the URLs
+// of the workload cluster are reported once they are set on the ClusterDeployment
+func mapClusterDeploymentToAgentClusterInstall(_ context.Context, o client.Object) []ctrl.Request {
+	cd, ok := o.(*hivev1.ClusterDeployment)
+	if !ok {
+		panic(fmt.Sprintf("Expected a ClusterDeployment but got a %T", o))
+	}
+	if cd.Spec.ClusterInstallRef == nil || cd.Spec.ClusterInstallRef.Kind != "AgentClusterInstall" {
+		return nil
+	}
+	return []ctrl.Request{{NamespacedName: client.ObjectKey{Namespace: cd.Namespace, Name: cd.Spec.ClusterInstallRef.Name}}}
+}
+
 func (r *AgentClusterInstallReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := ctrl.LoggerFrom(ctx)
 
@@ -65,7 +121,7 @@ func (r *AgentClusterInstallReconciler) Reconcile(ctx context.Context, req ctrl.
 	}
 	log.WithValues("agent_cluster_install", aci.Name, "agent_cluster_install_namespace", aci.Namespace)
 
-	acp := controlplanev1alpha2.OpenshiftAssistedControlPlane{}
+	acp := controlplanev1beta1.OpenshiftAssistedControlPlane{}
 	if err := util.GetTypedOwner(ctx, r.Client, aci, &acp); err != nil {
 		return ctrl.Result{}, err
 	}
@@ -75,33 +131,132 @@ func (r *AgentClusterInstallReconciler) Reconcile(ctx context.Context, req ctrl.
 		return ctrl.Result{}, err
 	}
 
+	if err := r.syncKubeadminPassword(ctx, aci, acp); err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.setClusterMetadata(ctx, aci, &acp); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	acp.Status.InstallationProgress = &controlplanev1beta1.InstallationProgress{
+		State:           aci.Status.DebugInfo.State,
+		StateInfo:       aci.Status.DebugInfo.StateInfo,
+		TotalPercentage: aci.Status.Progress.TotalPercentage,
+	}
+
 	// Check if AgentClusterInstall has moved to day 2 aka control plane is installed
 	if isInstalled(aci) {
 		acp.Status.Ready = true
-		conditions.MarkTrue(&acp, controlplanev1alpha2.ControlPlaneReadyCondition)
-		return ctrl.Result{}, r.updateControlplaneStatus(ctx, &acp)
+		clearInstallationFailure(&acp)
+		conditions.MarkTrue(&acp, controlplanev1beta1.ControlPlaneReadyCondition)
+		// periodically check that the kubeconfig of the installed workload cluster still works
+		return ctrl.Result{RequeueAfter: kubeconfigValidationInterval}, r.updateControlplaneStatus(ctx, &acp)
+	}
+	markInstallationStatus(&acp, aci)
+	return ctrl.Result{}, r.updateControlplaneStatus(ctx, &acp)
+}
+
+// markInstallationStatus sets the ControlPlaneReady condition of an OpenshiftAssistedControlPlane whose workload cluster
+// is not installed yet, from the conditions of the AgentClusterInstall. Terminal failures of the installation also set
+// the failure reason and message, which are cleared if the installation is retried.
+func markInstallationStatus(acp *controlplanev1beta1.OpenshiftAssistedControlPlane, aci *hiveext.AgentClusterInstall) {
+	if condition := getClusterInstallCondition(aci, hiveext.ClusterFailedCondition); condition != nil &&
+		condition.Status == corev1.ConditionTrue {
+		markInstallationFailure(acp, controlplanev1beta1.ControlPlaneInstallationFailedReason,
+			fmt.Sprintf("Controlplane installation failed: %s", condition.Message))
+		return
+	}
+	if condition := getClusterInstallCondition(aci, hiveext.ClusterStoppedCondition); condition != nil &&
+		condition.Status == corev1.ConditionTrue && condition.Reason != hiveext.ClusterStoppedCompletedReason {
+		markInstallationFailure(acp, controlplanev1beta1.ControlPlaneInstallationStoppedReason,
+			fmt.Sprintf("Controlplane installation stopped: %s", condition.Message))
+		return
+	}
+	clearInstallationFailure(acp)
+
+	if condition := getClusterInstallCondition(aci, hiveext.ClusterValidatedCondition); condition != nil &&
+		condition.Status == corev1.ConditionFalse {
+		conditions.MarkFalse(
+			acp,
+			controlplanev1beta1.ControlPlaneReadyCondition,
+			controlplanev1beta1.ControlPlaneValidationsFailingReason,
+			clusterv1.ConditionSeverityWarning,
+			"Controlplane validations failing: %s",
+			condition.Message,
+		)
+		return
+	}
+	if condition := getClusterInstallCondition(aci, hiveext.ClusterRequirementsMetCondition); condition != nil &&
+		condition.Status == corev1.ConditionFalse {
+		conditions.MarkFalse(
+			acp,
+			controlplanev1beta1.ControlPlaneReadyCondition,
+			controlplanev1beta1.ControlPlaneRequirementsNotMetReason,
+			clusterv1.ConditionSeverityWarning,
+			"Controlplane installation requirements not met: %s",
+			condition.Message,
+		)
+		return
 	}
 	conditions.MarkFalse(
-		&acp,
-		controlplanev1alpha2.ControlPlaneReadyCondition,
-		controlplanev1alpha2.ControlPlaneInstallingReason,
+		acp,
+		controlplanev1beta1.ControlPlaneReadyCondition,
+		controlplanev1beta1.ControlPlaneInstallingReason,
 		clusterv1.ConditionSeverityInfo,
-		"Controlplane installing, status: %s",
+		"Controlplane installing, status: %s, progress: %d%%",
 		aci.Status.DebugInfo.State,
+		aci.Status.Progress.TotalPercentage,
 	)
-	return ctrl.Result{}, r.updateControlplaneStatus(ctx, &acp)
+}
+
+func markInstallationFailure(acp *controlplanev1beta1.OpenshiftAssistedControlPlane, reason, message string) {
+	conditions.MarkFalse(
+		acp,
+		controlplanev1beta1.ControlPlaneReadyCondition,
+		reason,
+		clusterv1.ConditionSeverityError,
+		"%s",
+		message,
+	)
+	acp.Status.FailureReason = &reason
+	acp.Status.FailureMessage = &message
+}
+
+// clearInstallationFailure clears the failure reason and message set by a failed installation, leaving the ones set
+// by other failures
+func clearInstallationFailure(acp *controlplanev1beta1.OpenshiftAssistedControlPlane) {
+	if acp.Status.FailureReason == nil {
+		return
+	}
+	switch *acp.Status.FailureReason {
+	case controlplanev1beta1.ControlPlaneInstallationFailedReason, controlplanev1beta1.ControlPlaneInstallationStoppedReason:
+		acp.Status.FailureReason = nil
+		acp.Status.FailureMessage = nil
+	}
+}
+
+func getClusterInstallCondition(
+	aci *hiveext.AgentClusterInstall,
+	conditionType hivev1.ClusterInstallConditionType,
+) *hivev1.ClusterInstallCondition {
+	for i := range aci.Status.Conditions {
+		if aci.Status.Conditions[i].Type == conditionType {
+			return &aci.Status.Conditions[i]
+		}
+	}
+	return nil
 }
 
 func (r *AgentClusterInstallReconciler) reconcile(
 	ctx context.Context,
 	aci *hiveext.AgentClusterInstall,
-	acp *controlplanev1alpha2.OpenshiftAssistedControlPlane,
+	acp *controlplanev1beta1.OpenshiftAssistedControlPlane,
 ) error {
 	if !hasKubeconfigRef(aci) {
 		conditions.MarkFalse(
 			acp,
-			controlplanev1alpha2.KubeconfigAvailableCondition,
-			controlplanev1alpha2.KubeconfigUnavailableFailedReason,
+			controlplanev1beta1.KubeconfigAvailableCondition,
+			controlplanev1beta1.KubeconfigUnavailableFailedReason,
 			clusterv1.ConditionSeverityInfo,
 			"Kubeconfig not available",
 		)
@@ -112,8 +267,8 @@ func (r *AgentClusterInstallReconciler) reconcile(
 	if err != nil {
 		conditions.MarkFalse(
 			acp,
-			controlplanev1alpha2.KubeconfigAvailableCondition,
-			controlplanev1alpha2.KubeconfigUnavailableFailedReason,
+			controlplanev1beta1.KubeconfigAvailableCondition,
+			controlplanev1beta1.KubeconfigUnavailableFailedReason,
 			clusterv1.ConditionSeverityInfo,
 			"error retrieving Kubeconfig %v", err,
 		)
@@ -128,59 +283,171 @@ func (r *AgentClusterInstallReconciler) reconcile(
 	if err := r.updateLabels(ctx, kubeconfigSecret, labels); err != nil {
 		conditions.MarkFalse(
 			acp,
-			controlplanev1alpha2.KubeconfigAvailableCondition,
-			controlplanev1alpha2.KubeconfigUnavailableFailedReason,
+			controlplanev1beta1.KubeconfigAvailableCondition,
+			controlplanev1beta1.KubeconfigUnavailableFailedReason,
 			clusterv1.ConditionSeverityInfo,
 			"error updating Kubeconfig secret labels %v", err,
 		)
 		return err
 	}
 
-	if !r.ClusterKubeconfigSecretExists(ctx, clusterName, acp.Namespace) {
-		if err := r.createKubeconfig(ctx, kubeconfigSecret, clusterName, *acp); err != nil {
+	kubeconfig, ok := kubeconfigSecret.Data[kubeconfigSecretKey]
+	if !ok {
+		err := fmt.Errorf("kubeconfig with key `%s` not found in secret %s", kubeconfigSecretKey, kubeconfigSecret.Name)
+		conditions.MarkFalse(
+			acp,
+			controlplanev1beta1.KubeconfigAvailableCondition,
+			controlplanev1beta1.KubeconfigUnavailableFailedReason,
+			clusterv1.ConditionSeverityInfo,
+			"error retrieving Kubeconfig %v", err,
+		)
+		return err
+	}
+
+	if err := r.syncKubeconfig(ctx, kubeconfig, clusterName, *acp); err != nil {
+		conditions.MarkFalse(
+			acp,
+			controlplanev1beta1.KubeconfigAvailableCondition,
+			controlplanev1beta1.KubeconfigUnavailableFailedReason,
+			clusterv1.ConditionSeverityInfo,
+			"error syncing Kubeconfig secret: %v", err,
+		)
+		return err
+	}
+
+	// the API of the workload cluster is only expected to be reachable once the cluster is installed
+	if isInstalled(aci) {
+		if err := r.validateKubeconfig(ctx, kubeconfig); err != nil {
+			ctrl.LoggerFrom(ctx).V(logutil.WarningLevel).Info("failed to connect to the workload cluster", "error", err.Error())
 			conditions.MarkFalse(
 				acp,
-				controlplanev1alpha2.KubeconfigAvailableCondition,
-				controlplanev1alpha2.KubeconfigUnavailableFailedReason,
-				clusterv1.ConditionSeverityInfo,
-				"error creating Kubeconfig secret: %v", err,
+				controlplanev1beta1.KubeconfigAvailableCondition,
+				controlplanev1beta1.KubeconfigConnectionFailedReason,
+				clusterv1.ConditionSeverityWarning,
+				"error connecting to the workload cluster with the Kubeconfig: %v", err,
 			)
-			return err
+		} else {
+			conditions.MarkTrue(acp, controlplanev1beta1.KubeconfigAvailableCondition)
 		}
+	} else {
+		conditions.MarkTrue(acp, controlplanev1beta1.KubeconfigAvailableCondition)
 	}
-	conditions.MarkTrue(acp, controlplanev1alpha2.KubeconfigAvailableCondition)
 
 	acp.Status.Initialized = true
+	acp.Status.Initialization = &controlplanev1beta1.OpenshiftAssistedControlPlaneInitializationStatus{
+		ControlPlaneInitialized: true,
+	}
 	if err := r.Client.Status().Update(ctx, acp); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (r *AgentClusterInstallReconciler) createKubeconfig(
+// syncKubeconfig creates the <cluster-name>-kubeconfig secret from the admin kubeconfig of the AgentClusterInstall, or
+// updates it when the admin kubeconfig was regenerated
+func (r *AgentClusterInstallReconciler) syncKubeconfig(
 	ctx context.Context,
-	kubeconfigSecret *corev1.Secret,
+	kubeconfig []byte,
 	clusterName string,
-	acp controlplanev1alpha2.OpenshiftAssistedControlPlane,
+	acp controlplanev1beta1.OpenshiftAssistedControlPlane,
 ) error {
-	kubeconfig, ok := kubeconfigSecret.Data[kubeconfigSecretKey]
-	if !ok {
-		return fmt.Errorf("kubeconfig with key `%s` not found in secret %s", kubeconfigSecretKey, kubeconfigSecret.Name)
-	}
 	// Create secret <cluster-name>-kubeconfig from original kubeconfig secret - this is what the CAPI Cluster looks for to set the control plane as initialized
 	clusterNameKubeconfigSecret := GenerateSecretWithOwner(
 		client.ObjectKey{Name: clusterName, Namespace: acp.Namespace},
 		kubeconfig,
-		*metav1.NewControllerRef(&acp, controlplanev1alpha2.GroupVersion.WithKind(openshiftAssistedControlPlaneKind)),
+		*metav1.NewControllerRef(&acp, controlplanev1beta1.GroupVersion.WithKind(openshiftAssistedControlPlaneKind)),
 	)
-	if err := r.Client.Create(ctx, clusterNameKubeconfigSecret); err != nil {
-		if !apierrors.IsAlreadyExists(err) {
+	existingSecret := &corev1.Secret{}
+	if err := r.Client.Get(ctx, client.ObjectKeyFromObject(clusterNameKubeconfigSecret), existingSecret); err != nil {
+		if !apierrors.IsNotFound(err) {
 			return err
 		}
-		if err := r.Client.Update(ctx, clusterNameKubeconfigSecret); err != nil {
+		return r.Client.Create(ctx, clusterNameKubeconfigSecret)
+	}
+	if bytes.Equal(existingSecret.Data[kubeconfigSecretValueKey], kubeconfig) {
+		return nil
+	}
+	ctrl.LoggerFrom(ctx).Info("updating the kubeconfig secret from the admin kubeconfig", "secret", existingSecret.Name)
+	if existingSecret.Data == nil {
+		existingSecret.Data = make(map[string][]byte)
+	}
+	existingSecret.Data[kubeconfigSecretValueKey] = kubeconfig
+	return r.Client.Update(ctx, existingSecret)
+}
+
+// validateKubeconfig checks that the API of the workload cluster can be reached with the kubeconfig
+func (r *AgentClusterInstallReconciler) validateKubeconfig(ctx context.Context, kubeconfig []byte) error {
+	workloadClient, err := r.WorkloadClientGenerator.GetWorkloadClusterClient(kubeconfig)
+	if err != nil {
+		return err
+	}
+	return workloadClient.Get(ctx, client.ObjectKey{Name: metav1.NamespaceDefault}, &corev1.Namespace{})
+}
+
+// syncKubeadminPassword creates the <cluster-name>-kubeadmin secret from the admin password secret of the
+// AgentClusterInstall, or updates it when the admin password secret changed
+func (r *AgentClusterInstallReconciler) syncKubeadminPassword(
+	ctx context.Context,
+	aci *hiveext.AgentClusterInstall,
+	acp controlplanev1beta1.OpenshiftAssistedControlPlane,
+) error {
+	if !hasAdminPasswordRef(aci) {
+		return nil
+	}
+	adminPasswordSecret := &corev1.Secret{}
+	if err := r.Client.Get(ctx, client.ObjectKey{
+		Name:      aci.Spec.ClusterMetadata.AdminPasswordSecretRef.Name,
+		Namespace: aci.Namespace,
+	}, adminPasswordSecret); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	clusterName := acp.Labels[clusterv1.ClusterNameLabel]
+	kubeadminSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-kubeadmin", clusterName),
+			Namespace: acp.Namespace,
+		},
+	}
+	existingSecret := &corev1.Secret{}
+	if err := r.Client.Get(ctx, client.ObjectKeyFromObject(kubeadminSecret), existingSecret); err != nil {
+		if !apierrors.IsNotFound(err) {
 			return err
 		}
+		kubeadminSecret.Labels = map[string]string{clusterv1.ClusterNameLabel: clusterName}
+		kubeadminSecret.OwnerReferences = []metav1.OwnerReference{
+			*metav1.NewControllerRef(&acp, controlplanev1beta1.GroupVersion.WithKind(openshiftAssistedControlPlaneKind)),
+		}
+		kubeadminSecret.Data = adminPasswordSecret.Data
+		return r.Client.Create(ctx, kubeadminSecret)
+	}
+	if equality.Semantic.DeepEqual(existingSecret.Data, adminPasswordSecret.Data) {
+		return nil
+	}
+	existingSecret.Data = adminPasswordSecret.Data
+	return r.Client.Update(ctx, existingSecret)
+}
+
+// setClusterMetadata sets the identifiers of the workload cluster, from the AgentClusterInstall, and its URLs, from
+// the ClusterDeployment, in the status of the OpenshiftAssistedControlPlane
+func (r *AgentClusterInstallReconciler) setClusterMetadata(
+	ctx context.Context,
+	aci *hiveext.AgentClusterInstall,
+	acp *controlplanev1beta1.OpenshiftAssistedControlPlane,
+) error {
+	if aci.Spec.ClusterMetadata != nil {
+		acp.Status.ClusterID = aci.Spec.ClusterMetadata.ClusterID
+		acp.Status.InfraID = aci.Spec.ClusterMetadata.InfraID
+	}
+	if aci.Spec.ClusterDeploymentRef.Name == "" {
+		return nil
+	}
+	cd := &hivev1.ClusterDeployment{}
+	if err := r.Client.Get(ctx, client.ObjectKey{Name: aci.Spec.ClusterDeploymentRef.Name, Namespace: aci.Namespace}, cd); err != nil {
+		return client.IgnoreNotFound(err)
 	}
+	acp.Status.APIURL = cd.Status.APIURL
+	acp.Status.ConsoleURL = cd.Status.WebConsoleURL
 	return nil
 }
 
@@ -207,7 +474,7 @@ func (r *AgentClusterInstallReconciler) updateLabels(
 func (r *AgentClusterInstallReconciler) getACIKubeconfig(
 	ctx context.Context,
 	aci *hiveext.AgentClusterInstall,
-	openshiftAssistedCP controlplanev1alpha2.OpenshiftAssistedControlPlane,
+	openshiftAssistedCP controlplanev1beta1.OpenshiftAssistedControlPlane,
 ) (*corev1.Secret, error) {
 	secretName := aci.Spec.ClusterMetadata.AdminKubeconfigSecretRef.Name
 
@@ -223,23 +490,16 @@ func hasKubeconfigRef(aci *hiveext.AgentClusterInstall) bool {
 	return aci.Spec.ClusterMetadata != nil && aci.Spec.ClusterMetadata.AdminKubeconfigSecretRef.Name != ""
 }
 
-func isInstalled(aci *hiveext.AgentClusterInstall) bool {
-	return aci.Status.DebugInfo.State == aimodels.ClusterStatusAddingHosts
+func hasAdminPasswordRef(aci *hiveext.AgentClusterInstall) bool {
+	return aci.Spec.ClusterMetadata != nil && aci.Spec.ClusterMetadata.AdminPasswordSecretRef != nil &&
+		aci.Spec.ClusterMetadata.AdminPasswordSecretRef.Name != ""
 }
 
-func (r *AgentClusterInstallReconciler) ClusterKubeconfigSecretExists(
-	ctx context.Context,
-	clusterName, namespace string,
-) bool {
-	secretName := fmt.Sprintf("%s-kubeconfig", clusterName)
-	kubeconfigSecret := &corev1.Secret{}
-	if err := r.Client.Get(ctx, client.ObjectKey{Name: secretName, Namespace: namespace}, kubeconfigSecret); err != nil {
-		return !apierrors.IsNotFound(err)
-	}
-	return true
+func isInstalled(aci *hiveext.AgentClusterInstall) bool {
+	return aci.Status.DebugInfo.State == aimodels.ClusterStatusAddingHosts
 }
 
-func (r *AgentClusterInstallReconciler) updateControlplaneStatus(ctx context.Context, oacp *controlplanev1alpha2.OpenshiftAssistedControlPlane) error {
+func (r *AgentClusterInstallReconciler) updateControlplaneStatus(ctx context.Context, oacp *controlplanev1beta1.OpenshiftAssistedControlPlane) error {
 	if err := r.Client.Status().Update(ctx, oacp); err != nil {
 		return err
 	}
@@ -260,7 +520,7 @@ func GenerateSecretWithOwner(clusterName client.ObjectKey, data []byte, owner me
 			},
 		},
 		Data: map[string][]byte{
-			"value": data,
+			kubeconfigSecretValueKey: data,
 		},
 		Type: clusterv1.ClusterSecretType,
 	}
@@ -18,15 +18,18 @@ package controller
 
 import (
 	"context"
+	"errors"
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
-	controlplanev1alpha2 "github.com/openshift-assisted/cluster-api-agent/controlplane/api/v1alpha2"
+	controlplanev1beta1 "github.com/openshift-assisted/cluster-api-agent/controlplane/api/v1beta1"
+	"github.com/openshift-assisted/cluster-api-agent/pkg/workloadclient"
 	hiveext "github.com/openshift/assisted-service/api/hiveextension/v1beta1"
 	aimodels "github.com/openshift/assisted-service/models"
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
 
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -47,15 +50,17 @@ var _ = Describe("AgentClusterInstall Controller", func() {
 			namespace                         = "test-namespace"
 			agentClusterInstallName           = "test-aci"
 			adminKubeconfigSecret             = "test-admin-kubeconfig"
+			adminPasswordSecret               = "test-admin-password"
 			kubeconfigSecret                  = clusterName + "-kubeconfig"
 		)
 		var (
 			ctx                           = context.Background()
 			aciNamespacedName             types.NamespacedName
-			openshiftAssistedControlPlane *controlplanev1alpha2.OpenshiftAssistedControlPlane
+			openshiftAssistedControlPlane *controlplanev1beta1.OpenshiftAssistedControlPlane
 			aci                           *hiveext.AgentClusterInstall
 			reconciler                    *AgentClusterInstallReconciler
 			mockCtrl                      *gomock.Controller
+			mockClientGenerator           *workloadclient.MockClientGenerator
 			k8sClient                     client.Client
 		)
 
@@ -63,7 +68,7 @@ var _ = Describe("AgentClusterInstall Controller", func() {
 			mockCtrl = gomock.NewController(GinkgoT())
 			k8sClient = fakeclient.NewClientBuilder().
 				WithScheme(testScheme).
-				WithStatusSubresource(&controlplanev1alpha2.OpenshiftAssistedControlPlane{}, &hiveext.AgentClusterInstall{}).
+				WithStatusSubresource(&controlplanev1beta1.OpenshiftAssistedControlPlane{}, &hiveext.AgentClusterInstall{}, &hivev1.ClusterDeployment{}).
 				Build()
 			Expect(k8sClient).NotTo(BeNil())
 			aciNamespacedName = types.NamespacedName{
@@ -71,15 +76,17 @@ var _ = Describe("AgentClusterInstall Controller", func() {
 				Namespace: namespace,
 			}
 
+			mockClientGenerator = workloadclient.NewMockClientGenerator(mockCtrl)
 			reconciler = &AgentClusterInstallReconciler{
-				Client: k8sClient,
-				Scheme: k8sClient.Scheme(),
+				Client:                  k8sClient,
+				Scheme:                  k8sClient.Scheme(),
+				WorkloadClientGenerator: mockClientGenerator,
 			}
 
-			openshiftAssistedControlPlane = &controlplanev1alpha2.OpenshiftAssistedControlPlane{
+			openshiftAssistedControlPlane = &controlplanev1beta1.OpenshiftAssistedControlPlane{
 				TypeMeta: metav1.TypeMeta{
 					Kind:       "OpenshiftAssistedControlPlane",
-					APIVersion: controlplanev1alpha2.GroupVersion.String(),
+					APIVersion: controlplanev1beta1.GroupVersion.String(),
 				},
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      openshiftAssistedControlPlaneName,
@@ -140,7 +147,7 @@ var _ = Describe("AgentClusterInstall Controller", func() {
 				Expect(res).To(Equal(ctrl.Result{}))
 
 				By("Checking that the OpenshiftAssistedControlPlane status was not changed")
-				acp := &controlplanev1alpha2.OpenshiftAssistedControlPlane{}
+				acp := &controlplanev1beta1.OpenshiftAssistedControlPlane{}
 				Expect(
 					k8sClient.Get(ctx, types.NamespacedName{Name: openshiftAssistedControlPlaneName, Namespace: namespace}, acp),
 				).To(Succeed())
@@ -173,7 +180,7 @@ var _ = Describe("AgentClusterInstall Controller", func() {
 			).NotTo(Succeed())
 
 			By("Checking that the OpenshiftAssistedControlPlane status was not changed")
-			acp := &controlplanev1alpha2.OpenshiftAssistedControlPlane{}
+			acp := &controlplanev1beta1.OpenshiftAssistedControlPlane{}
 			Expect(
 				k8sClient.Get(ctx, types.NamespacedName{Name: openshiftAssistedControlPlaneName, Namespace: namespace}, acp),
 			).To(Succeed())
@@ -230,11 +237,13 @@ var _ = Describe("AgentClusterInstall Controller", func() {
 			Expect(kubeconfig.Labels).To(HaveKeyWithValue(clusterv1.ClusterNameLabel, clusterName))
 
 			By("Checking that the OpenshiftAssistedControlPlane status is correct")
-			acp := &controlplanev1alpha2.OpenshiftAssistedControlPlane{}
+			acp := &controlplanev1beta1.OpenshiftAssistedControlPlane{}
 			Expect(
 				k8sClient.Get(ctx, types.NamespacedName{Name: openshiftAssistedControlPlaneName, Namespace: namespace}, acp),
 			).To(Succeed())
 			Expect(acp.Status.Initialized).To(BeTrue())
+			Expect(acp.Status.Initialization).NotTo(BeNil())
+			Expect(acp.Status.Initialization.ControlPlaneInitialized).To(BeTrue())
 			Expect(acp.Status.Ready).NotTo(BeTrue())
 		})
 
@@ -263,22 +272,279 @@ var _ = Describe("AgentClusterInstall Controller", func() {
 			By("Updating the AgentClusterInstall's status")
 			aci.Status.DebugInfo.State = aimodels.ClusterStatusAddingHosts
 			Expect(k8sClient.Status().Update(ctx, aci)).To(Succeed())
+			workloadClient := fakeclient.NewClientBuilder().WithObjects(
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: metav1.NamespaceDefault}},
+			).Build()
+			mockClientGenerator.EXPECT().GetWorkloadClusterClient([]byte("test-kubeconfig-data")).Return(workloadClient, nil)
 
 			By("Reconciling the AgentClusterInstall")
 			res, err := reconciler.Reconcile(ctx, reconcile.Request{
 				NamespacedName: aciNamespacedName,
 			})
 			Expect(err).NotTo(HaveOccurred())
-			Expect(res).To(Equal(ctrl.Result{}))
+			Expect(res).To(Equal(ctrl.Result{RequeueAfter: kubeconfigValidationInterval}))
 
 			By("Checking that the OpenshiftAssistedControlPlane status is correct")
-			acp := &controlplanev1alpha2.OpenshiftAssistedControlPlane{}
+			acp := &controlplanev1beta1.OpenshiftAssistedControlPlane{}
 			Expect(
 				k8sClient.Get(ctx, types.NamespacedName{Name: openshiftAssistedControlPlaneName, Namespace: namespace}, acp),
 			).To(Succeed())
 			Expect(acp.Status.Initialized).To(BeTrue())
 			Expect(acp.Status.Ready).To(BeTrue())
 		})
+
+		When("the admin kubeconfig of the AgentClusterInstall exists", func() {
+			var adminSecret *corev1.Secret
+
+			BeforeEach(func() {
+				Expect(controllerutil.SetOwnerReference(openshiftAssistedControlPlane, aci, k8sClient.Scheme())).To(Succeed())
+				aci.Spec.ClusterMetadata = &hivev1.ClusterMetadata{
+					AdminKubeconfigSecretRef: corev1.LocalObjectReference{
+						Name: adminKubeconfigSecret,
+					},
+				}
+				Expect(k8sClient.Update(ctx, aci)).To(Succeed())
+				adminSecret = &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      adminKubeconfigSecret,
+						Namespace: namespace,
+					},
+					Data: map[string][]byte{
+						"kubeconfig": []byte("test-kubeconfig-data"),
+					},
+				}
+				Expect(k8sClient.Create(ctx, adminSecret)).To(Succeed())
+			})
+
+			It("should update the cluster kubeconfig secret when the admin kubeconfig changes", func() {
+				_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: aciNamespacedName})
+				Expect(err).NotTo(HaveOccurred())
+
+				By("Rotating the admin kubeconfig")
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(adminSecret), adminSecret)).To(Succeed())
+				adminSecret.Data["kubeconfig"] = []byte("rotated-kubeconfig-data")
+				Expect(k8sClient.Update(ctx, adminSecret)).To(Succeed())
+
+				_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: aciNamespacedName})
+				Expect(err).NotTo(HaveOccurred())
+
+				kubeconfig := &corev1.Secret{}
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: kubeconfigSecret, Namespace: namespace}, kubeconfig)).To(Succeed())
+				Expect(kubeconfig.Data).To(HaveKeyWithValue("value", []byte("rotated-kubeconfig-data")))
+				Expect(kubeconfig.OwnerReferences).To(HaveLen(1))
+			})
+
+			It("should set KubeconfigAvailable to false when the installed workload cluster cannot be reached", func() {
+				aci.Status.DebugInfo.State = aimodels.ClusterStatusAddingHosts
+				Expect(k8sClient.Status().Update(ctx, aci)).To(Succeed())
+				mockClientGenerator.EXPECT().GetWorkloadClusterClient([]byte("test-kubeconfig-data")).
+					Return(nil, errors.New("certificate signed by unknown authority"))
+
+				res, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: aciNamespacedName})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(res).To(Equal(ctrl.Result{RequeueAfter: kubeconfigValidationInterval}))
+
+				acp := &controlplanev1beta1.OpenshiftAssistedControlPlane{}
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(openshiftAssistedControlPlane), acp)).To(Succeed())
+				condition := conditions.Get(acp, controlplanev1beta1.KubeconfigAvailableCondition)
+				Expect(condition).NotTo(BeNil())
+				Expect(condition.Status).To(Equal(corev1.ConditionFalse))
+				Expect(condition.Reason).To(Equal(controlplanev1beta1.KubeconfigConnectionFailedReason))
+				Expect(condition.Message).To(ContainSubstring("certificate signed by unknown authority"))
+			})
+
+			It("should not connect to the workload cluster while it is installing", func() {
+				aci.Status.DebugInfo.State = aimodels.ClusterStatusInstalling
+				Expect(k8sClient.Status().Update(ctx, aci)).To(Succeed())
+
+				res, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: aciNamespacedName})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(res).To(Equal(ctrl.Result{}))
+
+				acp := &controlplanev1beta1.OpenshiftAssistedControlPlane{}
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(openshiftAssistedControlPlane), acp)).To(Succeed())
+				Expect(conditions.IsTrue(acp, controlplanev1beta1.KubeconfigAvailableCondition)).To(BeTrue())
+			})
+
+			It("should map the admin kubeconfig secret to the AgentClusterInstall", func() {
+				Expect(reconciler.mapAdminSecretToAgentClusterInstall(ctx, adminSecret)).To(ConsistOf(
+					ctrl.Request{NamespacedName: aciNamespacedName},
+				))
+				otherSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: namespace}}
+				Expect(reconciler.mapAdminSecretToAgentClusterInstall(ctx, otherSecret)).To(BeEmpty())
+			})
+
+			It("should copy the admin password to the kubeadmin secret of the cluster", func() {
+				aci.Spec.ClusterMetadata.AdminPasswordSecretRef = &corev1.LocalObjectReference{Name: adminPasswordSecret}
+				Expect(k8sClient.Update(ctx, aci)).To(Succeed())
+				passwordSecret := &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      adminPasswordSecret,
+						Namespace: namespace,
+					},
+					Data: map[string][]byte{
+						"username": []byte("kubeadmin"),
+						"password": []byte("test-password"),
+					},
+				}
+				Expect(k8sClient.Create(ctx, passwordSecret)).To(Succeed())
+				Expect(reconciler.mapAdminSecretToAgentClusterInstall(ctx, passwordSecret)).To(ConsistOf(
+					ctrl.Request{NamespacedName: aciNamespacedName},
+				))
+
+				_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: aciNamespacedName})
+				Expect(err).NotTo(HaveOccurred())
+
+				kubeadmin := &corev1.Secret{}
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: clusterName + "-kubeadmin", Namespace: namespace}, kubeadmin)).To(Succeed())
+				Expect(kubeadmin.Labels).To(HaveKeyWithValue(clusterv1.ClusterNameLabel, clusterName))
+				Expect(kubeadmin.OwnerReferences).To(HaveLen(1))
+				Expect(kubeadmin.OwnerReferences[0].Name).To(Equal(openshiftAssistedControlPlaneName))
+				Expect(kubeadmin.Data).To(Equal(passwordSecret.Data))
+
+				By("Changing the admin password")
+				passwordSecret.Data["password"] = []byte("new-password")
+				Expect(k8sClient.Update(ctx, passwordSecret)).To(Succeed())
+
+				_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: aciNamespacedName})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(kubeadmin), kubeadmin)).To(Succeed())
+				Expect(kubeadmin.Data).To(HaveKeyWithValue("password", []byte("new-password")))
+			})
+
+			It("should report the identifiers and the URLs of the workload cluster", func() {
+				aci.Spec.ClusterMetadata.ClusterID = "test-cluster-id"
+				aci.Spec.ClusterMetadata.InfraID = "test-infra-id"
+				aci.Spec.ClusterDeploymentRef = corev1.LocalObjectReference{Name: clusterName}
+				Expect(k8sClient.Update(ctx, aci)).To(Succeed())
+				cd := &hivev1.ClusterDeployment{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      clusterName,
+						Namespace: namespace,
+					},
+					Spec: hivev1.ClusterDeploymentSpec{
+						ClusterInstallRef: &hivev1.ClusterInstallLocalReference{
+							Group:   hiveext.Group,
+							Version: hiveext.Version,
+							Kind:    "AgentClusterInstall",
+							Name:    agentClusterInstallName,
+						},
+					},
+				}
+				Expect(k8sClient.Create(ctx, cd)).To(Succeed())
+				cd.Status.APIURL = "https://api.test-cluster.example.com:6443"
+				cd.Status.WebConsoleURL = "https://console-openshift-console.apps.test-cluster.example.com"
+				Expect(k8sClient.Status().Update(ctx, cd)).To(Succeed())
+				Expect(mapClusterDeploymentToAgentClusterInstall(ctx, cd)).To(ConsistOf(
+					ctrl.Request{NamespacedName: aciNamespacedName},
+				))
+
+				_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: aciNamespacedName})
+				Expect(err).NotTo(HaveOccurred())
+
+				acp := &controlplanev1beta1.OpenshiftAssistedControlPlane{}
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(openshiftAssistedControlPlane), acp)).To(Succeed())
+				Expect(acp.Status.ClusterID).To(Equal("test-cluster-id"))
+				Expect(acp.Status.InfraID).To(Equal("test-infra-id"))
+				Expect(acp.Status.APIURL).To(Equal("https://api.test-cluster.example.com:6443"))
+				Expect(acp.Status.ConsoleURL).To(Equal("https://console-openshift-console.apps.test-cluster.example.com"))
+			})
+		})
+
+		DescribeTable("should map the AgentClusterInstall conditions to the ControlPlaneReady condition",
+			func(aciConditions []hivev1.ClusterInstallCondition, expectedReason string, expectedSeverity clusterv1.ConditionSeverity, terminal bool) {
+				Expect(controllerutil.SetOwnerReference(openshiftAssistedControlPlane, aci, k8sClient.Scheme())).To(Succeed())
+				Expect(k8sClient.Update(ctx, aci)).To(Succeed())
+				aci.Status.DebugInfo.State = aimodels.ClusterStatusInsufficient
+				aci.Status.Conditions = aciConditions
+				Expect(k8sClient.Status().Update(ctx, aci)).To(Succeed())
+
+				_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: aciNamespacedName})
+				Expect(err).NotTo(HaveOccurred())
+
+				acp := &controlplanev1beta1.OpenshiftAssistedControlPlane{}
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(openshiftAssistedControlPlane), acp)).To(Succeed())
+				condition := conditions.Get(acp, controlplanev1beta1.ControlPlaneReadyCondition)
+				Expect(condition).NotTo(BeNil())
+				Expect(condition.Status).To(Equal(corev1.ConditionFalse))
+				Expect(condition.Reason).To(Equal(expectedReason))
+				Expect(condition.Severity).To(Equal(expectedSeverity))
+				if terminal {
+					Expect(acp.Status.FailureReason).To(HaveValue(Equal(expectedReason)))
+					Expect(acp.Status.FailureMessage).To(HaveValue(ContainSubstring("test message")))
+				} else {
+					Expect(acp.Status.FailureReason).To(BeNil())
+					Expect(acp.Status.FailureMessage).To(BeNil())
+				}
+			},
+			Entry("installing", []hivev1.ClusterInstallCondition{
+				{Type: hiveext.ClusterRequirementsMetCondition, Status: corev1.ConditionTrue},
+				{Type: hiveext.ClusterValidatedCondition, Status: corev1.ConditionTrue},
+			}, controlplanev1beta1.ControlPlaneInstallingReason, clusterv1.ConditionSeverityInfo, false),
+			Entry("installation failed", []hivev1.ClusterInstallCondition{
+				{Type: hiveext.ClusterFailedCondition, Status: corev1.ConditionTrue, Reason: hiveext.ClusterFailedReason, Message: "test message"},
+				{Type: hiveext.ClusterStoppedCondition, Status: corev1.ConditionTrue, Reason: hiveext.ClusterStoppedFailedReason},
+			}, controlplanev1beta1.ControlPlaneInstallationFailedReason, clusterv1.ConditionSeverityError, true),
+			Entry("installation cancelled", []hivev1.ClusterInstallCondition{
+				{Type: hiveext.ClusterFailedCondition, Status: corev1.ConditionFalse, Reason: hiveext.ClusterNotFailedReason},
+				{Type: hiveext.ClusterStoppedCondition, Status: corev1.ConditionTrue, Reason: hiveext.ClusterStoppedCanceledReason, Message: "test message"},
+			}, controlplanev1beta1.ControlPlaneInstallationStoppedReason, clusterv1.ConditionSeverityError, true),
+			Entry("validations failing", []hivev1.ClusterInstallCondition{
+				{Type: hiveext.ClusterValidatedCondition, Status: corev1.ConditionFalse, Reason: hiveext.ClusterValidationsFailingReason, Message: "test message"},
+				{Type: hiveext.ClusterRequirementsMetCondition, Status: corev1.ConditionFalse, Reason: hiveext.ClusterNotReadyReason},
+			}, controlplanev1beta1.ControlPlaneValidationsFailingReason, clusterv1.ConditionSeverityWarning, false),
+			Entry("requirements not met", []hivev1.ClusterInstallCondition{
+				{Type: hiveext.ClusterValidatedCondition, Status: corev1.ConditionTrue},
+				{Type: hiveext.ClusterRequirementsMetCondition, Status: corev1.ConditionFalse, Reason: hiveext.ClusterInsufficientAgentsReason, Message: "test message"},
+			}, controlplanev1beta1.ControlPlaneRequirementsNotMetReason, clusterv1.ConditionSeverityWarning, false),
+		)
+
+		It("should mirror the installation progress of the AgentClusterInstall", func() {
+			Expect(controllerutil.SetOwnerReference(openshiftAssistedControlPlane, aci, k8sClient.Scheme())).To(Succeed())
+			Expect(k8sClient.Update(ctx, aci)).To(Succeed())
+			aci.Status.DebugInfo.State = aimodels.ClusterStatusInstalling
+			aci.Status.DebugInfo.StateInfo = "Installation in progress"
+			aci.Status.Progress.TotalPercentage = 42
+			Expect(k8sClient.Status().Update(ctx, aci)).To(Succeed())
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: aciNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			acp := &controlplanev1beta1.OpenshiftAssistedControlPlane{}
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(openshiftAssistedControlPlane), acp)).To(Succeed())
+			Expect(acp.Status.InstallationProgress).To(Equal(&controlplanev1beta1.InstallationProgress{
+				State:           aimodels.ClusterStatusInstalling,
+				StateInfo:       "Installation in progress",
+				TotalPercentage: 42,
+			}))
+			condition := conditions.Get(acp, controlplanev1beta1.ControlPlaneReadyCondition)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Message).To(Equal("Controlplane installing, status: installing, progress: 42%"))
+		})
+
+		It("should clear the failure of a retried installation", func() {
+			Expect(controllerutil.SetOwnerReference(openshiftAssistedControlPlane, aci, k8sClient.Scheme())).To(Succeed())
+			Expect(k8sClient.Update(ctx, aci)).To(Succeed())
+			failureReason := controlplanev1beta1.ControlPlaneInstallationFailedReason
+			failureMessage := "Controlplane installation failed"
+			openshiftAssistedControlPlane.Status.FailureReason = &failureReason
+			openshiftAssistedControlPlane.Status.FailureMessage = &failureMessage
+			Expect(k8sClient.Status().Update(ctx, openshiftAssistedControlPlane)).To(Succeed())
+			aci.Status.DebugInfo.State = aimodels.ClusterStatusInstalling
+			aci.Status.Conditions = []hivev1.ClusterInstallCondition{
+				{Type: hiveext.ClusterFailedCondition, Status: corev1.ConditionFalse, Reason: hiveext.ClusterNotFailedReason},
+			}
+			Expect(k8sClient.Status().Update(ctx, aci)).To(Succeed())
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: aciNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			acp := &controlplanev1beta1.OpenshiftAssistedControlPlane{}
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(openshiftAssistedControlPlane), acp)).To(Succeed())
+			Expect(acp.Status.FailureReason).To(BeNil())
+			Expect(acp.Status.FailureMessage).To(BeNil())
+			Expect(conditions.GetReason(acp, controlplanev1beta1.ControlPlaneReadyCondition)).To(Equal(controlplanev1beta1.ControlPlaneInstallingReason))
+		})
 	})
 })
 
@@ -24,7 +24,7 @@ import (
 	"slices"
 	"strings"
 
-	controlplanev1alpha2 "github.com/openshift-assisted/cluster-api-agent/controlplane/api/v1alpha2"
+	controlplanev1beta1 "github.com/openshift-assisted/cluster-api-agent/controlplane/api/v1beta1"
 	"github.com/openshift-assisted/cluster-api-agent/controlplane/internal/imageregistry"
 	"github.com/openshift-assisted/cluster-api-agent/controlplane/internal/release"
 	"github.com/openshift-assisted/cluster-api-agent/util"
@@ -41,6 +41,8 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	capiutil "sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/patch"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -66,7 +68,7 @@ type ClusterDeploymentReconciler struct {
 func (r *ClusterDeploymentReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&hivev1.ClusterDeployment{}).
-		Watches(&controlplanev1alpha2.OpenshiftAssistedControlPlane{}, &handler.EnqueueRequestForObject{}).
+		Watches(&controlplanev1beta1.OpenshiftAssistedControlPlane{}, &handler.EnqueueRequestForObject{}).
 		Watches(&clusterv1.MachineDeployment{}, &handler.EnqueueRequestForObject{}).
 		Complete(r)
 }
@@ -81,7 +83,7 @@ func (r *ClusterDeploymentReconciler) Reconcile(ctx context.Context, req ctrl.Re
 	log.WithValues("cluster_deployment", clusterDeployment.Name, "cluster_deployment_namespace", clusterDeployment.Namespace)
 	log.V(logutil.TraceLevel).Info("Reconciling ClusterDeployment")
 
-	acp := controlplanev1alpha2.OpenshiftAssistedControlPlane{}
+	acp := controlplanev1beta1.OpenshiftAssistedControlPlane{}
 	if err := util.GetTypedOwner(ctx, r.Client, clusterDeployment, &acp); err != nil {
 		log.V(logutil.TraceLevel).Info("Cluster deployment is not owned by OpenshiftAssistedControlPlane")
 		return ctrl.Result{}, nil
@@ -94,7 +96,7 @@ func (r *ClusterDeploymentReconciler) Reconcile(ctx context.Context, req ctrl.Re
 func (r *ClusterDeploymentReconciler) ensureAgentClusterInstall(
 	ctx context.Context,
 	clusterDeployment *hivev1.ClusterDeployment,
-	oacp controlplanev1alpha2.OpenshiftAssistedControlPlane,
+	oacp controlplanev1beta1.OpenshiftAssistedControlPlane,
 ) (ctrl.Result, error) {
 	log := ctrl.LoggerFrom(ctx)
 
@@ -115,6 +117,13 @@ func (r *ClusterDeploymentReconciler) ensureAgentClusterInstall(
 		return ctrl.Result{}, err
 	}
 
+	// the ACI is not created until the networking is fixed, as the installation would fail. Changes to the
+	// OpenshiftAssistedControlPlane trigger a new reconcile.
+	if err := validateIPStackOrder(cluster, &oacp); err != nil {
+		log.Error(err, "invalid networking configuration")
+		return ctrl.Result{}, r.markNetworkingInvalid(ctx, &oacp, err)
+	}
+
 	workerNodes := r.getWorkerNodesCount(ctx, cluster)
 	aci, err := r.computeAgentClusterInstall(ctx, clusterDeployment, oacp, imageSet, cluster, workerNodes)
 	if err != nil {
@@ -134,7 +143,7 @@ func (r *ClusterDeploymentReconciler) ensureAgentClusterInstall(
 // quay.io/openshift-release-dev/ocp-release:4.17.0-rc.2-x86_64
 // quay.io/okd/scos-release:4.18.0-okd-scos.ec.1
 // Can be overridden with annotation: cluster.x-k8s.io/release-image-repository-override=quay.io/myorg/myrepo
-func getReleaseImage(oacp controlplanev1alpha2.OpenshiftAssistedControlPlane, architecture string) string {
+func getReleaseImage(oacp controlplanev1beta1.OpenshiftAssistedControlPlane, architecture string) string {
 	releaseImageRepository, ok := oacp.Annotations[release.ReleaseImageRepositoryOverrideAnnotation]
 	if !ok {
 		releaseImageRepository = ""
@@ -158,6 +167,30 @@ func (r *ClusterDeploymentReconciler) getWorkerNodesCount(ctx context.Context, c
 	return count
 }
 
+// markNetworkingInvalid reports the invalid networking configuration with the ControlPlaneReady condition of the
+// OpenshiftAssistedControlPlane, only patching the condition
+func (r *ClusterDeploymentReconciler) markNetworkingInvalid(
+	ctx context.Context,
+	oacp *controlplanev1beta1.OpenshiftAssistedControlPlane,
+	err error,
+) error {
+	patchHelper, patchErr := patch.NewHelper(oacp, r.Client)
+	if patchErr != nil {
+		return patchErr
+	}
+	conditions.MarkFalse(
+		oacp,
+		controlplanev1beta1.ControlPlaneReadyCondition,
+		controlplanev1beta1.ControlPlaneNetworkingInvalidReason,
+		clusterv1.ConditionSeverityError,
+		"invalid networking configuration: %s",
+		err.Error(),
+	)
+	return patchHelper.Patch(ctx, oacp, patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{
+		controlplanev1beta1.ControlPlaneReadyCondition,
+	}})
+}
+
 func (r *ClusterDeploymentReconciler) updateClusterDeploymentRef(
 	ctx context.Context,
 	cd *hivev1.ClusterDeployment,
@@ -186,23 +219,19 @@ func computeClusterImageSet(imageSetName string, releaseImage string) *hivev1.Cl
 func (r *ClusterDeploymentReconciler) computeAgentClusterInstall(
 	ctx context.Context,
 	clusterDeployment *hivev1.ClusterDeployment,
-	acp controlplanev1alpha2.OpenshiftAssistedControlPlane,
+	acp controlplanev1beta1.OpenshiftAssistedControlPlane,
 	imageSet *hivev1.ClusterImageSet,
 	cluster *clusterv1.Cluster,
 	workerReplicas int,
 ) (*hiveext.AgentClusterInstall, error) {
 	log := ctrl.LoggerFrom(ctx)
-	var clusterNetwork []hiveext.ClusterNetworkEntry
-
-	if cluster.Spec.ClusterNetwork != nil && cluster.Spec.ClusterNetwork.Pods != nil {
-		for _, cidrBlock := range cluster.Spec.ClusterNetwork.Pods.CIDRBlocks {
-			clusterNetwork = append(clusterNetwork, hiveext.ClusterNetworkEntry{CIDR: cidrBlock, HostPrefix: 23})
-		}
-	}
+	clusterNetwork := getClusterNetworks(cluster, acp.Spec.Config.Networking)
 	var serviceNetwork []string
 	if cluster.Spec.ClusterNetwork != nil && cluster.Spec.ClusterNetwork.Services != nil {
 		serviceNetwork = cluster.Spec.ClusterNetwork.Services.CIDRBlocks
 	}
+	machineNetwork := getMachineNetworks(acp.Spec.Config.Networking)
+
 	var additionalManifests []hiveext.ManifestsConfigMapReference
 	if len(acp.Spec.Config.ManifestsConfigMapRefs) > 0 {
 		additionalManifests = append(additionalManifests, acp.Spec.Config.ManifestsConfigMapRefs...)
@@ -216,6 +245,18 @@ func (r *ClusterDeploymentReconciler) computeAgentClusterInstall(
 		additionalManifests = append(additionalManifests, hiveext.ManifestsConfigMapReference{Name: imageregistry.ImageConfigMapName})
 	}
 
+	networkConfigMap, err := computeNetworkConfigMap(&acp)
+	if err != nil {
+		return nil, err
+	}
+	if networkConfigMap != nil {
+		if err := util.CreateOrUpdate(ctx, r.Client, networkConfigMap); err != nil {
+			log.Error(err, "failed to create network configuration manifest")
+			return nil, err
+		}
+		additionalManifests = append(additionalManifests, hiveext.ManifestsConfigMapReference{Name: networkConfigMap.Name})
+	}
+
 	aci := &hiveext.AgentClusterInstall{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      clusterDeployment.Name,
@@ -225,7 +266,7 @@ func (r *ClusterDeploymentReconciler) computeAgentClusterInstall(
 				clusterDeployment.Labels[clusterv1.ClusterNameLabel],
 			),
 			OwnerReferences: []metav1.OwnerReference{
-				*metav1.NewControllerRef(&acp, controlplanev1alpha2.GroupVersion.WithKind(openshiftAssistedControlPlaneKind)),
+				*metav1.NewControllerRef(&acp, controlplanev1beta1.GroupVersion.WithKind(openshiftAssistedControlPlaneKind)),
 			},
 		},
 		Spec: hiveext.AgentClusterInstallSpec{
@@ -243,11 +284,15 @@ func (r *ClusterDeploymentReconciler) computeAgentClusterInstall(
 			Networking: hiveext.Networking{
 				ClusterNetwork: clusterNetwork,
 				ServiceNetwork: serviceNetwork,
+				MachineNetwork: machineNetwork,
 			},
 			ManifestsConfigMapRefs: additionalManifests,
 		},
 	}
 
+	if acp.Spec.Config.Networking != nil {
+		aci.Spec.Networking.NetworkType = acp.Spec.Config.Networking.NetworkType
+	}
 	if len(acp.Spec.Config.APIVIPs) > 0 && len(acp.Spec.Config.IngressVIPs) > 0 {
 		aci.Spec.APIVIPs = acp.Spec.Config.APIVIPs
 		aci.Spec.IngressVIPs = acp.Spec.Config.IngressVIPs
@@ -286,7 +331,7 @@ type InstallConfigOverride struct {
 // the OpenshiftAssistedControlPlane has additional capabilities set.
 // For MNO (multi-node openshift), this is set to the default list for baremetal platform,
 // but the OpenshiftAssistedControlPlane can specify additional capabilities to be appended to this list.
-func setACICapabilities(oacp *controlplanev1alpha2.OpenshiftAssistedControlPlane, aci *hiveext.AgentClusterInstall) error {
+func setACICapabilities(oacp *controlplanev1beta1.OpenshiftAssistedControlPlane, aci *hiveext.AgentClusterInstall) error {
 	isBaremetalPlatform := aci.Spec.PlatformType == hiveext.BareMetalPlatformType
 	if isCapabilitiesEmpty(oacp.Spec.Config.Capabilities) && !isBaremetalPlatform {
 		return nil
@@ -314,6 +359,12 @@ func setACICapabilities(oacp *controlplanev1alpha2.OpenshiftAssistedControlPlane
 	return nil
 }
 
+// ValidateBaselineCapability returns an error if the given baseline capability set is not supported
+func ValidateBaselineCapability(capability string) error {
+	_, err := getBaselineCapability(capability, false)
+	return err
+}
+
 func getBaselineCapability(capability string, isBaremetalPlatform bool) (string, error) {
 	baselineCapability := capability
 	if baselineCapability == "None" || baselineCapability == "vCurrent" {
@@ -357,6 +408,6 @@ func getAdditionalCapabilities(specifiedAdditionalCapabilities []string, isBarem
 	return additionalCapabilitiesList
 }
 
-func isCapabilitiesEmpty(capabilities controlplanev1alpha2.Capabilities) bool {
-	return equality.Semantic.DeepEqual(capabilities, controlplanev1alpha2.Capabilities{})
+func isCapabilitiesEmpty(capabilities controlplanev1beta1.Capabilities) bool {
+	return equality.Semantic.DeepEqual(capabilities, controlplanev1beta1.Capabilities{})
 }
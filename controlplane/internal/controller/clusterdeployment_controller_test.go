@@ -23,12 +23,13 @@ import (
 
 	"k8s.io/apimachinery/pkg/types"
 
-	"github.com/openshift-assisted/cluster-api-agent/controlplane/api/v1alpha2"
+	controlplanev1beta1 "github.com/openshift-assisted/cluster-api-agent/controlplane/api/v1beta1"
 	"github.com/openshift-assisted/cluster-api-agent/test/utils"
 	hiveext "github.com/openshift/assisted-service/api/hiveextension/v1beta1"
 	"github.com/openshift/assisted-service/models"
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	"k8s.io/client-go/tools/reference"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -56,7 +57,7 @@ var _ = Describe("ClusterDeployment Controller", func() {
 
 	BeforeEach(func() {
 		k8sClient = fakeclient.NewClientBuilder().WithScheme(testScheme).
-			WithStatusSubresource(&hivev1.ClusterDeployment{}, &v1alpha2.OpenshiftAssistedControlPlane{}).
+			WithStatusSubresource(&hivev1.ClusterDeployment{}, &controlplanev1beta1.OpenshiftAssistedControlPlane{}).
 			Build()
 		Expect(k8sClient).NotTo(BeNil())
 		controllerReconciler = &ClusterDeploymentReconciler{
@@ -202,7 +203,7 @@ var _ = Describe("ClusterDeployment Controller", func() {
 		Context("Baremetal workload cluster", func() {
 			var (
 				cd   *hivev1.ClusterDeployment
-				oacp *v1alpha2.OpenshiftAssistedControlPlane
+				oacp *controlplanev1beta1.OpenshiftAssistedControlPlane
 			)
 			BeforeEach(func() {
 				cluster := utils.NewCluster(clusterName, namespace)
@@ -240,7 +241,7 @@ var _ = Describe("ClusterDeployment Controller", func() {
 			})
 			When("additional capabilities are specified", func() {
 				It("ACI should have default baremetal install config override annotation along with the additional capabilities", func() {
-					oacp.Spec.Config.Capabilities = v1alpha2.Capabilities{AdditionalEnabledCapabilities: []string{"NodeTuning"}}
+					oacp.Spec.Config.Capabilities = controlplanev1beta1.Capabilities{AdditionalEnabledCapabilities: []string{"NodeTuning"}}
 					Expect(k8sClient.Update(ctx, oacp)).To(Succeed())
 					_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
 						NamespacedName: client.ObjectKeyFromObject(cd),
@@ -257,7 +258,7 @@ var _ = Describe("ClusterDeployment Controller", func() {
 			})
 			When("additional capabilities are the same as the default baremetal capabilities", func() {
 				It("ACI should have default baremetal install config override annotation with no duplicates", func() {
-					oacp.Spec.Config.Capabilities = v1alpha2.Capabilities{AdditionalEnabledCapabilities: []string{"baremetal"}}
+					oacp.Spec.Config.Capabilities = controlplanev1beta1.Capabilities{AdditionalEnabledCapabilities: []string{"baremetal"}}
 					Expect(k8sClient.Update(ctx, oacp)).To(Succeed())
 
 					_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
@@ -275,7 +276,7 @@ var _ = Describe("ClusterDeployment Controller", func() {
 			})
 			When("additional capabilities include MAPI", func() {
 				It("ACI should have default baremetal install config override annotation without MAPI", func() {
-					oacp.Spec.Config.Capabilities = v1alpha2.Capabilities{AdditionalEnabledCapabilities: []string{"MachineAPI", "NodeTuning"}}
+					oacp.Spec.Config.Capabilities = controlplanev1beta1.Capabilities{AdditionalEnabledCapabilities: []string{"MachineAPI", "NodeTuning"}}
 					Expect(k8sClient.Update(ctx, oacp)).To(Succeed())
 
 					_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
@@ -293,7 +294,7 @@ var _ = Describe("ClusterDeployment Controller", func() {
 			})
 			When("only baseline capability is specified", func() {
 				It("ACI should have default baremetal install config override annotation with specified baseline capability", func() {
-					oacp.Spec.Config.Capabilities = v1alpha2.Capabilities{BaselineCapability: "vCurrent"}
+					oacp.Spec.Config.Capabilities = controlplanev1beta1.Capabilities{BaselineCapability: "vCurrent"}
 					Expect(k8sClient.Update(ctx, oacp)).To(Succeed())
 
 					_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
@@ -313,7 +314,7 @@ var _ = Describe("ClusterDeployment Controller", func() {
 		Context("Non-baremetal workload cluster", func() {
 			var (
 				cd   *hivev1.ClusterDeployment
-				oacp *v1alpha2.OpenshiftAssistedControlPlane
+				oacp *controlplanev1beta1.OpenshiftAssistedControlPlane
 			)
 			BeforeEach(func() {
 				cluster := utils.NewCluster(clusterName, namespace)
@@ -346,7 +347,7 @@ var _ = Describe("ClusterDeployment Controller", func() {
 			})
 			When("additional capabilities are specified", func() {
 				It("ACI should have only the specified capabilities in the install config override annotation and the baseline should be set to the default", func() {
-					oacp.Spec.Config.Capabilities = v1alpha2.Capabilities{AdditionalEnabledCapabilities: []string{"NodeTuning"}}
+					oacp.Spec.Config.Capabilities = controlplanev1beta1.Capabilities{AdditionalEnabledCapabilities: []string{"NodeTuning"}}
 
 					Expect(k8sClient.Update(ctx, oacp)).To(Succeed())
 					_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
@@ -364,7 +365,7 @@ var _ = Describe("ClusterDeployment Controller", func() {
 			})
 			When("additional capabilities include MAPI", func() {
 				It("ACI should include MAPI in its install config override annotation", func() {
-					oacp.Spec.Config.Capabilities = v1alpha2.Capabilities{AdditionalEnabledCapabilities: []string{"MachineAPI", "NodeTuning"}}
+					oacp.Spec.Config.Capabilities = controlplanev1beta1.Capabilities{AdditionalEnabledCapabilities: []string{"MachineAPI", "NodeTuning"}}
 					Expect(k8sClient.Update(ctx, oacp)).To(Succeed())
 
 					_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
@@ -382,7 +383,7 @@ var _ = Describe("ClusterDeployment Controller", func() {
 			})
 			When("only baseline capability is specified", func() {
 				It("ACI should have only have the specified baseline capability", func() {
-					oacp.Spec.Config.Capabilities = v1alpha2.Capabilities{BaselineCapability: "v4.17"}
+					oacp.Spec.Config.Capabilities = controlplanev1beta1.Capabilities{BaselineCapability: "v4.17"}
 					Expect(k8sClient.Update(ctx, oacp)).To(Succeed())
 
 					_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
@@ -400,7 +401,7 @@ var _ = Describe("ClusterDeployment Controller", func() {
 			})
 			When("both baseline capability and additional capabilities are specified", func() {
 				It("ACI should have the install config override annotation with both specified baseline capability and additional capabilities set", func() {
-					oacp.Spec.Config.Capabilities = v1alpha2.Capabilities{BaselineCapability: "v4.8", AdditionalEnabledCapabilities: []string{"NodeTuning"}}
+					oacp.Spec.Config.Capabilities = controlplanev1beta1.Capabilities{BaselineCapability: "v4.8", AdditionalEnabledCapabilities: []string{"NodeTuning"}}
 					Expect(k8sClient.Update(ctx, oacp)).To(Succeed())
 
 					_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
@@ -418,7 +419,7 @@ var _ = Describe("ClusterDeployment Controller", func() {
 			})
 			When("baseline capability is not valid", func() {
 				It("should error out", func() {
-					oacp.Spec.Config.Capabilities = v1alpha2.Capabilities{BaselineCapability: "abcd"}
+					oacp.Spec.Config.Capabilities = controlplanev1beta1.Capabilities{BaselineCapability: "abcd"}
 					Expect(k8sClient.Update(ctx, oacp)).To(Succeed())
 
 					_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
@@ -434,6 +435,118 @@ var _ = Describe("ClusterDeployment Controller", func() {
 		})
 
 	})
+	Context("ACI networking", func() {
+		var (
+			cluster *clusterv1.Cluster
+			cd      *hivev1.ClusterDeployment
+			oacp    *controlplanev1beta1.OpenshiftAssistedControlPlane
+		)
+		BeforeEach(func() {
+			cluster = utils.NewCluster(clusterName, namespace)
+			cluster.Spec.ClusterNetwork = &clusterv1.ClusterNetwork{
+				Pods:     &clusterv1.NetworkRanges{CIDRBlocks: []string{"10.128.0.0/14", "fd01::/48"}},
+				Services: &clusterv1.NetworkRanges{CIDRBlocks: []string{"172.30.0.0/16", "fd02::/112"}},
+			}
+			Expect(k8sClient.Create(ctx, cluster)).To(Succeed())
+
+			cd = utils.NewClusterDeployment(namespace, clusterDeploymentName)
+
+			oacp = utils.NewOpenshiftAssistedControlPlane(namespace, openshiftAssistedControlPlaneName)
+			oacp.Spec.DistributionVersion = openShiftVersion
+			oacp.Spec.Config.APIVIPs = []string{"192.168.111.5", "fd2e:6f44:5dd8::5"}
+			oacp.Spec.Config.IngressVIPs = []string{"192.168.111.4", "fd2e:6f44:5dd8::4"}
+			oacp.Spec.Config.Networking = &controlplanev1beta1.Networking{
+				MachineNetwork: []string{"192.168.111.0/24", "fd2e:6f44:5dd8::/64"},
+				HostPrefixes:   []controlplanev1beta1.HostPrefix{{CIDR: "10.128.0.0/14", HostPrefix: 24}},
+				NetworkType:    "OVNKubernetes",
+			}
+
+			Expect(controllerutil.SetOwnerReference(cluster, oacp, testScheme)).To(Succeed())
+			Expect(controllerutil.SetOwnerReference(oacp, cd, testScheme)).To(Succeed())
+		})
+		JustBeforeEach(func() {
+			Expect(k8sClient.Create(ctx, oacp)).To(Succeed())
+			Expect(k8sClient.Create(ctx, cd)).To(Succeed())
+		})
+
+		It("should set the networks, host prefixes and network type of the ACI", func() {
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: client.ObjectKeyFromObject(cd),
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			aci := &hiveext.AgentClusterInstall{}
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cd), aci)).To(Succeed())
+			Expect(aci.Spec.Networking.ClusterNetwork).To(Equal([]hiveext.ClusterNetworkEntry{
+				{CIDR: "10.128.0.0/14", HostPrefix: 24},
+				{CIDR: "fd01::/48", HostPrefix: 64},
+			}))
+			Expect(aci.Spec.Networking.ServiceNetwork).To(Equal([]string{"172.30.0.0/16", "fd02::/112"}))
+			Expect(aci.Spec.Networking.MachineNetwork).To(Equal([]hiveext.MachineNetworkEntry{
+				{CIDR: "192.168.111.0/24"},
+				{CIDR: "fd2e:6f44:5dd8::/64"},
+			}))
+			Expect(aci.Spec.Networking.NetworkType).To(Equal("OVNKubernetes"))
+			Expect(aci.Spec.ManifestsConfigMapRefs).To(BeEmpty())
+		})
+
+		When("the OVN-Kubernetes internal subnets are configured", func() {
+			BeforeEach(func() {
+				oacp.Spec.Config.Networking.OVNKubernetesConfig = &controlplanev1beta1.OVNKubernetesConfig{
+					IPv4: &controlplanev1beta1.OVNKubernetesInternalSubnets{
+						InternalJoinSubnet:          "100.65.0.0/16",
+						InternalTransitSwitchSubnet: "100.89.0.0/16",
+					},
+					IPv6: &controlplanev1beta1.OVNKubernetesInternalSubnets{InternalJoinSubnet: "fd99::/64"},
+				}
+			})
+
+			It("should add the network operator configuration manifest to the ACI", func() {
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: client.ObjectKeyFromObject(cd),
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				aci := &hiveext.AgentClusterInstall{}
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cd), aci)).To(Succeed())
+				Expect(aci.Spec.ManifestsConfigMapRefs).To(ContainElement(
+					hiveext.ManifestsConfigMapReference{Name: openshiftAssistedControlPlaneName + "-network-config"}))
+
+				configMap := &corev1.ConfigMap{}
+				Expect(k8sClient.Get(ctx, types.NamespacedName{
+					Name:      openshiftAssistedControlPlaneName + "-network-config",
+					Namespace: namespace,
+				}, configMap)).To(Succeed())
+				Expect(configMap.Data).To(HaveKeyWithValue("cluster-network-03-config.json",
+					`{"apiVersion":"operator.openshift.io/v1","kind":"Network","metadata":{"name":"cluster"},`+
+						`"spec":{"defaultNetwork":{"ovnKubernetesConfig":{"ipv4":{"internalJoinSubnet":"100.65.0.0/16",`+
+						`"internalTransitSwitchSubnet":"100.89.0.0/16"},"ipv6":{"internalJoinSubnet":"fd99::/64"}},"type":"OVNKubernetes"}}}`))
+			})
+		})
+
+		When("the IP stacks are not in the same order", func() {
+			BeforeEach(func() {
+				oacp.Spec.Config.Networking.MachineNetwork = []string{"fd2e:6f44:5dd8::/64", "192.168.111.0/24"}
+			})
+
+			It("should report the invalid networking on the control plane without creating the ACI", func() {
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: client.ObjectKeyFromObject(cd),
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				aci := &hiveext.AgentClusterInstall{}
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cd), aci)).NotTo(Succeed())
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(oacp), oacp)).To(Succeed())
+				condition := conditions.Get(oacp, controlplanev1beta1.ControlPlaneReadyCondition)
+				Expect(condition).NotTo(BeNil())
+				Expect(condition.Status).To(Equal(corev1.ConditionFalse))
+				Expect(condition.Reason).To(Equal(controlplanev1beta1.ControlPlaneNetworkingInvalidReason))
+				Expect(condition.Message).To(Equal("invalid networking configuration: machine network lists IPv6 first, " +
+					"but cluster network lists IPv4 first: the IP stacks must be in the same order"))
+			})
+		})
+	})
 	AfterEach(func() {
 		k8sClient = nil
 		controllerReconciler = nil
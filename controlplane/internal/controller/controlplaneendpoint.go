@@ -0,0 +1,44 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+
+	controlplanev1beta1 "github.com/openshift-assisted/cluster-api-agent/controlplane/api/v1beta1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+const apiServerPort = 6443
+
+// getControlPlaneEndpoint returns the endpoint of the API of the workload cluster: the first API VIP or, with user
+// managed networking, the api.<clusterName>.<baseDomain> record the user is expected to provide
+func getControlPlaneEndpoint(oacp *controlplanev1beta1.OpenshiftAssistedControlPlane, clusterName string) clusterv1.APIEndpoint {
+	if len(oacp.Spec.Config.APIVIPs) > 0 {
+		return clusterv1.APIEndpoint{Host: oacp.Spec.Config.APIVIPs[0], Port: apiServerPort}
+	}
+	if oacp.Spec.Config.ClusterName != "" {
+		clusterName = oacp.Spec.Config.ClusterName
+	}
+	if oacp.Spec.Config.BaseDomain == "" {
+		return clusterv1.APIEndpoint{}
+	}
+	return clusterv1.APIEndpoint{
+		Host: fmt.Sprintf("api.%s.%s", clusterName, oacp.Spec.Config.BaseDomain),
+		Port: apiServerPort,
+	}
+}
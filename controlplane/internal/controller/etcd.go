@@ -0,0 +1,119 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	controlplanev1beta1 "github.com/openshift-assisted/cluster-api-agent/controlplane/api/v1beta1"
+	"github.com/openshift-assisted/cluster-api-agent/controlplane/internal/etcd"
+	"github.com/openshift-assisted/cluster-api-agent/pkg/workloadclient"
+	"github.com/openshift-assisted/cluster-api-agent/util"
+	logutil "github.com/openshift-assisted/cluster-api-agent/util/log"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// etcdRequeueAfter is how long to wait before checking etcd again when the removal of a machine is blocked
+const etcdRequeueAfter = 30 * time.Second
+
+// preflightChecksForScaleDown ensures the machine can be removed from the workload cluster, and removes its etcd member.
+// Returns false when the scale down is blocked, the reason is reported by the ScaleDownAllowed condition.
+// Machines without a node never joined the cluster, hence they do not run an etcd member.
+func (r *OpenshiftAssistedControlPlaneReconciler) preflightChecksForScaleDown(
+	ctx context.Context,
+	oacp *controlplanev1beta1.OpenshiftAssistedControlPlane,
+	cluster *clusterv1.Cluster,
+	machine *clusterv1.Machine,
+) (bool, error) {
+	log := ctrl.LoggerFrom(ctx).WithValues("machine", machine.Name)
+	if machine.Status.NodeRef == nil {
+		conditions.MarkTrue(oacp, controlplanev1beta1.ScaleDownAllowedCondition)
+		return true, nil
+	}
+
+	workloadClient, err := workloadclient.GetWorkloadClientFromClusterName(ctx, r.Client, r.WorkloadClientGenerator, cluster.Name, cluster.Namespace)
+	if err != nil {
+		return false, err
+	}
+	healthy, err := etcd.IsHealthy(ctx, workloadClient)
+	if err != nil {
+		return false, err
+	}
+	if !healthy {
+		log.V(logutil.InfoLevel).Info("scale down blocked, etcd cluster is not healthy")
+		conditions.MarkFalse(oacp, controlplanev1beta1.ScaleDownAllowedCondition, controlplanev1beta1.EtcdClusterUnhealthyReason,
+			clusterv1.ConditionSeverityWarning, "cannot remove machine %s, waiting for all etcd members to be healthy", machine.Name)
+		return false, nil
+	}
+	canSafelyRemove, err := etcd.CanSafelyRemoveMember(ctx, workloadClient, machine.Status.NodeRef.Name)
+	if err != nil {
+		return false, err
+	}
+	if !canSafelyRemove {
+		log.V(logutil.InfoLevel).Info("scale down blocked, removing the machine would cause etcd to lose quorum")
+		conditions.MarkFalse(oacp, controlplanev1beta1.ScaleDownAllowedCondition, controlplanev1beta1.EtcdQuorumAtRiskReason,
+			clusterv1.ConditionSeverityWarning, "cannot remove machine %s, this could result in etcd losing quorum", machine.Name)
+		return false, nil
+	}
+
+	if err := r.removeEtcdMember(ctx, cluster, machine); err != nil {
+		conditions.MarkFalse(oacp, controlplanev1beta1.ScaleDownAllowedCondition, controlplanev1beta1.EtcdMemberRemovalFailedReason,
+			clusterv1.ConditionSeverityWarning, "cannot remove machine %s: %v", machine.Name, err)
+		return false, err
+	}
+	conditions.MarkTrue(oacp, controlplanev1beta1.ScaleDownAllowedCondition)
+	return true, nil
+}
+
+// canSafelyRemoveEtcdMember checks on the workload cluster that the etcd member of the machine can be removed
+// without losing quorum.
+func (r *OpenshiftAssistedControlPlaneReconciler) canSafelyRemoveEtcdMember(ctx context.Context, cluster *clusterv1.Cluster, machine *clusterv1.Machine) (bool, error) {
+	if machine.Status.NodeRef == nil {
+		return true, nil
+	}
+	workloadClient, err := workloadclient.GetWorkloadClientFromClusterName(ctx, r.Client, r.WorkloadClientGenerator, cluster.Name, cluster.Namespace)
+	if err != nil {
+		return false, err
+	}
+	return etcd.CanSafelyRemoveMember(ctx, workloadClient, machine.Status.NodeRef.Name)
+}
+
+// removeEtcdMember removes the etcd member of the machine node from the workload cluster, so that the etcd
+// cluster does not keep waiting for a member that is gone once the machine is deleted.
+func (r *OpenshiftAssistedControlPlaneReconciler) removeEtcdMember(ctx context.Context, cluster *clusterv1.Cluster, machine *clusterv1.Machine) error {
+	if machine.Status.NodeRef == nil {
+		return nil
+	}
+	kubeconfig, err := util.GetWorkloadKubeconfig(ctx, r.Client, cluster.Name, cluster.Namespace)
+	if err != nil {
+		return err
+	}
+	memberClient, err := r.EtcdMemberClientGenerator.GetMemberClient(kubeconfig)
+	if err != nil {
+		return fmt.Errorf("failed to get etcd member client: %w", err)
+	}
+	if err := etcd.RemoveNodeMember(ctx, memberClient, machine.Status.NodeRef.Name); err != nil {
+		return err
+	}
+	ctrl.LoggerFrom(ctx).V(logutil.InfoLevel).Info("removed etcd member", "machine", machine.Name, "node", machine.Status.NodeRef.Name)
+	return nil
+}
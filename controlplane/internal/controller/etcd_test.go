@@ -0,0 +1,245 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	controlplanev1beta1 "github.com/openshift-assisted/cluster-api-agent/controlplane/api/v1beta1"
+	"github.com/openshift-assisted/cluster-api-agent/controlplane/internal/etcd"
+	"github.com/openshift-assisted/cluster-api-agent/controlplane/internal/version"
+	"github.com/openshift-assisted/cluster-api-agent/pkg/workloadclient"
+	testutils "github.com/openshift-assisted/cluster-api-agent/test/utils"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+var _ = Describe("Scale down etcd preflight checks", func() {
+	const (
+		openshiftAssistedControlPlaneName = "test-resource"
+		clusterName                       = "test-cluster"
+		namespace                         = "test"
+	)
+
+	var (
+		ctx                  context.Context
+		typeNamespacedName   types.NamespacedName
+		controllerReconciler *OpenshiftAssistedControlPlaneReconciler
+		k8sClient            client.Client
+		workloadClient       client.Client
+		mockMemberClient     *etcd.MockMemberClient
+		ctrl                 *gomock.Controller
+		oacp                 *controlplanev1beta1.OpenshiftAssistedControlPlane
+		members              []etcd.Member
+	)
+
+	listMachines := func() []clusterv1.Machine {
+		machineList := &clusterv1.MachineList{}
+		Expect(k8sClient.List(ctx, machineList, client.InNamespace(namespace))).To(Succeed())
+		return machineList.Items
+	}
+	setEtcdMember := func(nodeName string, ready bool) {
+		status := corev1.ConditionFalse
+		if ready {
+			status = corev1.ConditionTrue
+		}
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "etcd-" + nodeName,
+				Namespace: etcd.Namespace,
+				Labels:    map[string]string{"app": "etcd"},
+			},
+			Spec: corev1.PodSpec{NodeName: nodeName},
+			Status: corev1.PodStatus{
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: status}},
+			},
+		}
+		_ = workloadClient.Delete(ctx, pod)
+		Expect(workloadClient.Create(ctx, pod)).To(Succeed())
+	}
+	scaleDown := func() {
+		Expect(k8sClient.Get(ctx, typeNamespacedName, oacp)).To(Succeed())
+		oacp.Spec.Replicas = 2
+		Expect(k8sClient.Update(ctx, oacp)).To(Succeed())
+	}
+	expectScaleDownBlocked := func(reason string) {
+		Expect(listMachines()).To(HaveLen(3))
+		Expect(k8sClient.Get(ctx, typeNamespacedName, oacp)).To(Succeed())
+		condition := conditions.Get(oacp, controlplanev1beta1.ScaleDownAllowedCondition)
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Status).To(Equal(corev1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(reason))
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		ctrl = gomock.NewController(GinkgoT())
+		k8sClient = fakeclient.NewClientBuilder().
+			WithScheme(testScheme).
+			WithStatusSubresource(&clusterv1.Cluster{}, &controlplanev1beta1.OpenshiftAssistedControlPlane{}, &clusterv1.Machine{}).
+			Build()
+		workloadClient = fakeclient.NewClientBuilder().WithScheme(testScheme).Build()
+
+		mockKubernetesVersionDetector := version.NewMockKubernetesVersionDetector(ctrl)
+		k8sVersion := "1.30.0"
+		mockKubernetesVersionDetector.EXPECT().GetKubernetesVersion(gomock.Any(), gomock.Any()).Return(&k8sVersion, nil).AnyTimes()
+		mockClientGenerator := workloadclient.NewMockClientGenerator(ctrl)
+		mockClientGenerator.EXPECT().GetWorkloadClusterClient(gomock.Any()).Return(workloadClient, nil).AnyTimes()
+		mockMemberClient = etcd.NewMockMemberClient(ctrl)
+		mockMemberClientGenerator := etcd.NewMockMemberClientGenerator(ctrl)
+		mockMemberClientGenerator.EXPECT().GetMemberClient(gomock.Any()).Return(mockMemberClient, nil).AnyTimes()
+
+		controllerReconciler = &OpenshiftAssistedControlPlaneReconciler{
+			Client:                    k8sClient,
+			Scheme:                    k8sClient.Scheme(),
+			K8sVersionDetector:        mockKubernetesVersionDetector,
+			WorkloadClientGenerator:   mockClientGenerator,
+			EtcdMemberClientGenerator: mockMemberClientGenerator,
+		}
+		typeNamespacedName = types.NamespacedName{
+			Name:      openshiftAssistedControlPlaneName,
+			Namespace: namespace,
+		}
+
+		machineTemplate := getMachineTemplate("infratemplate", namespace)
+		Expect(k8sClient.Create(ctx, &machineTemplate)).To(Succeed())
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}}
+		Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+		kubeconfigSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      clusterName + "-kubeconfig",
+				Namespace: namespace,
+			},
+			Data: map[string][]byte{"value": []byte("kubeconfig")},
+		}
+		Expect(k8sClient.Create(ctx, kubeconfigSecret)).To(Succeed())
+
+		cluster := testutils.NewCluster(clusterName, namespace)
+		Expect(k8sClient.Create(ctx, cluster)).To(Succeed())
+
+		oacp = testutils.NewOpenshiftAssistedControlPlane(namespace, openshiftAssistedControlPlaneName)
+		oacp.SetOwnerReferences([]metav1.OwnerReference{
+			*metav1.NewControllerRef(cluster, clusterv1.GroupVersion.WithKind(clusterv1.ClusterKind)),
+		})
+		oacp.Spec.MachineTemplate.InfrastructureRef = corev1.ObjectReference{
+			Kind:       "Metal3MachineTemplate",
+			Namespace:  namespace,
+			Name:       "infratemplate",
+			APIVersion: "infrastructure.cluster.x-k8s.io/v1beta1",
+		}
+		oacp.Spec.Replicas = 3
+		Expect(k8sClient.Create(ctx, oacp)).To(Succeed())
+		for i := 0; i < 3; i++ {
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		By("joining the machines to the cluster")
+		members = nil
+		machines := listMachines()
+		for i := range machines {
+			machine := &machines[i]
+			nodeName := fmt.Sprintf("master-%d", i)
+			machine.Status.NodeRef = &corev1.ObjectReference{Kind: "Node", Name: nodeName}
+			conditions.MarkTrue(machine, clusterv1.ReadyCondition)
+			Expect(k8sClient.Status().Update(ctx, machine)).To(Succeed())
+			setEtcdMember(nodeName, true)
+			members = append(members, etcd.Member{ID: uint64(i + 1), Name: nodeName})
+		}
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	It("should remove the etcd member before deleting the machine", func() {
+		scaleDown()
+		mockMemberClient.EXPECT().ListMembers(gomock.Any()).Return(members, nil)
+		var removed etcd.Member
+		mockMemberClient.EXPECT().RemoveMember(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, member etcd.Member) error {
+			removed = member
+			// the machine still exists when its member is removed
+			Expect(listMachines()).To(HaveLen(3))
+			return nil
+		})
+
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+
+		machines := listMachines()
+		Expect(machines).To(HaveLen(2))
+		for _, machine := range machines {
+			Expect(machine.Status.NodeRef.Name).NotTo(Equal(removed.Name))
+		}
+		Expect(k8sClient.Get(ctx, typeNamespacedName, oacp)).To(Succeed())
+		Expect(conditions.IsTrue(oacp, controlplanev1beta1.ScaleDownAllowedCondition)).To(BeTrue())
+	})
+
+	It("should block the scale down when an etcd member is not healthy", func() {
+		setEtcdMember("master-1", false)
+		scaleDown()
+
+		result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(etcdRequeueAfter))
+		expectScaleDownBlocked(controlplanev1beta1.EtcdClusterUnhealthyReason)
+
+		By("resuming the scale down once etcd is healthy")
+		setEtcdMember("master-1", true)
+		mockMemberClient.EXPECT().ListMembers(gomock.Any()).Return(members, nil)
+		mockMemberClient.EXPECT().RemoveMember(gomock.Any(), gomock.Any()).Return(nil)
+		_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(listMachines()).To(HaveLen(2))
+	})
+
+	It("should block the scale down when the etcd member is the last one", func() {
+		// a single member runs on the node shared by all the machines
+		Expect(workloadClient.DeleteAllOf(ctx, &corev1.Pod{}, client.InNamespace(etcd.Namespace))).To(Succeed())
+		setEtcdMember("master-0", true)
+		for _, machine := range listMachines() {
+			machine.Status.NodeRef.Name = "master-0"
+			Expect(k8sClient.Status().Update(ctx, &machine)).To(Succeed())
+		}
+		scaleDown()
+
+		result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(etcdRequeueAfter))
+		expectScaleDownBlocked(controlplanev1beta1.EtcdQuorumAtRiskReason)
+	})
+
+	It("should block the scale down when the etcd member cannot be removed", func() {
+		scaleDown()
+		mockMemberClient.EXPECT().ListMembers(gomock.Any()).Return(nil, fmt.Errorf("etcdctl failed"))
+
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).To(HaveOccurred())
+		expectScaleDownBlocked(controlplanev1beta1.EtcdMemberRemovalFailedReason)
+	})
+})
@@ -0,0 +1,87 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+
+	controlplanev1beta1 "github.com/openshift-assisted/cluster-api-agent/controlplane/api/v1beta1"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/utils/ptr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/collections"
+	"sigs.k8s.io/cluster-api/util/patch"
+)
+
+// getMachineVersions returns the Kubernetes and OpenShift versions run by the control plane machines. The versions
+// are only known when the machines run the desired release: before the cluster is installed, as the installation
+// uses the desired release, or once the workload cluster completed its upgrade to the desired release.
+func getMachineVersions(oacp *controlplanev1beta1.OpenshiftAssistedControlPlane) (*string, string, bool) {
+	if oacp.Status.Version == nil || *oacp.Status.Version == "" {
+		return nil, "", false
+	}
+	if oacp.Status.DistributionVersion != "" && !isWorkloadClusterRunningDesiredVersion(oacp) {
+		return nil, "", false
+	}
+	// Machine versions are semantic versions prefixed with v
+	k8sVersion := "v" + strings.TrimPrefix(*oacp.Status.Version, "v")
+	return ptr.To(k8sVersion), oacp.Spec.DistributionVersion, true
+}
+
+// setMachineVersions sets the Kubernetes version and the OpenShift version annotation of a machine, returning true
+// when they changed
+func setMachineVersions(machine *clusterv1.Machine, k8sVersion *string, distributionVersion string) bool {
+	if isEqualPtr(k8sVersion, machine.Spec.Version) &&
+		machine.Annotations[controlplanev1beta1.DistributionVersionAnnotation] == distributionVersion {
+		return false
+	}
+	machine.Spec.Version = k8sVersion
+	if machine.Annotations == nil {
+		machine.Annotations = map[string]string{}
+	}
+	machine.Annotations[controlplanev1beta1.DistributionVersionAnnotation] = distributionVersion
+	return true
+}
+
+// syncMachinesVersions refreshes the versions of the control plane machines once they run the desired release.
+// OpenShift upgrades the machines in place, so their versions are updated without replacing them.
+func (r *OpenshiftAssistedControlPlaneReconciler) syncMachinesVersions(
+	ctx context.Context,
+	oacp *controlplanev1beta1.OpenshiftAssistedControlPlane,
+	machines collections.Machines,
+) error {
+	k8sVersion, distributionVersion, ok := getMachineVersions(oacp)
+	if !ok {
+		return nil
+	}
+	var errs []error
+	for _, machine := range machines {
+		patchHelper, err := patch.NewHelper(machine, r.Client)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if !setMachineVersions(machine, k8sVersion, distributionVersion) {
+			continue
+		}
+		if err := patchHelper.Patch(ctx, machine); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return kerrors.NewAggregate(errs)
+}
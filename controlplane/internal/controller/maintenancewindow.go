@@ -0,0 +1,56 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"time"
+
+	controlplanev1beta1 "github.com/openshift-assisted/cluster-api-agent/controlplane/api/v1beta1"
+	"github.com/openshift-assisted/cluster-api-agent/util/cron"
+)
+
+// getUpgradeHold returns why an upgrade of the workload cluster cannot start at the given time, if any, and when
+// it can start. The requeue duration is 0 when the upgrade is only released by a change of the OpenshiftAssistedControlPlane.
+func getUpgradeHold(oacp *controlplanev1beta1.OpenshiftAssistedControlPlane, now time.Time) (string, time.Duration, bool) {
+	if _, ok := oacp.Annotations[controlplanev1beta1.HoldUpgradeAnnotation]; ok {
+		return fmt.Sprintf("upgrade to version %s held by the %s annotation",
+			oacp.Spec.DistributionVersion, controlplanev1beta1.HoldUpgradeAnnotation), 0, true
+	}
+	if oacp.Spec.UpgradeStrategy == nil || oacp.Spec.UpgradeStrategy.MaintenanceWindow == nil {
+		return "", 0, false
+	}
+
+	window := oacp.Spec.UpgradeStrategy.MaintenanceWindow
+	schedule, err := cron.Parse(window.Schedule)
+	if err != nil {
+		return fmt.Sprintf("upgrade to version %s held by an invalid maintenance window: %s",
+			oacp.Spec.DistributionVersion, err.Error()), 0, true
+	}
+	now = now.UTC()
+	// a window started at most one duration ago is still open
+	if lastStart := schedule.Next(now.Add(-window.Duration.Duration)); !lastStart.IsZero() && !lastStart.After(now) {
+		return "", 0, false
+	}
+	nextStart := schedule.Next(now)
+	if nextStart.IsZero() {
+		return fmt.Sprintf("upgrade to version %s held by a maintenance window that never starts",
+			oacp.Spec.DistributionVersion), 0, true
+	}
+	return fmt.Sprintf("upgrade to version %s pending, next maintenance window starts at %s",
+		oacp.Spec.DistributionVersion, nextStart.Format(time.RFC3339)), nextStart.Sub(now), true
+}
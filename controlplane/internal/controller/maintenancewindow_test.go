@@ -0,0 +1,87 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	controlplanev1beta1 "github.com/openshift-assisted/cluster-api-agent/controlplane/api/v1beta1"
+	testutils "github.com/openshift-assisted/cluster-api-agent/test/utils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Upgrade hold", func() {
+	// Saturday
+	now := time.Date(2024, time.March, 9, 23, 0, 0, 0, time.UTC)
+
+	var oacp *controlplanev1beta1.OpenshiftAssistedControlPlane
+
+	BeforeEach(func() {
+		oacp = testutils.NewOpenshiftAssistedControlPlane("test", "test-resource")
+		oacp.Spec.DistributionVersion = "4.16.2"
+	})
+
+	withMaintenanceWindow := func(schedule string, duration time.Duration) {
+		oacp.Spec.UpgradeStrategy = &controlplanev1beta1.UpgradeStrategy{
+			MaintenanceWindow: &controlplanev1beta1.MaintenanceWindow{
+				Schedule: schedule,
+				Duration: metav1.Duration{Duration: duration},
+			},
+		}
+	}
+
+	It("should not hold upgrades without maintenance window", func() {
+		_, _, isHeld := getUpgradeHold(oacp, now)
+		Expect(isHeld).To(BeFalse())
+	})
+
+	It("should hold upgrades with the hold annotation", func() {
+		oacp.Annotations = map[string]string{controlplanev1beta1.HoldUpgradeAnnotation: ""}
+
+		message, requeueAfter, isHeld := getUpgradeHold(oacp, now)
+		Expect(isHeld).To(BeTrue())
+		Expect(requeueAfter).To(BeZero())
+		Expect(message).To(ContainSubstring(controlplanev1beta1.HoldUpgradeAnnotation))
+	})
+
+	It("should not hold upgrades within the maintenance window", func() {
+		withMaintenanceWindow("0 22 * * sat", 4*time.Hour)
+
+		_, _, isHeld := getUpgradeHold(oacp, now)
+		Expect(isHeld).To(BeFalse())
+	})
+
+	It("should hold upgrades until the next maintenance window", func() {
+		withMaintenanceWindow("0 22 * * sat", 30*time.Minute)
+
+		message, requeueAfter, isHeld := getUpgradeHold(oacp, now)
+		Expect(isHeld).To(BeTrue())
+		Expect(requeueAfter).To(Equal(7*24*time.Hour - time.Hour))
+		Expect(message).To(Equal("upgrade to version 4.16.2 pending, next maintenance window starts at 2024-03-16T22:00:00Z"))
+	})
+
+	It("should hold upgrades with an invalid maintenance window", func() {
+		withMaintenanceWindow("invalid", time.Hour)
+
+		message, requeueAfter, isHeld := getUpgradeHold(oacp, now)
+		Expect(isHeld).To(BeTrue())
+		Expect(requeueAfter).To(BeZero())
+		Expect(message).To(ContainSubstring("invalid maintenance window"))
+	})
+})
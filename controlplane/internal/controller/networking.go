@@ -0,0 +1,228 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+
+	controlplanev1beta1 "github.com/openshift-assisted/cluster-api-agent/controlplane/api/v1beta1"
+	hiveext "github.com/openshift/assisted-service/api/hiveextension/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+const (
+	defaultIPv4HostPrefix = 23
+	defaultIPv6HostPrefix = 64
+
+	ipv4Stack = "IPv4"
+	ipv6Stack = "IPv6"
+
+	networkConfigMapSuffix   = "-network-config"
+	networkOperatorConfigKey = "cluster-network-03-config.json"
+)
+
+// IPAddressPool is a named list of IP addresses or CIDRs of the workload cluster, e.g. its service networks or API VIPs
+type IPAddressPool struct {
+	Name      string
+	Addresses []string
+}
+
+// ValidateIPStackOrder returns an error if the IP address pools do not list the IP stacks in the same order: the
+// addresses at the same position of each pool must be of the same stack, the first ones defining the primary IP stack
+// of a dual-stack cluster
+func ValidateIPStackOrder(pools ...IPAddressPool) error {
+	var referenceStacks, referencePools []string
+	for _, pool := range pools {
+		for i, address := range pool.Addresses {
+			stack, err := getIPStack(address)
+			if err != nil {
+				return fmt.Errorf("invalid %s: %w", pool.Name, err)
+			}
+			if i == len(referenceStacks) {
+				referenceStacks = append(referenceStacks, stack)
+				referencePools = append(referencePools, pool.Name)
+				continue
+			}
+			if stack != referenceStacks[i] {
+				return fmt.Errorf("%s lists %s %s, but %s lists %s %s: the IP stacks must be in the same order",
+					pool.Name, stack, getOrdinal(i), referencePools[i], referenceStacks[i], getOrdinal(i))
+			}
+		}
+	}
+	return nil
+}
+
+func getOrdinal(i int) string {
+	switch i {
+	case 0:
+		return "first"
+	case 1:
+		return "second"
+	default:
+		return fmt.Sprintf("at position %d", i+1)
+	}
+}
+
+// validateIPStackOrder validates the IP stack order of all the networks and VIPs of the workload cluster
+func validateIPStackOrder(cluster *clusterv1.Cluster, oacp *controlplanev1beta1.OpenshiftAssistedControlPlane) error {
+	var clusterNetwork, serviceNetwork, machineNetwork []string
+	if cluster.Spec.ClusterNetwork != nil && cluster.Spec.ClusterNetwork.Pods != nil {
+		clusterNetwork = cluster.Spec.ClusterNetwork.Pods.CIDRBlocks
+	}
+	if cluster.Spec.ClusterNetwork != nil && cluster.Spec.ClusterNetwork.Services != nil {
+		serviceNetwork = cluster.Spec.ClusterNetwork.Services.CIDRBlocks
+	}
+	if oacp.Spec.Config.Networking != nil {
+		machineNetwork = oacp.Spec.Config.Networking.MachineNetwork
+	}
+	return ValidateIPStackOrder(
+		IPAddressPool{Name: "cluster network", Addresses: clusterNetwork},
+		IPAddressPool{Name: "service network", Addresses: serviceNetwork},
+		IPAddressPool{Name: "machine network", Addresses: machineNetwork},
+		IPAddressPool{Name: "API VIPs", Addresses: oacp.Spec.Config.APIVIPs},
+		IPAddressPool{Name: "ingress VIPs", Addresses: oacp.Spec.Config.IngressVIPs},
+	)
+}
+
+// getIPStack returns the IP stack of an IP address or a CIDR
+func getIPStack(address string) (string, error) {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		var err error
+		if ip, _, err = net.ParseCIDR(address); err != nil {
+			return "", fmt.Errorf("%q is neither an IP address nor a CIDR", address)
+		}
+	}
+	if ip.To4() != nil {
+		return ipv4Stack, nil
+	}
+	return ipv6Stack, nil
+}
+
+// getClusterNetworks returns the cluster networks of the CAPI Cluster, with their configured host prefix or the
+// default host prefix of their IP stack
+func getClusterNetworks(cluster *clusterv1.Cluster, networking *controlplanev1beta1.Networking) []hiveext.ClusterNetworkEntry {
+	var clusterNetwork []hiveext.ClusterNetworkEntry
+	if cluster.Spec.ClusterNetwork == nil || cluster.Spec.ClusterNetwork.Pods == nil {
+		return clusterNetwork
+	}
+	for _, cidrBlock := range cluster.Spec.ClusterNetwork.Pods.CIDRBlocks {
+		clusterNetwork = append(clusterNetwork, hiveext.ClusterNetworkEntry{
+			CIDR:       cidrBlock,
+			HostPrefix: getHostPrefix(cidrBlock, networking),
+		})
+	}
+	return clusterNetwork
+}
+
+func getHostPrefix(cidrBlock string, networking *controlplanev1beta1.Networking) int32 {
+	if networking != nil {
+		for _, hostPrefix := range networking.HostPrefixes {
+			if hostPrefix.CIDR == cidrBlock {
+				return hostPrefix.HostPrefix
+			}
+		}
+	}
+	if stack, err := getIPStack(cidrBlock); err == nil && stack == ipv6Stack {
+		return defaultIPv6HostPrefix
+	}
+	return defaultIPv4HostPrefix
+}
+
+func getMachineNetworks(networking *controlplanev1beta1.Networking) []hiveext.MachineNetworkEntry {
+	var machineNetwork []hiveext.MachineNetworkEntry
+	if networking == nil {
+		return machineNetwork
+	}
+	for _, cidr := range networking.MachineNetwork {
+		machineNetwork = append(machineNetwork, hiveext.MachineNetworkEntry{CIDR: cidr})
+	}
+	return machineNetwork
+}
+
+// getNetworkConfigMapName returns the name of the ConfigMap holding the network operator configuration manifest of
+// the workload cluster
+func getNetworkConfigMapName(oacp *controlplanev1beta1.OpenshiftAssistedControlPlane) string {
+	return oacp.Name + networkConfigMapSuffix
+}
+
+// computeNetworkConfigMap returns the ConfigMap with the network operator configuration manifest setting the internal
+// subnets of OVN-Kubernetes, which cannot be set through the install config. It returns nil when the internal subnets
+// are not configured.
+func computeNetworkConfigMap(oacp *controlplanev1beta1.OpenshiftAssistedControlPlane) (*corev1.ConfigMap, error) {
+	networking := oacp.Spec.Config.Networking
+	if networking == nil || networking.OVNKubernetesConfig == nil {
+		return nil, nil
+	}
+
+	ovnKubernetesConfig := map[string]interface{}{}
+	if subnets := getOVNKubernetesInternalSubnets(networking.OVNKubernetesConfig.IPv4); len(subnets) > 0 {
+		ovnKubernetesConfig["ipv4"] = subnets
+	}
+	if subnets := getOVNKubernetesInternalSubnets(networking.OVNKubernetesConfig.IPv6); len(subnets) > 0 {
+		ovnKubernetesConfig["ipv6"] = subnets
+	}
+	if len(ovnKubernetesConfig) == 0 {
+		return nil, nil
+	}
+
+	manifest, err := json.Marshal(map[string]interface{}{
+		"apiVersion": "operator.openshift.io/v1",
+		"kind":       "Network",
+		"metadata":   map[string]interface{}{"name": "cluster"},
+		"spec": map[string]interface{}{
+			"defaultNetwork": map[string]interface{}{
+				"type":                "OVNKubernetes",
+				"ovnKubernetesConfig": ovnKubernetesConfig,
+			},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal the network operator configuration: %w", err)
+	}
+
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getNetworkConfigMapName(oacp),
+			Namespace: oacp.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(oacp, controlplanev1beta1.GroupVersion.WithKind(openshiftAssistedControlPlaneKind)),
+			},
+		},
+		Data: map[string]string{
+			networkOperatorConfigKey: string(manifest),
+		},
+	}, nil
+}
+
+func getOVNKubernetesInternalSubnets(subnets *controlplanev1beta1.OVNKubernetesInternalSubnets) map[string]interface{} {
+	config := map[string]interface{}{}
+	if subnets == nil {
+		return config
+	}
+	if subnets.InternalJoinSubnet != "" {
+		config["internalJoinSubnet"] = subnets.InternalJoinSubnet
+	}
+	if subnets.InternalTransitSwitchSubnet != "" {
+		config["internalTransitSwitchSubnet"] = subnets.InternalTransitSwitchSubnet
+	}
+	return config
+}
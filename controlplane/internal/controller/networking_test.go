@@ -0,0 +1,71 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("IP stack order", func() {
+	DescribeTable("ValidateIPStackOrder",
+		func(serviceNetwork, machineNetwork, apiVIPs []string, expectedErr string) {
+			err := ValidateIPStackOrder(
+				IPAddressPool{Name: "service network", Addresses: serviceNetwork},
+				IPAddressPool{Name: "machine network", Addresses: machineNetwork},
+				IPAddressPool{Name: "API VIPs", Addresses: apiVIPs},
+			)
+			if expectedErr == "" {
+				Expect(err).NotTo(HaveOccurred())
+				return
+			}
+			Expect(err).To(MatchError(expectedErr))
+		},
+		Entry("single-stack IPv4", []string{"172.30.0.0/16"}, []string{"192.168.111.0/24"}, []string{"192.168.111.5"}, ""),
+		Entry("single-stack IPv6", []string{"fd02::/112"}, []string{"fd2e:6f44:5dd8::/64"}, []string{"fd2e:6f44:5dd8::5"}, ""),
+		Entry("dual-stack with IPv4 first",
+			[]string{"172.30.0.0/16", "fd02::/112"},
+			[]string{"192.168.111.0/24", "fd2e:6f44:5dd8::/64"},
+			[]string{"192.168.111.5", "fd2e:6f44:5dd8::5"}, ""),
+		Entry("dual-stack with IPv6 first",
+			[]string{"fd02::/112", "172.30.0.0/16"},
+			[]string{"fd2e:6f44:5dd8::/64", "192.168.111.0/24"},
+			[]string{"fd2e:6f44:5dd8::5", "192.168.111.5"}, ""),
+		Entry("unset pools are ignored", nil, []string{"fd2e:6f44:5dd8::/64", "192.168.111.0/24"}, nil, ""),
+		Entry("VIPs in a different order",
+			[]string{"172.30.0.0/16", "fd02::/112"},
+			[]string{"192.168.111.0/24", "fd2e:6f44:5dd8::/64"},
+			[]string{"fd2e:6f44:5dd8::5", "192.168.111.5"},
+			"API VIPs lists IPv6 first, but service network lists IPv4 first: the IP stacks must be in the same order"),
+		Entry("single-stack pools with several entries",
+			[]string{"172.30.0.0/16"},
+			[]string{"192.168.111.0/24", "192.168.112.0/24"},
+			[]string{"192.168.111.5"}, ""),
+		Entry("second addresses in a different order",
+			[]string{"172.30.0.0/16", "fd02::/112"},
+			[]string{"192.168.111.0/24", "192.168.112.0/24"},
+			nil,
+			"machine network lists IPv4 second, but service network lists IPv6 second: the IP stacks must be in the same order"),
+		Entry("IPv6 pool listing IPv6 twice in a dual-stack cluster",
+			[]string{"fd02::/112", "172.30.0.0/16"},
+			[]string{"fd01::/48", "fd03::/48"},
+			nil,
+			"machine network lists IPv6 second, but service network lists IPv4 second: the IP stacks must be in the same order"),
+		Entry("invalid address", []string{"172.30.0.0/16"}, []string{"192.168.111.0/33"}, nil,
+			`invalid machine network: "192.168.111.0/33" is neither an IP address nor a CIDR`),
+	)
+})
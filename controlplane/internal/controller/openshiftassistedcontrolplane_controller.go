@@ -20,18 +20,21 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	semver "github.com/blang/semver/v4"
 
 	"github.com/openshift-assisted/cluster-api-agent/assistedinstaller"
-	bootstrapv1alpha1 "github.com/openshift-assisted/cluster-api-agent/bootstrap/api/v1alpha1"
-	controlplanev1alpha2 "github.com/openshift-assisted/cluster-api-agent/controlplane/api/v1alpha2"
+	bootstrapv1beta1 "github.com/openshift-assisted/cluster-api-agent/bootstrap/api/v1beta1"
+	controlplanev1beta1 "github.com/openshift-assisted/cluster-api-agent/controlplane/api/v1beta1"
 	"github.com/openshift-assisted/cluster-api-agent/controlplane/internal/auth"
+	"github.com/openshift-assisted/cluster-api-agent/controlplane/internal/etcd"
 	"github.com/openshift-assisted/cluster-api-agent/controlplane/internal/release"
 	"github.com/openshift-assisted/cluster-api-agent/controlplane/internal/upgrade"
 	"github.com/openshift-assisted/cluster-api-agent/controlplane/internal/version"
 	"github.com/openshift-assisted/cluster-api-agent/pkg/containers"
+	"github.com/openshift-assisted/cluster-api-agent/pkg/workloadclient"
 	"github.com/openshift-assisted/cluster-api-agent/util"
 	"github.com/openshift-assisted/cluster-api-agent/util/failuredomains"
 	logutil "github.com/openshift-assisted/cluster-api-agent/util/log"
@@ -44,6 +47,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apiserver/pkg/storage/names"
 	"k8s.io/client-go/tools/reference"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -60,21 +64,32 @@ import (
 )
 
 const (
-	minOpenShiftVersion               = "4.14.0"
+	// MinOpenShiftVersion is the minimum OpenShift version that can be installed
+	MinOpenShiftVersion               = "4.14.0"
 	openshiftAssistedControlPlaneKind = "OpenshiftAssistedControlPlane"
-	acpFinalizer                      = "openshiftassistedcontrolplane." + controlplanev1alpha2.Group + "/deprovision"
-	placeholderPullSecretName         = "placeholder-pull-secret"
+	acpFinalizer                      = "openshiftassistedcontrolplane." + controlplanev1beta1.Group + "/deprovision"
+	// PlaceholderPullSecretName is the name of the fake pull secret used when no pull secret is specified
+	PlaceholderPullSecretName = "placeholder-pull-secret"
+	// upgradePathRequeueAfter is the delay before validating again an upgrade path refused by the update graph,
+	// which may recommend it later
+	upgradePathRequeueAfter = 10 * time.Minute
 )
 
 // OpenshiftAssistedControlPlaneReconciler reconciles a OpenshiftAssistedControlPlane object
 type OpenshiftAssistedControlPlaneReconciler struct {
 	client.Client
-	K8sVersionDetector version.KubernetesVersionDetector
-	Scheme             *runtime.Scheme
-	UpgradeFactory     upgrade.ClusterUpgradeFactory
+	K8sVersionDetector        version.KubernetesVersionDetector
+	Scheme                    *runtime.Scheme
+	UpgradeFactory            upgrade.ClusterUpgradeFactory
+	UpgradeGraphProvider      upgrade.UpgradeGraphProvider
+	UpgradeChannel            string
+	WorkloadClientGenerator   workloadclient.ClientGenerator
+	EtcdMemberClientGenerator etcd.MemberClientGenerator
+	UpgradeTimeout            time.Duration
+	UpgradeHealthGates        []upgrade.HealthGate
 }
 
-var minVersion = semver.MustParse(minOpenShiftVersion)
+var minVersion = semver.MustParse(MinOpenShiftVersion)
 
 // +kubebuilder:rbac:groups=bootstrap.cluster.x-k8s.io,resources=openshiftassistedconfigs,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=metal3machines,verbs=get;list;watch;create;update;patch;delete
@@ -101,7 +116,7 @@ var minVersion = semver.MustParse(minOpenShiftVersion)
 func (r *OpenshiftAssistedControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, rerr error) {
 	log := ctrl.LoggerFrom(ctx)
 
-	oacp := &controlplanev1alpha2.OpenshiftAssistedControlPlane{}
+	oacp := &controlplanev1beta1.OpenshiftAssistedControlPlane{}
 	if err := r.Client.Get(ctx, req.NamespacedName, oacp); err != nil {
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
@@ -120,14 +135,16 @@ func (r *OpenshiftAssistedControlPlaneReconciler) Reconcile(ctx context.Context,
 		conditions.SetSummary(oacp,
 			conditions.WithConditions(
 				clusterv1.MachinesReadyCondition,
-				controlplanev1alpha2.KubeconfigAvailableCondition,
-				controlplanev1alpha2.ControlPlaneReadyCondition,
-				controlplanev1alpha2.MachinesCreatedCondition,
+				controlplanev1beta1.KubeconfigAvailableCondition,
+				controlplanev1beta1.ControlPlaneReadyCondition,
+				controlplanev1beta1.MachinesCreatedCondition,
+				controlplanev1beta1.MachinesSpecUpToDateCondition,
 			),
 		)
+		setV1Beta2Conditions(oacp)
 
 		// Patch ObservedGeneration only if the reconciliation completed successfully
-		patchOpts := []patch.Option{}
+		patchOpts := []patch.Option{patch.WithOwnedV1Beta2Conditions{Conditions: v1beta2OwnedConditions}}
 		if rerr == nil {
 			patchOpts = append(patchOpts, patch.WithStatusObservedGeneration{})
 		}
@@ -152,8 +169,8 @@ func (r *OpenshiftAssistedControlPlaneReconciler) Reconcile(ctx context.Context,
 		log.V(logutil.WarningLevel).Info("invalid OpenShift version", "version", oacp.Spec.DistributionVersion)
 	}
 	if err == nil && acpVersion.LT(minVersion) {
-		conditions.MarkFalse(oacp, controlplanev1alpha2.MachinesCreatedCondition, controlplanev1alpha2.MachineGenerationFailedReason,
-			clusterv1.ConditionSeverityError, "version %v is not supported, the minimum supported version is %s", oacp.Spec.DistributionVersion, minOpenShiftVersion)
+		conditions.MarkFalse(oacp, controlplanev1beta1.MachinesCreatedCondition, controlplanev1beta1.MachineGenerationFailedReason,
+			clusterv1.ConditionSeverityError, "version %v is not supported, the minimum supported version is %s", oacp.Spec.DistributionVersion, MinOpenShiftVersion)
 		return ctrl.Result{}, nil
 	}
 
@@ -172,6 +189,11 @@ func (r *OpenshiftAssistedControlPlaneReconciler) Reconcile(ctx context.Context,
 		return ctrl.Result{}, nil
 	}
 
+	// the Cluster controller copies the control plane endpoint to the Cluster, unless the infrastructure provider set it
+	if !oacp.Spec.ControlPlaneEndpoint.IsValid() {
+		oacp.Spec.ControlPlaneEndpoint = getControlPlaneEndpoint(oacp, cluster.Name)
+	}
+
 	if !cluster.Status.InfrastructureReady || !cluster.Spec.ControlPlaneEndpoint.IsValid() {
 		return ctrl.Result{Requeue: true, RequeueAfter: time.Second * 20}, nil
 	}
@@ -201,26 +223,45 @@ func (r *OpenshiftAssistedControlPlaneReconciler) Reconcile(ctx context.Context,
 	if errors.Is(err, containers.ErrImageNotFound) {
 		conditions.MarkFalse(
 			oacp,
-			controlplanev1alpha2.UpgradeAvailableCondition,
-			controlplanev1alpha2.UpgradeImageUnavailableReason,
+			controlplanev1beta1.UpgradeAvailableCondition,
+			controlplanev1beta1.UpgradeImageUnavailableReason,
 			clusterv1.ConditionSeverityError,
 			"upgrade unavailable: %s", err.Error(),
 		)
 		return ctrl.Result{}, err
 	}
 	oacp.Status.Version = k8sVersion
+	markKubernetesVersionMismatch(oacp, k8sVersion)
 	result := ctrl.Result{}
-	if conditions.IsTrue(oacp, controlplanev1alpha2.KubeconfigAvailableCondition) {
+	if conditions.IsTrue(oacp, controlplanev1beta1.KubeconfigAvailableCondition) {
 		// in case upgrade is still in progress, we want to requeue, however we also want to reconcile replicas
 		result, err = r.upgradeWorkloadCluster(ctx, cluster, oacp, architecture, pullsecret)
 		if err != nil {
 			return result, err
 		}
 	}
-	return result, r.reconcileReplicas(ctx, oacp, cluster)
+	replicasResult, err := r.reconcileReplicas(ctx, oacp, cluster)
+	if err != nil {
+		return result, err
+	}
+	result = capiutil.LowestNonZeroResult(result, replicasResult)
+
+	if conditions.IsTrue(oacp, controlplanev1beta1.KubeconfigAvailableCondition) {
+		topologyResult, err := r.reconcileControlPlaneTopology(ctx, oacp, cluster)
+		if err != nil {
+			return result, err
+		}
+		result = capiutil.LowestNonZeroResult(result, topologyResult)
+	}
+
+	// requeue to start the rollout once rolloutAfter is reached, as no event would trigger it
+	if oacp.Spec.RolloutAfter != nil && oacp.Spec.RolloutAfter.After(time.Now()) {
+		result = capiutil.LowestNonZeroResult(result, ctrl.Result{RequeueAfter: time.Until(oacp.Spec.RolloutAfter.Time)})
+	}
+	return result, nil
 }
 
-func getArchitectureFromBootstrapConfigs(ctx context.Context, k8sClient client.Client, oacp *controlplanev1alpha2.OpenshiftAssistedControlPlane) (string, error) {
+func getArchitectureFromBootstrapConfigs(ctx context.Context, k8sClient client.Client, oacp *controlplanev1beta1.OpenshiftAssistedControlPlane) (string, error) {
 	defaultArch := "multi"
 
 	// if oacp is nil, return default arch
@@ -240,7 +281,7 @@ func getArchitectureFromBootstrapConfigs(ctx context.Context, k8sClient client.C
 		client.InNamespace(oacp.Namespace),
 		client.MatchingLabels(labelSelector),
 	}
-	var configList bootstrapv1alpha1.OpenshiftAssistedConfigList
+	var configList bootstrapv1beta1.OpenshiftAssistedConfigList
 	if err := k8sClient.List(ctx, &configList, listOptions...); err != nil {
 		return "", err
 	}
@@ -274,24 +315,28 @@ func getArchitecture(architectures []string, defaultArchitecture string) string
 	return firstArch
 }
 
-func (r *OpenshiftAssistedControlPlaneReconciler) upgradeWorkloadCluster(ctx context.Context, cluster *clusterv1.Cluster, oacp *controlplanev1alpha2.OpenshiftAssistedControlPlane, architecture string, pullSecret []byte) (ctrl.Result, error) {
+func (r *OpenshiftAssistedControlPlaneReconciler) upgradeWorkloadCluster(ctx context.Context, cluster *clusterv1.Cluster, oacp *controlplanev1beta1.OpenshiftAssistedControlPlane, architecture string, pullSecret []byte) (ctrl.Result, error) {
 	log := ctrl.LoggerFrom(ctx)
 
-	var isUpdateInProgress bool
+	var isUpdateInProgress, isUpgradeBlocked bool
+	var upgradeStatus *upgrade.UpgradeStatus
 	defer func() {
+		if isUpgradeBlocked {
+			return
+		}
 		if isUpdateInProgress || !isWorkloadClusterRunningDesiredVersion(oacp) {
-			conditions.MarkFalse(
-				oacp,
-				controlplanev1alpha2.UpgradeCompletedCondition,
-				controlplanev1alpha2.UpgradeInProgressReason,
-				clusterv1.ConditionSeverityInfo,
-				"upgrade to version %s in progress",
-				oacp.Spec.DistributionVersion,
-			)
+			markUpgradeInProgress(oacp, upgradeStatus)
+			if upgradeStatus != nil {
+				r.markUpgradeTimeout(ctx, oacp, upgradeStatus)
+			}
 			return
 		}
-		if conditions.IsFalse(oacp, controlplanev1alpha2.UpgradeCompletedCondition) {
-			conditions.MarkTrue(oacp, controlplanev1alpha2.UpgradeCompletedCondition)
+		if conditions.IsFalse(oacp, controlplanev1beta1.UpgradeCompletedCondition) {
+			conditions.MarkTrue(oacp, controlplanev1beta1.UpgradeCompletedCondition)
+		}
+		if oacp.Status.FailureReason != nil && *oacp.Status.FailureReason == controlplanev1beta1.UpgradeTimedOutReason {
+			oacp.Status.FailureReason = nil
+			oacp.Status.FailureMessage = nil
 		}
 	}()
 
@@ -314,11 +359,24 @@ func (r *OpenshiftAssistedControlPlaneReconciler) upgradeWorkloadCluster(ctx con
 		log.V(logutil.WarningLevel).Info("failed to get OpenShift version from ClusterVersion", "error", err.Error())
 	}
 
-	// TODO: check for upgrade errors, mark relevant conditions
 	isDesiredVersionUpdated, err := upgrader.IsDesiredVersionUpdated(ctx, oacp.Spec.DistributionVersion)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
+	if err := syncUpgradeHistory(ctx, oacp, upgrader); err != nil {
+		return ctrl.Result{}, err
+	}
+	if isWorkloadClusterRunningDesiredVersion(oacp) && !isUpdateInProgress {
+		log.V(logutil.WarningLevel).Info("Cluster is now running expected version, upgraded completed")
+
+		return ctrl.Result{}, nil
+	}
+
+	status, err := upgrader.GetUpgradeStatus(ctx)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	upgradeStatus = &status
 	if isDesiredVersionUpdated && isUpdateInProgress {
 		log.V(logutil.WarningLevel).Info("desired version is updated, but did not complete upgrade yet. Re-reconciling")
 		return ctrl.Result{
@@ -327,26 +385,222 @@ func (r *OpenshiftAssistedControlPlaneReconciler) upgradeWorkloadCluster(ctx con
 		}, nil
 	}
 
-	if isWorkloadClusterRunningDesiredVersion(oacp) && !isUpdateInProgress {
-		log.V(logutil.WarningLevel).Info("Cluster is now running expected version, upgraded completed")
-
-		return ctrl.Result{}, nil
+	pathRequeueAfter, isUpgradeBlocked, err := r.validateUpgradePath(ctx, oacp, architecture)
+	if isUpgradeBlocked || err != nil {
+		return ctrl.Result{RequeueAfter: pathRequeueAfter}, err
+	}
+	if message, requeueAfter, isHeld := getUpgradeHold(oacp, time.Now()); isHeld {
+		isUpgradeBlocked = true
+		log.V(logutil.InfoLevel).Info("upgrade pending", "message", message)
+		conditions.MarkFalse(
+			oacp,
+			controlplanev1beta1.UpgradeCompletedCondition,
+			controlplanev1beta1.UpgradePendingReason,
+			clusterv1.ConditionSeverityInfo,
+			"%s", message,
+		)
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
+	// the health gates only hold back the start of the upgrade, not an upgrade already requested to the workload cluster
+	var failedHealthGates []string
+	if !isDesiredVersionUpdated {
+		failedHealthGates, err = r.checkUpgradeHealthGates(ctx, oacp, cluster)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	if len(failedHealthGates) > 0 {
+		isUpgradeBlocked = true
+		log.V(logutil.InfoLevel).Info("upgrade blocked by health gates", "gates", failedHealthGates)
+		conditions.MarkFalse(
+			oacp,
+			controlplanev1beta1.UpgradeCompletedCondition,
+			controlplanev1beta1.UpgradeHealthGatesFailedReason,
+			clusterv1.ConditionSeverityWarning,
+			"upgrade to version %s blocked by health gates: %s",
+			oacp.Spec.DistributionVersion,
+			strings.Join(failedHealthGates, "; "),
+		)
+		return ctrl.Result{RequeueAfter: 1 * time.Minute}, nil
+	}
+
+	err = upgrader.UpdateClusterVersionDesiredUpdate(
+		ctx,
+		oacp.Spec.DistributionVersion,
+		architecture,
+		getUpgradeOptions(oacp, pullSecret)...,
+	)
+	if reason := getReleaseSignatureFailureReason(err); reason != "" {
+		isUpgradeBlocked = true
+		log.V(logutil.WarningLevel).Info("release signature unavailable", "error", err.Error())
+		conditions.MarkFalse(
+			oacp,
+			controlplanev1beta1.UpgradeAvailableCondition,
+			reason,
+			clusterv1.ConditionSeverityError,
+			"upgrade unavailable: %s", err.Error(),
+		)
+		return ctrl.Result{RequeueAfter: 1 * time.Minute}, nil
+	}
+	if err == nil {
+		recordUpgradeStarted(oacp, time.Now())
+		if reason := conditions.GetReason(oacp, controlplanev1beta1.UpgradeAvailableCondition); reason ==
+			controlplanev1beta1.UpgradeSignatureVerificationFailedReason ||
+			reason == controlplanev1beta1.UpgradeSignatureNotFoundReason {
+			conditions.MarkTrue(oacp, controlplanev1beta1.UpgradeAvailableCondition)
+		}
 	}
-
 	// once updating, requeue to check update status
 	return ctrl.Result{
-			Requeue:      true,
-			RequeueAfter: 1 * time.Minute,
-		},
-		upgrader.UpdateClusterVersionDesiredUpdate(
-			ctx,
+		Requeue:      true,
+		RequeueAfter: 1 * time.Minute,
+	}, err
+}
+
+// markUpgradeInProgress reports the upgrade in progress, or the most severe issue preventing it from completing
+func markUpgradeInProgress(oacp *controlplanev1beta1.OpenshiftAssistedControlPlane, upgradeStatus *upgrade.UpgradeStatus) {
+	if upgradeStatus == nil || len(upgradeStatus.Issues) == 0 {
+		conditions.MarkFalse(
+			oacp,
+			controlplanev1beta1.UpgradeCompletedCondition,
+			controlplanev1beta1.UpgradeInProgressReason,
+			clusterv1.ConditionSeverityInfo,
+			"upgrade to version %s in progress",
 			oacp.Spec.DistributionVersion,
-			architecture,
-			getUpgradeOptions(oacp, pullSecret)...,
 		)
+		return
+	}
+	issue := upgradeStatus.Issues[0]
+	conditions.MarkFalse(
+		oacp,
+		controlplanev1beta1.UpgradeCompletedCondition,
+		getUpgradeIssueReason(issue.Reason),
+		clusterv1.ConditionSeverityWarning,
+		"upgrade to version %s blocked: %s",
+		oacp.Spec.DistributionVersion,
+		issue.Message,
+	)
+}
+
+func getUpgradeIssueReason(reason upgrade.UpgradeIssueReason) string {
+	switch reason {
+	case upgrade.UpgradeReleaseNotAccepted:
+		return controlplanev1beta1.UpgradeReleaseNotAcceptedReason
+	case upgrade.UpgradeFailing:
+		return controlplanev1beta1.UpgradeFailingReason
+	case upgrade.UpgradeClusterOperatorsDegraded:
+		return controlplanev1beta1.UpgradeClusterOperatorsDegradedReason
+	default:
+		return controlplanev1beta1.UpgradeRetrievingUpdatesFailedReason
+	}
+}
+
+// markUpgradeTimeout sets the failure reason and message of the OpenshiftAssistedControlPlane when the upgrade did not
+// complete within the upgrade timeout. The upgrade starts with the rollout of the desired release, or when the
+// UpgradeCompleted condition turned false if the desired release was not rolled out yet.
+func (r *OpenshiftAssistedControlPlaneReconciler) markUpgradeTimeout(
+	ctx context.Context,
+	oacp *controlplanev1beta1.OpenshiftAssistedControlPlane,
+	upgradeStatus *upgrade.UpgradeStatus,
+) {
+	if r.UpgradeTimeout <= 0 || oacp.Status.FailureReason != nil {
+		return
+	}
+	startedTime := upgradeStatus.StartedTime
+	if startedTime == nil {
+		startedTime = &conditions.Get(oacp, controlplanev1beta1.UpgradeCompletedCondition).LastTransitionTime
+	}
+	if time.Since(startedTime.Time) < r.UpgradeTimeout {
+		return
+	}
+	message := fmt.Sprintf("upgrade to version %s did not complete within %s", oacp.Spec.DistributionVersion, r.UpgradeTimeout)
+	if len(upgradeStatus.Issues) > 0 {
+		message = fmt.Sprintf("%s: %s", message, upgradeStatus.Issues[0].Message)
+	}
+	ctrl.LoggerFrom(ctx).V(logutil.WarningLevel).Info("upgrade timed out", "message", message)
+	failureReason := controlplanev1beta1.UpgradeTimedOutReason
+	oacp.Status.FailureReason = &failureReason
+	oacp.Status.FailureMessage = &message
+}
+
+// validateUpgradePath checks that the workload cluster can be upgraded from its current version to the desired
+// version. Downgrades and upgrades skipping a minor version are always refused; when an update graph is configured,
+// the upgrade must also be an edge recommended by the graph, in the configured channel. Returns true when the upgrade
+// is refused, with the delay after which the path must be validated again when the graph may change.
+func (r *OpenshiftAssistedControlPlaneReconciler) validateUpgradePath(ctx context.Context, oacp *controlplanev1beta1.OpenshiftAssistedControlPlane, architecture string) (time.Duration, bool, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	// the current version is unknown until the ClusterVersion reports a completed update
+	if oacp.Status.DistributionVersion == "" {
+		return 0, false, nil
+	}
+	err := upgrade.ValidateUpgradeVersions(oacp.Status.DistributionVersion, oacp.Spec.DistributionVersion)
+	if err == nil && r.UpgradeGraphProvider != nil {
+		var channel string
+		channel, err = upgrade.GetUpgradeChannel(r.UpgradeChannel, oacp.Spec.DistributionVersion)
+		if err != nil {
+			return 0, false, err
+		}
+		var graph *upgrade.UpgradeGraph
+		graph, err = r.UpgradeGraphProvider.GetUpgradeGraph(ctx, channel, architecture)
+		if err != nil {
+			conditions.MarkFalse(
+				oacp,
+				controlplanev1beta1.UpgradeCompletedCondition,
+				controlplanev1beta1.UpgradeGraphUnavailableReason,
+				clusterv1.ConditionSeverityWarning,
+				"failed to get update graph: %s", err.Error(),
+			)
+			return 0, true, err
+		}
+		err = upgrade.ValidateUpgradePath(graph, oacp.Status.DistributionVersion, oacp.Spec.DistributionVersion)
+	}
+	var pathErr *upgrade.UpgradePathError
+	if !errors.As(err, &pathErr) {
+		return 0, false, err
+	}
+	log.V(logutil.WarningLevel).Info("upgrade refused", "reason", pathErr.Reason, "message", pathErr.Message)
+	conditions.MarkFalse(
+		oacp,
+		controlplanev1beta1.UpgradeCompletedCondition,
+		getUpgradePathReason(pathErr.Reason),
+		clusterv1.ConditionSeverityWarning,
+		"%s", pathErr.Message,
+	)
+	// the graph is not watched: a path it does not recommend yet is validated again later
+	if pathErr.Reason == upgrade.UpgradePathBlocked {
+		return upgradePathRequeueAfter, true, nil
+	}
+	return 0, true, nil
+}
+
+func getUpgradePathReason(reason upgrade.UpgradePathReason) string {
+	switch reason {
+	case upgrade.UpgradePathDowngrade:
+		return controlplanev1beta1.UpgradeDowngradeNotAllowedReason
+	case upgrade.UpgradePathSkipsMinorVersion:
+		return controlplanev1beta1.UpgradeSkipsMinorVersionReason
+	case upgrade.UpgradePathInvalidVersion:
+		return controlplanev1beta1.UpgradeInvalidVersionReason
+	default:
+		return controlplanev1beta1.UpgradePathBlockedReason
+	}
 }
 
-func getUpgradeOptions(oacp *controlplanev1alpha2.OpenshiftAssistedControlPlane, pullSecret []byte) []upgrade.ClusterUpgradeOption {
+// getReleaseSignatureFailureReason returns the reason of the UpgradeAvailable condition when the release signature
+// prevents the update, or an empty reason
+func getReleaseSignatureFailureReason(err error) string {
+	switch {
+	case errors.Is(err, upgrade.ErrReleaseSignatureVerification):
+		return controlplanev1beta1.UpgradeSignatureVerificationFailedReason
+	case errors.Is(err, upgrade.ErrReleaseSignatureNotFound):
+		return controlplanev1beta1.UpgradeSignatureNotFoundReason
+	default:
+		return ""
+	}
+}
+
+func getUpgradeOptions(oacp *controlplanev1beta1.OpenshiftAssistedControlPlane, pullSecret []byte) []upgrade.ClusterUpgradeOption {
 	upgradeOptions := []upgrade.ClusterUpgradeOption{
 		{
 			Name:  upgrade.ReleaseImagePullSecretOption,
@@ -359,33 +613,74 @@ func getUpgradeOptions(oacp *controlplanev1alpha2.OpenshiftAssistedControlPlane,
 			Value: repo,
 		})
 	}
+	if oacp.Spec.UpgradeStrategy != nil &&
+		oacp.Spec.UpgradeStrategy.ReleaseSignatures == controlplanev1beta1.PushReleaseSignaturesMode {
+		upgradeOptions = append(upgradeOptions, upgrade.ClusterUpgradeOption{
+			Name:  upgrade.PushReleaseSignaturesOption,
+			Value: "true",
+		})
+	}
 	return upgradeOptions
 }
 
-func isWorkloadClusterRunningDesiredVersion(oacp *controlplanev1alpha2.OpenshiftAssistedControlPlane) bool {
+func isWorkloadClusterRunningDesiredVersion(oacp *controlplanev1beta1.OpenshiftAssistedControlPlane) bool {
 	return oacp.Spec.DistributionVersion == oacp.Status.DistributionVersion
 }
 
-func markKubernetesVersionCondition(oacp *controlplanev1alpha2.OpenshiftAssistedControlPlane, err error) {
+func markKubernetesVersionCondition(oacp *controlplanev1beta1.OpenshiftAssistedControlPlane, err error) {
 	if err != nil {
 		conditions.MarkFalse(
 			oacp,
-			controlplanev1alpha2.KubernetesVersionAvailableCondition,
-			controlplanev1alpha2.KubernetesVersionUnavailableFailedReason,
+			controlplanev1beta1.KubernetesVersionAvailableCondition,
+			controlplanev1beta1.KubernetesVersionUnavailableFailedReason,
 			clusterv1.ConditionSeverityWarning,
 			"failed to get k8s version from release image: %v",
 			err,
 		)
 	} else {
-		conditions.MarkTrue(oacp, controlplanev1alpha2.KubernetesVersionAvailableCondition)
+		conditions.MarkTrue(oacp, controlplanev1beta1.KubernetesVersionAvailableCondition)
+	}
+}
+
+// markKubernetesVersionMismatch marks the KubernetesVersionAvailable condition as false when spec.version (i.e. set
+// by the Cluster topology) does not match the Kubernetes version shipped with the requested OpenShift release.
+// Only major and minor versions are compared, as OpenShift z-streams do not map 1:1 to Kubernetes patch releases.
+func markKubernetesVersionMismatch(oacp *controlplanev1beta1.OpenshiftAssistedControlPlane, detectedVersion *string) {
+	if oacp.Spec.Version == "" || detectedVersion == nil {
+		return
+	}
+	if isSameMinorVersion(oacp.Spec.Version, *detectedVersion) {
+		return
+	}
+	conditions.MarkFalse(
+		oacp,
+		controlplanev1beta1.KubernetesVersionAvailableCondition,
+		controlplanev1beta1.KubernetesVersionMismatchReason,
+		clusterv1.ConditionSeverityWarning,
+		"requested Kubernetes version %s does not match version %s shipped with OpenShift %s",
+		oacp.Spec.Version,
+		*detectedVersion,
+		oacp.Spec.DistributionVersion,
+	)
+}
+
+func isSameMinorVersion(a, b string) bool {
+	aVersion, err := semver.ParseTolerant(a)
+	if err != nil {
+		return false
 	}
+	bVersion, err := semver.ParseTolerant(b)
+	if err != nil {
+		return false
+	}
+	return aVersion.Major == bVersion.Major && aVersion.Minor == bVersion.Minor
 }
 
 // Ensures dependencies are deleted before allowing the OpenshiftAssistedControlPlane to be deleted
 // Deletes the ClusterDeployment (which deletes the AgentClusterInstall)
 // Machines, InfraMachines, and OpenshiftAssistedConfigs get auto-deleted when the ACP has a deletion timestamp - this deprovisions the BMH automatically
 // TODO: should we handle watching until all machines & openshiftassistedconfigs are deleted too?
-func (r *OpenshiftAssistedControlPlaneReconciler) handleDeletion(ctx context.Context, acp *controlplanev1alpha2.OpenshiftAssistedControlPlane) error {
+func (r *OpenshiftAssistedControlPlaneReconciler) handleDeletion(ctx context.Context, acp *controlplanev1beta1.OpenshiftAssistedControlPlane) error {
 	log := ctrl.LoggerFrom(ctx)
 
 	if !controllerutil.ContainsFinalizer(acp, acpFinalizer) {
@@ -422,7 +717,7 @@ func (r *OpenshiftAssistedControlPlaneReconciler) deleteClusterDeployment(
 	return r.Client.Delete(ctx, cd)
 }
 
-func (r *OpenshiftAssistedControlPlaneReconciler) computeDesiredMachine(oacp *controlplanev1alpha2.OpenshiftAssistedControlPlane, name string, cluster *clusterv1.Cluster, failureDomain *string) *clusterv1.Machine {
+func (r *OpenshiftAssistedControlPlaneReconciler) computeDesiredMachine(oacp *controlplanev1beta1.OpenshiftAssistedControlPlane, name string, cluster *clusterv1.Cluster, failureDomain *string) *clusterv1.Machine {
 	var machineUID types.UID
 	annotations := map[string]string{
 		"bmac.agent-install.openshift.io/role": "master",
@@ -466,20 +761,24 @@ func (r *OpenshiftAssistedControlPlaneReconciler) computeDesiredMachine(oacp *co
 	for k, v := range annotations {
 		desiredMachine.Annotations[k] = v
 	}
+	if _, distributionVersion, ok := getMachineVersions(oacp); ok {
+		desiredMachine.Annotations[controlplanev1beta1.DistributionVersionAnnotation] = distributionVersion
+	}
 
 	return desiredMachine
 }
 
 // Returns desired machine specs given controlplane and clustername
-func getMachineSpec(acp *controlplanev1alpha2.OpenshiftAssistedControlPlane, cluster *clusterv1.Cluster) clusterv1.MachineSpec {
+func getMachineSpec(acp *controlplanev1beta1.OpenshiftAssistedControlPlane, cluster *clusterv1.Cluster) clusterv1.MachineSpec {
 	// for creating
+	k8sVersion, _, _ := getMachineVersions(acp)
 
 	return clusterv1.MachineSpec{
 		ClusterName:             cluster.Name,
 		NodeDrainTimeout:        acp.Spec.MachineTemplate.NodeDrainTimeout,
 		NodeDeletionTimeout:     acp.Spec.MachineTemplate.NodeDeletionTimeout,
 		NodeVolumeDetachTimeout: acp.Spec.MachineTemplate.NodeVolumeDetachTimeout,
-		// TODO: add distribution version
+		Version:                 k8sVersion,
 	}
 }
 
@@ -487,17 +786,17 @@ func getMachineSpec(acp *controlplanev1alpha2.OpenshiftAssistedControlPlane, clu
 func (r *OpenshiftAssistedControlPlaneReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	//TODO: maybe enqueue for clusterdeployment owned by this ACP in case it gets deleted...?
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&controlplanev1alpha2.OpenshiftAssistedControlPlane{}).
+		For(&controlplanev1beta1.OpenshiftAssistedControlPlane{}).
 		Watches(
 			&clusterv1.Machine{},
-			handler.EnqueueRequestForOwner(r.Scheme, mgr.GetRESTMapper(), &controlplanev1alpha2.OpenshiftAssistedControlPlane{}),
+			handler.EnqueueRequestForOwner(r.Scheme, mgr.GetRESTMapper(), &controlplanev1beta1.OpenshiftAssistedControlPlane{}),
 		).
 		Complete(r)
 }
 
 func (r *OpenshiftAssistedControlPlaneReconciler) ensureClusterDeployment(
 	ctx context.Context,
-	acp *controlplanev1alpha2.OpenshiftAssistedControlPlane,
+	acp *controlplanev1beta1.OpenshiftAssistedControlPlane,
 	clusterName string,
 ) error {
 	if acp.Status.ClusterDeploymentRef == nil {
@@ -527,51 +826,260 @@ func (r *OpenshiftAssistedControlPlaneReconciler) ensureClusterDeployment(
 	return nil
 }
 
-func (r *OpenshiftAssistedControlPlaneReconciler) reconcileReplicas(ctx context.Context, oacp *controlplanev1alpha2.OpenshiftAssistedControlPlane, cluster *clusterv1.Cluster) error {
+func (r *OpenshiftAssistedControlPlaneReconciler) reconcileReplicas(ctx context.Context, oacp *controlplanev1beta1.OpenshiftAssistedControlPlane, cluster *clusterv1.Cluster) (ctrl.Result, error) {
 	log := ctrl.LoggerFrom(ctx)
 	machines, err := collections.GetFilteredMachinesForCluster(ctx, r.Client, cluster, collections.OwnedMachines(oacp))
 	if err != nil {
-		return err
+		return ctrl.Result{}, err
 	}
 
-	upToDateMachines := collections.Machines{}
-	for _, machine := range machines {
-		if r.hasExpectedSpecs(ctx, machine, oacp, cluster) {
-			upToDateMachines.Insert(machine)
-		}
+	machinesNeedingRollout, err := r.getMachinesNeedingRollout(ctx, oacp, cluster, machines)
+	if err != nil {
+		return ctrl.Result{}, err
 	}
+	upToDateMachines := machines.Difference(machinesNeedingRollout)
+	if err := r.syncMachinesMetadata(ctx, oacp, cluster, machines); err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.syncMachinesVersions(ctx, oacp, machines); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	defer func() {
+		oacp.Status.Selector = collections.ControlPlaneSelectorForCluster(cluster.Name).String()
+		markMachinesSpecUpToDateCondition(oacp, machinesNeedingRollout, upToDateMachines)
+		r.updateReplicaStatus(oacp, machines, upToDateMachines)
+	}()
+
+	// unhealthy machines are remediated before any other operation, the scale up will then replace them
+	result, err := r.reconcileUnhealthyMachines(ctx, oacp, cluster, machines)
+	if err != nil || !result.IsZero() {
+		return result, err
+	}
+
 	numMachines := machines.Len()
 	desiredReplicas := int(oacp.Spec.Replicas)
 	machinesToCreate := desiredReplicas - numMachines
-	var errs []error
-	if machinesToCreate > 0 {
+	switch {
+	case machinesToCreate > 0:
 		fd, err := failuredomains.NextFailureDomainForScaleUp(ctx, cluster, machines, upToDateMachines)
 		if err != nil {
-			return fmt.Errorf("failed to find failure domain for scale up: %v", err)
+			return ctrl.Result{}, fmt.Errorf("failed to find failure domain for scale up: %v", err)
 		}
 		machine, err := r.scaleUpControlPlane(ctx, oacp, cluster, fd)
 		if err != nil {
-			return fmt.Errorf("failed to scale up control plane: %v", err)
+			return ctrl.Result{}, fmt.Errorf("failed to scale up control plane: %v", err)
 		}
 		log.V(logutil.InfoLevel).Info("creating controlplane machine", "machine name", machine.Name)
+	case machinesToCreate < 0:
+		eligibleMachines := machines
+		if machinesNeedingRollout.Len() > 0 {
+			// retire out of date machines first, once the machine replacing them joined the cluster
+			if !isControlPlaneStable(machines) {
+				log.V(logutil.InfoLevel).Info("waiting for control plane machines to be ready before scaling down")
+				break
+			}
+			eligibleMachines = machinesNeedingRollout
+		}
+		fd, err := failuredomains.NextFailureDomainForScaleDown(ctx, cluster, eligibleMachines)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to find failure domain for scale down: %v", err)
+		}
+		machine, err := r.scaleDownControlPlane(ctx, oacp, cluster, eligibleMachines, fd)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to scale down control plane: %v", err)
+		}
+		if machine == nil {
+			return ctrl.Result{RequeueAfter: etcdRequeueAfter}, nil
+		}
+		log.V(logutil.InfoLevel).Info("deleting controlplane machine", "machine name", machine.Name)
+	case machinesNeedingRollout.Len() > 0:
+		result, err := r.rolloutControlPlane(ctx, oacp, cluster, machines, upToDateMachines, machinesNeedingRollout)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to roll out control plane: %v", err)
+		}
+		return result, nil
+	default:
+		// no machine has to be removed anymore
+		if conditions.IsFalse(oacp, controlplanev1beta1.ScaleDownAllowedCondition) {
+			conditions.MarkTrue(oacp, controlplanev1beta1.ScaleDownAllowedCondition)
+		}
 	}
-	if machinesToCreate < 0 {
-		fd, err := failuredomains.NextFailureDomainForScaleDown(ctx, cluster, machines)
+	return ctrl.Result{}, nil
+}
+
+// getMachinesNeedingRollout returns the machines that have to be replaced, either because their spec does not match
+// the desired one or because they were created before spec.rolloutAfter. Machines being deleted are not compared, as
+// their bootstrap config and infrastructure may already be gone.
+func (r *OpenshiftAssistedControlPlaneReconciler) getMachinesNeedingRollout(
+	ctx context.Context,
+	oacp *controlplanev1beta1.OpenshiftAssistedControlPlane,
+	cluster *clusterv1.Cluster,
+	machines collections.Machines,
+) (collections.Machines, error) {
+	now := metav1.Now()
+	machinesNeedingRollout := machines.Filter(collections.ShouldRolloutAfter(&now, oacp.Spec.RolloutAfter))
+	for _, machine := range machines.Difference(machinesNeedingRollout) {
+		if !machine.DeletionTimestamp.IsZero() {
+			continue
+		}
+		upToDate, err := r.hasExpectedSpecs(ctx, machine, oacp, cluster)
 		if err != nil {
-			return fmt.Errorf("failed to find failure domain for scale down: %v", err)
+			return nil, err
 		}
-		machine, err := r.scaleDownControlPlane(ctx, machines, fd)
+		if !upToDate {
+			machinesNeedingRollout.Insert(machine)
+		}
+	}
+	return machinesNeedingRollout, nil
+}
+
+// rolloutControlPlane replaces one out of date machine when the control plane has the desired number of replicas.
+// New control plane machines can only join the cluster through the assisted installer day-2 flow, so the rollout
+// waits for the cluster to be installed, and for every machine to be ready before each step.
+// With maxSurge 1 a new machine is added, and the out of date one is deleted by the scale down once the new
+// machine joined. With maxSurge 0 the out of date machine is deleted first, and replaced by the scale up.
+// Single node control planes are not rolled out.
+func (r *OpenshiftAssistedControlPlaneReconciler) rolloutControlPlane(
+	ctx context.Context,
+	oacp *controlplanev1beta1.OpenshiftAssistedControlPlane,
+	cluster *clusterv1.Cluster,
+	machines, upToDateMachines, machinesNeedingRollout collections.Machines,
+) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	if oacp.Spec.Replicas == 1 {
+		log.V(logutil.InfoLevel).Info("not rolling out the machine of a single node control plane")
+		return ctrl.Result{}, nil
+	}
+	if !oacp.Status.Ready {
+		log.V(logutil.InfoLevel).Info("waiting for the cluster to be installed before rolling out control plane machines")
+		return ctrl.Result{}, nil
+	}
+	if !isControlPlaneStable(machines) {
+		log.V(logutil.InfoLevel).Info("waiting for control plane machines to be ready before rolling out")
+		return ctrl.Result{}, nil
+	}
+
+	maxSurge, err := getMaxSurge(oacp)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if maxSurge > 0 {
+		fd, err := failuredomains.NextFailureDomainForScaleUp(ctx, cluster, machines, upToDateMachines)
 		if err != nil {
-			return fmt.Errorf("failed to scale down control plane: %v", err)
+			return ctrl.Result{}, fmt.Errorf("failed to find failure domain for scale up: %v", err)
 		}
-		log.V(logutil.InfoLevel).Info("creating controlplane machine", "machine name", machine.Name)
+		machine, err := r.scaleUpControlPlane(ctx, oacp, cluster, fd)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		log.V(logutil.InfoLevel).Info("creating controlplane machine to replace out of date machines", "machine name", machine.Name)
+		return ctrl.Result{}, nil
 	}
 
-	r.updateReplicaStatus(oacp, machines, upToDateMachines)
+	fd, err := failuredomains.NextFailureDomainForScaleDown(ctx, cluster, machinesNeedingRollout)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to find failure domain for scale down: %v", err)
+	}
+	machine, err := r.scaleDownControlPlane(ctx, oacp, cluster, machinesNeedingRollout, fd)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if machine == nil {
+		return ctrl.Result{RequeueAfter: etcdRequeueAfter}, nil
+	}
+	log.V(logutil.InfoLevel).Info("deleting out of date controlplane machine", "machine name", machine.Name)
+	return ctrl.Result{}, nil
+}
+
+// getMaxSurge returns the number of machines that can be created above the desired replicas during a rollout
+func getMaxSurge(oacp *controlplanev1beta1.OpenshiftAssistedControlPlane) (int, error) {
+	if oacp.Spec.RolloutStrategy == nil || oacp.Spec.RolloutStrategy.RollingUpdate == nil ||
+		oacp.Spec.RolloutStrategy.RollingUpdate.MaxSurge == nil {
+		return 1, nil
+	}
+	return intstr.GetScaledValueFromIntOrPercent(oacp.Spec.RolloutStrategy.RollingUpdate.MaxSurge, int(oacp.Spec.Replicas), true)
+}
+
+// isControlPlaneStable returns true when all the control plane machines are ready and none of them is being deleted
+func isControlPlaneStable(machines collections.Machines) bool {
+	return machines.Filter(collections.HasDeletionTimestamp).Len() == 0 &&
+		machines.Filter(collections.Not(collections.IsReady())).Len() == 0
+}
+
+func markMachinesSpecUpToDateCondition(oacp *controlplanev1beta1.OpenshiftAssistedControlPlane, machinesNeedingRollout, upToDateMachines collections.Machines) {
+	if machinesNeedingRollout.Len() == 0 {
+		conditions.MarkTrue(oacp, controlplanev1beta1.MachinesSpecUpToDateCondition)
+		return
+	}
+	if oacp.Spec.Replicas == 1 {
+		conditions.MarkFalse(oacp, controlplanev1beta1.MachinesSpecUpToDateCondition,
+			controlplanev1beta1.RollingUpdateNotSupportedReason, clusterv1.ConditionSeverityWarning,
+			"%d replicas with outdated spec cannot be rolled out: single node control planes do not support rollouts",
+			machinesNeedingRollout.Len())
+		return
+	}
+	if !oacp.Status.Ready {
+		conditions.MarkFalse(oacp, controlplanev1beta1.MachinesSpecUpToDateCondition,
+			controlplanev1beta1.RollingUpdateWaitingForInstallReason, clusterv1.ConditionSeverityInfo,
+			"%d replicas with outdated spec will be rolled out once the cluster is installed", machinesNeedingRollout.Len())
+		return
+	}
+	conditions.MarkFalse(oacp, controlplanev1beta1.MachinesSpecUpToDateCondition,
+		controlplanev1beta1.RollingUpdateInProgressReason, clusterv1.ConditionSeverityWarning,
+		"Rolling %d replicas with outdated spec (%d replicas up to date)", machinesNeedingRollout.Len(), upToDateMachines.Len())
+}
+
+// syncMachinesMetadata propagates labels and annotations from the machine template to existing control plane machines,
+// so that changes made to spec.machineTemplate.metadata (i.e. by the Cluster topology) are applied in place.
+func (r *OpenshiftAssistedControlPlaneReconciler) syncMachinesMetadata(
+	ctx context.Context,
+	oacp *controlplanev1beta1.OpenshiftAssistedControlPlane,
+	cluster *clusterv1.Cluster,
+	machines collections.Machines,
+) error {
+	desiredLabels := util.ControlPlaneMachineLabelsForCluster(oacp, cluster.Name)
+	desiredAnnotations := oacp.Spec.MachineTemplate.ObjectMeta.Annotations
+	var errs []error
+	for _, machine := range machines {
+		if isMapSubset(desiredLabels, machine.Labels) && isMapSubset(desiredAnnotations, machine.Annotations) {
+			continue
+		}
+		patchHelper, err := patch.NewHelper(machine, r.Client)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		machine.Labels = mergeMaps(machine.Labels, desiredLabels)
+		machine.Annotations = mergeMaps(machine.Annotations, desiredAnnotations)
+		if err := patchHelper.Patch(ctx, machine); err != nil {
+			errs = append(errs, err)
+		}
+	}
 	return kerrors.NewAggregate(errs)
 }
 
-func (r *OpenshiftAssistedControlPlaneReconciler) scaleUpControlPlane(ctx context.Context, acp *controlplanev1alpha2.OpenshiftAssistedControlPlane, cluster *clusterv1.Cluster, failureDomain *string) (*clusterv1.Machine, error) {
+// isMapSubset returns true if all the entries in subset are present in superset
+func isMapSubset(subset, superset map[string]string) bool {
+	for k, v := range subset {
+		if value, ok := superset[k]; !ok || value != v {
+			return false
+		}
+	}
+	return true
+}
+
+func mergeMaps(dst, src map[string]string) map[string]string {
+	if dst == nil {
+		dst = make(map[string]string, len(src))
+	}
+	for k, v := range src {
+		dst[k] = v
+	}
+	return dst
+}
+
+func (r *OpenshiftAssistedControlPlaneReconciler) scaleUpControlPlane(ctx context.Context, acp *controlplanev1beta1.OpenshiftAssistedControlPlane, cluster *clusterv1.Cluster, failureDomain *string) (*clusterv1.Machine, error) {
 	name := names.SimpleNameGenerator.GenerateName(acp.Name + "-")
 	machine, err := r.generateMachine(ctx, acp, name, cluster, failureDomain)
 	if err != nil {
@@ -580,7 +1088,7 @@ func (r *OpenshiftAssistedControlPlaneReconciler) scaleUpControlPlane(ctx contex
 	bootstrapConfig := r.generateOpenshiftAssistedConfig(acp, cluster.Name, name)
 	_ = controllerutil.SetOwnerReference(acp, bootstrapConfig, r.Scheme)
 	if err := r.Client.Create(ctx, bootstrapConfig); err != nil {
-		conditions.MarkFalse(acp, controlplanev1alpha2.MachinesCreatedCondition, controlplanev1alpha2.BootstrapTemplateCloningFailedReason,
+		conditions.MarkFalse(acp, controlplanev1beta1.MachinesCreatedCondition, controlplanev1beta1.BootstrapTemplateCloningFailedReason,
 			clusterv1.ConditionSeverityError, "error creating bootstrap config: %v", err)
 		return nil, err
 	}
@@ -589,9 +1097,13 @@ func (r *OpenshiftAssistedControlPlaneReconciler) scaleUpControlPlane(ctx contex
 		return nil, err
 	}
 	machine.Spec.Bootstrap.ConfigRef = bootstrapRef
+	// link the replacement of a remediated machine to it, in order to keep track of remediation retries
+	if remediationData, ok := acp.Annotations[controlplanev1beta1.RemediationInProgressAnnotation]; ok {
+		machine.Annotations[controlplanev1beta1.RemediationForAnnotation] = remediationData
+	}
 	if err := r.Client.Create(ctx, machine); err != nil {
-		conditions.MarkFalse(acp, controlplanev1alpha2.MachinesCreatedCondition,
-			controlplanev1alpha2.MachineGenerationFailedReason,
+		conditions.MarkFalse(acp, controlplanev1beta1.MachinesCreatedCondition,
+			controlplanev1beta1.MachineGenerationFailedReason,
 			clusterv1.ConditionSeverityError, "error creating machine %v", err)
 		if deleteBootstrapErr := r.Client.Delete(ctx, bootstrapConfig); deleteBootstrapErr != nil {
 			err = errors.Join(err, deleteBootstrapErr)
@@ -601,10 +1113,11 @@ func (r *OpenshiftAssistedControlPlaneReconciler) scaleUpControlPlane(ctx contex
 		}
 		return nil, err
 	}
+	delete(acp.Annotations, controlplanev1beta1.RemediationInProgressAnnotation)
 	return machine, nil
 }
 
-func (r *OpenshiftAssistedControlPlaneReconciler) updateReplicaStatus(oacp *controlplanev1alpha2.OpenshiftAssistedControlPlane, machines collections.Machines, upToDateMachines collections.Machines) {
+func (r *OpenshiftAssistedControlPlaneReconciler) updateReplicaStatus(oacp *controlplanev1beta1.OpenshiftAssistedControlPlane, machines collections.Machines, upToDateMachines collections.Machines) {
 	desiredReplicas := oacp.Spec.Replicas
 	readyMachines := machines.Filter(collections.IsReady()).Len()
 
@@ -614,7 +1127,7 @@ func (r *OpenshiftAssistedControlPlaneReconciler) updateReplicaStatus(oacp *cont
 	oacp.Status.UnavailableReplicas = oacp.Status.Replicas - int32(readyMachines)
 	oacp.Status.ReadyReplicas = int32(readyMachines)
 	if oacp.Status.ReadyReplicas == desiredReplicas {
-		conditions.MarkTrue(oacp, controlplanev1alpha2.MachinesCreatedCondition)
+		conditions.MarkTrue(oacp, controlplanev1beta1.MachinesCreatedCondition)
 	}
 
 	// Aggregate the operational state of all the machines; while aggregating we are adding the
@@ -626,48 +1139,81 @@ func (r *OpenshiftAssistedControlPlaneReconciler) updateReplicaStatus(oacp *cont
 		conditions.WithStepCounterIf(false))
 }
 
-func (r *OpenshiftAssistedControlPlaneReconciler) hasExpectedSpecs(ctx context.Context, machine *clusterv1.Machine, acp *controlplanev1alpha2.OpenshiftAssistedControlPlane, cluster *clusterv1.Cluster) bool {
+func (r *OpenshiftAssistedControlPlaneReconciler) hasExpectedSpecs(ctx context.Context, machine *clusterv1.Machine, acp *controlplanev1beta1.OpenshiftAssistedControlPlane, cluster *clusterv1.Cluster) (bool, error) {
 	expectedSpecs := getMachineSpec(acp, cluster)
-	if !isEqualPtr(expectedSpecs.Version, machine.Spec.Version) {
-		return false
-	}
-
-	// TODO: add status.DistributionVersion
+	// versions are not compared, as upgrades update the machines in place
 	if !isEqualPtr(expectedSpecs.NodeDrainTimeout, machine.Spec.NodeDrainTimeout) {
-		return false
+		return false, nil
 	}
 	if !isEqualPtr(expectedSpecs.NodeDeletionTimeout, machine.Spec.NodeDeletionTimeout) {
-		return false
+		return false, nil
 	}
 	if !isEqualPtr(expectedSpecs.NodeVolumeDetachTimeout, machine.Spec.NodeVolumeDetachTimeout) {
-		return false
+		return false, nil
 	}
 	if expectedSpecs.ClusterName != machine.Spec.ClusterName {
-		return false
+		return false, nil
 	}
 
+	matches, err := r.matchesBootstrapConfig(ctx, machine, acp)
+	if err != nil || !matches {
+		return false, err
+	}
+	return r.matchesInfraTemplate(ctx, machine, acp)
+}
+
+// matchesBootstrapConfig returns true if the machine bootstrap config has the desired spec, or if it does not exist.
+// It returns an error when the bootstrap config cannot be retrieved, as the machine cannot be compared.
+func (r *OpenshiftAssistedControlPlaneReconciler) matchesBootstrapConfig(ctx context.Context, machine *clusterv1.Machine, acp *controlplanev1beta1.OpenshiftAssistedControlPlane) (bool, error) {
+	if machine.Spec.Bootstrap.ConfigRef == nil {
+		return true, nil
+	}
 	expectedBootstrapConfigSpec := acp.Spec.OpenshiftAssistedConfigSpec
-	bootstrapConfig := &bootstrapv1alpha1.OpenshiftAssistedConfig{}
+	bootstrapConfig := &bootstrapv1beta1.OpenshiftAssistedConfig{}
 	if err := r.Client.Get(ctx, types.NamespacedName{Name: machine.Spec.Bootstrap.ConfigRef.Name, Namespace: machine.Namespace}, bootstrapConfig); err != nil {
-		return false
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, fmt.Errorf("failed to get bootstrap config of machine %s: %w", machine.Name, err)
 	}
-	if !equality.Semantic.DeepDerivative(expectedBootstrapConfigSpec, bootstrapConfig.Spec) {
-		return false
+	return equality.Semantic.DeepDerivative(expectedBootstrapConfigSpec, bootstrapConfig.Spec), nil
+}
+
+// matchesInfraTemplate returns true if the machine infrastructure was cloned from the infrastructure template
+// currently referenced by the control plane.
+// Machines whose infrastructure does not exist or was not cloned from a template are considered up to date. It returns
+// an error when the infrastructure cannot be retrieved, as the machine cannot be compared.
+func (r *OpenshiftAssistedControlPlaneReconciler) matchesInfraTemplate(ctx context.Context, machine *clusterv1.Machine, acp *controlplanev1beta1.OpenshiftAssistedControlPlane) (bool, error) {
+	infraObj, err := external.Get(ctx, r.Client, &machine.Spec.InfrastructureRef)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, fmt.Errorf("failed to get infrastructure of machine %s: %w", machine.Name, err)
+	}
+	clonedFromName, ok := infraObj.GetAnnotations()[clusterv1.TemplateClonedFromNameAnnotation]
+	if !ok {
+		return true, nil
+	}
+	clonedFromGroupKind, ok := infraObj.GetAnnotations()[clusterv1.TemplateClonedFromGroupKindAnnotation]
+	if !ok {
+		return true, nil
 	}
 
-	// TODO: we should check they are from the same machinetemplate as currently referenced from CP
-	return true
+	templateRef := acp.Spec.MachineTemplate.InfrastructureRef
+	return clonedFromName == templateRef.Name &&
+		clonedFromGroupKind == templateRef.GroupVersionKind().GroupKind().String(), nil
 }
 
-// isEqualPtr compares two pointers of the same type and returns true if they are equal or the expected is nil.
+// isEqualPtr compares the values of two pointers of the same type and returns true if they are equal or the expected is nil.
 func isEqualPtr[T comparable](expected *T, actual *T) bool {
 	if expected == nil {
 		return true
 	}
-	return expected == actual
+	return actual != nil && *expected == *actual
 }
 
-func (r *OpenshiftAssistedControlPlaneReconciler) generateMachine(ctx context.Context, acp *controlplanev1alpha2.OpenshiftAssistedControlPlane, name string, cluster *clusterv1.Cluster, failureDomain *string) (*clusterv1.Machine, error) {
+func (r *OpenshiftAssistedControlPlaneReconciler) generateMachine(ctx context.Context, acp *controlplanev1beta1.OpenshiftAssistedControlPlane, name string, cluster *clusterv1.Cluster, failureDomain *string) (*clusterv1.Machine, error) {
 	// Compute desired Machine
 	machine := r.computeDesiredMachine(acp, name, cluster, failureDomain)
 	infraRef, err := r.computeInfraRef(ctx, acp, machine.Name, cluster.Name)
@@ -678,11 +1224,11 @@ func (r *OpenshiftAssistedControlPlaneReconciler) generateMachine(ctx context.Co
 	return machine, nil
 }
 
-func (r *OpenshiftAssistedControlPlaneReconciler) computeInfraRef(ctx context.Context, acp *controlplanev1alpha2.OpenshiftAssistedControlPlane, machineName, clusterName string) (*corev1.ObjectReference, error) {
+func (r *OpenshiftAssistedControlPlaneReconciler) computeInfraRef(ctx context.Context, acp *controlplanev1beta1.OpenshiftAssistedControlPlane, machineName, clusterName string) (*corev1.ObjectReference, error) {
 	// Since the cloned resource should eventually have a controller ref for the Machine, we create an
 	// OwnerReference here without the Controller field set
 	infraCloneOwner := &metav1.OwnerReference{
-		APIVersion: controlplanev1alpha2.GroupVersion.String(),
+		APIVersion: controlplanev1beta1.GroupVersion.String(),
 		Kind:       openshiftAssistedControlPlaneKind,
 		Name:       acp.Name,
 		UID:        acp.UID,
@@ -701,15 +1247,15 @@ func (r *OpenshiftAssistedControlPlaneReconciler) computeInfraRef(ctx context.Co
 	})
 	if err != nil {
 		// Safe to return early here since no resources have been created yet.
-		conditions.MarkFalse(acp, controlplanev1alpha2.MachinesCreatedCondition, controlplanev1alpha2.InfrastructureTemplateCloningFailedReason,
+		conditions.MarkFalse(acp, controlplanev1beta1.MachinesCreatedCondition, controlplanev1beta1.InfrastructureTemplateCloningFailedReason,
 			clusterv1.ConditionSeverityError, "error creating infraenv: %v", err)
 		return nil, err
 	}
 	return infraRef, nil
 }
 
-func (r *OpenshiftAssistedControlPlaneReconciler) generateOpenshiftAssistedConfig(acp *controlplanev1alpha2.OpenshiftAssistedControlPlane, clusterName string, name string) *bootstrapv1alpha1.OpenshiftAssistedConfig {
-	bootstrapConfig := &bootstrapv1alpha1.OpenshiftAssistedConfig{
+func (r *OpenshiftAssistedControlPlaneReconciler) generateOpenshiftAssistedConfig(acp *controlplanev1beta1.OpenshiftAssistedControlPlane, clusterName string, name string) *bootstrapv1beta1.OpenshiftAssistedConfig {
+	bootstrapConfig := &bootstrapv1beta1.OpenshiftAssistedConfig{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        name,
 			Namespace:   acp.Namespace,
@@ -725,25 +1271,34 @@ func (r *OpenshiftAssistedControlPlaneReconciler) generateOpenshiftAssistedConfi
 
 func (r *OpenshiftAssistedControlPlaneReconciler) ensurePullSecret(
 	ctx context.Context,
-	acp *controlplanev1alpha2.OpenshiftAssistedControlPlane,
+	acp *controlplanev1beta1.OpenshiftAssistedControlPlane,
 ) error {
-	if acp.Spec.Config.PullSecretRef != nil {
+	if acp.Spec.Config.PullSecretRef != nil && acp.Spec.Config.PullSecretRef.Name != PlaceholderPullSecretName {
 		return nil
 	}
 
-	secret := auth.GenerateFakePullSecret(placeholderPullSecretName, acp.Namespace)
+	// The pull secret reference might have been defaulted to the placeholder by the admission webhook
+	secret := auth.GenerateFakePullSecret(PlaceholderPullSecretName, acp.Namespace)
 	if err := controllerutil.SetOwnerReference(acp, secret, r.Scheme); err != nil {
 		return err
 	}
 
-	if err := r.Client.Create(ctx, secret); err != nil {
+	if err := r.Client.Create(ctx, secret); err != nil && !apierrors.IsAlreadyExists(err) {
 		return err
 	}
 	acp.Spec.Config.PullSecretRef = &corev1.LocalObjectReference{Name: secret.Name}
 	return nil
 }
 
-func (r *OpenshiftAssistedControlPlaneReconciler) scaleDownControlPlane(ctx context.Context, eligibleMachines collections.Machines, failureDomain *string) (*clusterv1.Machine, error) {
+// scaleDownControlPlane deletes a machine once its etcd member is removed from the workload cluster.
+// Returns a nil machine when the scale down is blocked by the preflight checks.
+func (r *OpenshiftAssistedControlPlaneReconciler) scaleDownControlPlane(
+	ctx context.Context,
+	oacp *controlplanev1beta1.OpenshiftAssistedControlPlane,
+	cluster *clusterv1.Cluster,
+	eligibleMachines collections.Machines,
+	failureDomain *string,
+) (*clusterv1.Machine, error) {
 	machineToDelete, err := selectMachineForScaleDown(eligibleMachines, failureDomain)
 	if err != nil {
 		return nil, fmt.Errorf("failed to select machine for scale down: %v", err)
@@ -751,6 +1306,10 @@ func (r *OpenshiftAssistedControlPlaneReconciler) scaleDownControlPlane(ctx cont
 	if machineToDelete == nil {
 		return nil, errors.New("failed to select machine for scale down: no machine found")
 	}
+	allowed, err := r.preflightChecksForScaleDown(ctx, oacp, cluster, machineToDelete)
+	if err != nil || !allowed {
+		return nil, err
+	}
 	if err := r.Client.Delete(ctx, machineToDelete); err != nil && !apierrors.IsNotFound(err) {
 		return nil, err
 	}
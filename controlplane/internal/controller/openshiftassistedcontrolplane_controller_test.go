@@ -22,12 +22,15 @@ import (
 	"time"
 
 	metal3v1beta1 "github.com/metal3-io/cluster-api-provider-metal3/api/v1beta1"
+	configv1 "github.com/openshift/api/config/v1"
 	"github.com/openshift/assisted-service/api/v1beta1"
 
 	"github.com/openshift-assisted/cluster-api-agent/controlplane/internal/auth"
 	"github.com/openshift-assisted/cluster-api-agent/controlplane/internal/release"
 	"github.com/openshift-assisted/cluster-api-agent/controlplane/internal/upgrade"
 	"github.com/openshift-assisted/cluster-api-agent/controlplane/internal/version"
+	"github.com/openshift-assisted/cluster-api-agent/pkg/containers"
+	"github.com/openshift-assisted/cluster-api-agent/pkg/workloadclient"
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo/v2"
@@ -35,13 +38,18 @@ import (
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/cluster-api/util/conditions"
+	v1beta2conditions "sigs.k8s.io/cluster-api/util/conditions/v1beta2"
 	ctrlruntime "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
-	controlplanev1alpha2 "github.com/openshift-assisted/cluster-api-agent/controlplane/api/v1alpha2"
+	bootstrapv1beta1 "github.com/openshift-assisted/cluster-api-agent/bootstrap/api/v1beta1"
+	controlplanev1beta1 "github.com/openshift-assisted/cluster-api-agent/controlplane/api/v1beta1"
 	testutils "github.com/openshift-assisted/cluster-api-agent/test/utils"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -72,7 +80,7 @@ var _ = Describe("OpenshiftAssistedControlPlane Controller", func() {
 			ctrl = gomock.NewController(GinkgoT())
 			k8sClient = fakeclient.NewClientBuilder().
 				WithScheme(testScheme).
-				WithStatusSubresource(&controlplanev1alpha2.OpenshiftAssistedControlPlane{}).Build()
+				WithStatusSubresource(&controlplanev1beta1.OpenshiftAssistedControlPlane{}).Build()
 
 			mockKubernetesVersionDetector = version.NewMockKubernetesVersionDetector(ctrl)
 			mockKubernetesVersionDetector.EXPECT().GetKubernetesVersion(gomock.Any(), gomock.Any()).Return(&k8sVersion, nil).AnyTimes()
@@ -81,6 +89,7 @@ var _ = Describe("OpenshiftAssistedControlPlane Controller", func() {
 			mockUpgrader.EXPECT().IsUpgradeInProgress(gomock.Any()).Return(false, nil).AnyTimes()
 			mockUpgrader.EXPECT().GetCurrentVersion(gomock.Any()).Return("4.18.0", nil).AnyTimes()
 			mockUpgrader.EXPECT().IsDesiredVersionUpdated(gomock.Any(), gomock.Any()).Return(true, nil).AnyTimes()
+			mockUpgrader.EXPECT().GetUpgradeStatus(gomock.Any()).Return(upgrade.UpgradeStatus{}, nil).AnyTimes()
 
 			mockUpgradeFactory = upgrade.NewMockClusterUpgradeFactory(ctrl)
 			mockUpgradeFactory.EXPECT().NewUpgrader(gomock.Any()).Return(mockUpgrader, nil).AnyTimes()
@@ -178,8 +187,8 @@ var _ = Describe("OpenshiftAssistedControlPlane Controller", func() {
 					},
 				)
 				// Simulate AgentClusterInstall marking KubeconfigAvailableCondition and ControlPlaneReadyCondition
-				conditions.MarkTrue(openshiftAssistedControlPlane, controlplanev1alpha2.KubeconfigAvailableCondition)
-				conditions.MarkTrue(openshiftAssistedControlPlane, controlplanev1alpha2.ControlPlaneReadyCondition)
+				conditions.MarkTrue(openshiftAssistedControlPlane, controlplanev1beta1.KubeconfigAvailableCondition)
+				conditions.MarkTrue(openshiftAssistedControlPlane, controlplanev1beta1.ControlPlaneReadyCondition)
 
 				Expect(k8sClient.Create(ctx, openshiftAssistedControlPlane)).To(Succeed())
 
@@ -194,9 +203,9 @@ var _ = Describe("OpenshiftAssistedControlPlane Controller", func() {
 				Expect(openshiftAssistedControlPlane.Status.ClusterDeploymentRef.Name).Should(Equal(openshiftAssistedControlPlane.Name))
 				By("checking conditions ready")
 				expectedReadyConditions := []clusterv1.ConditionType{
-					controlplanev1alpha2.MachinesCreatedCondition,
-					controlplanev1alpha2.KubeconfigAvailableCondition,
-					controlplanev1alpha2.KubernetesVersionAvailableCondition,
+					controlplanev1beta1.MachinesCreatedCondition,
+					controlplanev1beta1.KubeconfigAvailableCondition,
+					controlplanev1beta1.KubernetesVersionAvailableCondition,
 				}
 				checkReadyConditions(expectedReadyConditions, openshiftAssistedControlPlane)
 
@@ -236,7 +245,7 @@ var _ = Describe("OpenshiftAssistedControlPlane Controller", func() {
 
 				By("checking that the fake pull secret was created")
 				pullSecret := &corev1.Secret{}
-				err = k8sClient.Get(ctx, types.NamespacedName{Name: placeholderPullSecretName, Namespace: namespace}, pullSecret)
+				err = k8sClient.Get(ctx, types.NamespacedName{Name: PlaceholderPullSecretName, Namespace: namespace}, pullSecret)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(pullSecret).NotTo(BeNil())
 				Expect(pullSecret.Data).NotTo(BeNil())
@@ -248,10 +257,38 @@ var _ = Describe("OpenshiftAssistedControlPlane Controller", func() {
 				Expect(err).NotTo(HaveOccurred())
 				Expect(cd).NotTo(BeNil())
 				Expect(cd.Spec.PullSecretRef).NotTo(BeNil())
-				Expect(cd.Spec.PullSecretRef.Name).To(Equal(placeholderPullSecretName))
+				Expect(cd.Spec.PullSecretRef.Name).To(Equal(PlaceholderPullSecretName))
 
 			})
 		})
+
+		When("the pull secret was defaulted to the placeholder pull secret", func() {
+			It("should create the placeholder pull secret", func() {
+				openshiftAssistedControlPlane := testutils.NewOpenshiftAssistedControlPlane(namespace, openshiftAssistedControlPlaneName)
+				openshiftAssistedControlPlane.Spec.Config.PullSecretRef = &corev1.LocalObjectReference{Name: PlaceholderPullSecretName}
+				openshiftAssistedControlPlane.SetOwnerReferences(
+					[]metav1.OwnerReference{
+						*metav1.NewControllerRef(cluster, clusterv1.GroupVersion.WithKind(clusterv1.ClusterKind)),
+					},
+				)
+				Expect(k8sClient.Create(ctx, openshiftAssistedControlPlane)).To(Succeed())
+
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: typeNamespacedName,
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				pullSecret := &corev1.Secret{}
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: PlaceholderPullSecretName, Namespace: namespace}, pullSecret)).To(Succeed())
+				Expect(pullSecret.Data).To(HaveKey(".dockerconfigjson"))
+
+				By("reconciling again once the placeholder pull secret exists")
+				_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: typeNamespacedName,
+				})
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
 		It("should add a finalizer to the OpenshiftAssistedControlPlane if it's not being deleted", func() {
 			By("setting the owner ref on the OpenshiftAssistedControlPlane")
 
@@ -272,6 +309,97 @@ var _ = Describe("OpenshiftAssistedControlPlane Controller", func() {
 			Expect(openshiftAssistedControlPlane.Finalizers).NotTo(BeEmpty())
 			Expect(openshiftAssistedControlPlane.Finalizers).To(ContainElement(acpFinalizer))
 		})
+		When("the topology Kubernetes version does not match the OpenShift release", func() {
+			It("should mark the Kubernetes version condition as mismatched", func() {
+				openshiftAssistedControlPlane := testutils.NewOpenshiftAssistedControlPlane(namespace, openshiftAssistedControlPlaneName)
+				openshiftAssistedControlPlane.Spec.Version = "v1.31.1"
+				openshiftAssistedControlPlane.SetOwnerReferences(
+					[]metav1.OwnerReference{
+						*metav1.NewControllerRef(cluster, clusterv1.GroupVersion.WithKind(clusterv1.ClusterKind)),
+					},
+				)
+				Expect(k8sClient.Create(ctx, openshiftAssistedControlPlane)).To(Succeed())
+
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(k8sClient.Get(ctx, typeNamespacedName, openshiftAssistedControlPlane)).To(Succeed())
+				condition := conditions.Get(openshiftAssistedControlPlane, controlplanev1beta1.KubernetesVersionAvailableCondition)
+				Expect(condition).NotTo(BeNil())
+				Expect(condition.Status).To(Equal(corev1.ConditionFalse))
+				Expect(condition.Reason).To(Equal(controlplanev1beta1.KubernetesVersionMismatchReason))
+			})
+
+			It("should not report a mismatch when major and minor versions match", func() {
+				openshiftAssistedControlPlane := testutils.NewOpenshiftAssistedControlPlane(namespace, openshiftAssistedControlPlaneName)
+				openshiftAssistedControlPlane.Spec.Version = "v1.30.4"
+				openshiftAssistedControlPlane.SetOwnerReferences(
+					[]metav1.OwnerReference{
+						*metav1.NewControllerRef(cluster, clusterv1.GroupVersion.WithKind(clusterv1.ClusterKind)),
+					},
+				)
+				Expect(k8sClient.Create(ctx, openshiftAssistedControlPlane)).To(Succeed())
+
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(k8sClient.Get(ctx, typeNamespacedName, openshiftAssistedControlPlane)).To(Succeed())
+				Expect(conditions.IsTrue(openshiftAssistedControlPlane, controlplanev1beta1.KubernetesVersionAvailableCondition)).To(BeTrue())
+			})
+		})
+		When("the infrastructure provider does not set the control plane endpoint", func() {
+			BeforeEach(func() {
+				cluster.Spec.ControlPlaneEndpoint = clusterv1.APIEndpoint{}
+				Expect(k8sClient.Update(ctx, cluster)).To(Succeed())
+			})
+
+			DescribeTable("should publish the control plane endpoint",
+				func(apiVIPs []string, clusterNameOverride string, expected clusterv1.APIEndpoint) {
+					openshiftAssistedControlPlane := testutils.NewOpenshiftAssistedControlPlane(namespace, openshiftAssistedControlPlaneName)
+					openshiftAssistedControlPlane.Spec.Config.BaseDomain = "example.com"
+					openshiftAssistedControlPlane.Spec.Config.ClusterName = clusterNameOverride
+					openshiftAssistedControlPlane.Spec.Config.APIVIPs = apiVIPs
+					openshiftAssistedControlPlane.SetOwnerReferences(
+						[]metav1.OwnerReference{
+							*metav1.NewControllerRef(cluster, clusterv1.GroupVersion.WithKind(clusterv1.ClusterKind)),
+						},
+					)
+					Expect(k8sClient.Create(ctx, openshiftAssistedControlPlane)).To(Succeed())
+
+					result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(result.RequeueAfter).To(Equal(20 * time.Second))
+
+					Expect(k8sClient.Get(ctx, typeNamespacedName, openshiftAssistedControlPlane)).To(Succeed())
+					Expect(openshiftAssistedControlPlane.Spec.ControlPlaneEndpoint).To(Equal(expected))
+				},
+				Entry("from the first API VIP", []string{"192.168.111.5", "fd2e:6f44:5dd8::5"}, "",
+					clusterv1.APIEndpoint{Host: "192.168.111.5", Port: 6443}),
+				Entry("from the cluster name and base domain with user managed networking", nil, "",
+					clusterv1.APIEndpoint{Host: "api.test-cluster.example.com", Port: 6443}),
+				Entry("from the configured cluster name", nil, "my-cluster",
+					clusterv1.APIEndpoint{Host: "api.my-cluster.example.com", Port: 6443}),
+			)
+
+			It("should not override a control plane endpoint set by the user", func() {
+				openshiftAssistedControlPlane := testutils.NewOpenshiftAssistedControlPlane(namespace, openshiftAssistedControlPlaneName)
+				openshiftAssistedControlPlane.Spec.Config.BaseDomain = "example.com"
+				openshiftAssistedControlPlane.Spec.Config.APIVIPs = []string{"192.168.111.5"}
+				openshiftAssistedControlPlane.Spec.ControlPlaneEndpoint = clusterv1.APIEndpoint{Host: "api.example.org", Port: 443}
+				openshiftAssistedControlPlane.SetOwnerReferences(
+					[]metav1.OwnerReference{
+						*metav1.NewControllerRef(cluster, clusterv1.GroupVersion.WithKind(clusterv1.ClusterKind)),
+					},
+				)
+				Expect(k8sClient.Create(ctx, openshiftAssistedControlPlane)).To(Succeed())
+
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(k8sClient.Get(ctx, typeNamespacedName, openshiftAssistedControlPlane)).To(Succeed())
+				Expect(openshiftAssistedControlPlane.Spec.ControlPlaneEndpoint).To(Equal(clusterv1.APIEndpoint{Host: "api.example.org", Port: 443}))
+			})
+		})
 		When("an invalid version is set on the OpenshiftAssistedControlPlane", func() {
 			It("should return error", func() {
 				By("setting the cluster as the owner ref on the OpenshiftAssistedControlPlane")
@@ -292,7 +420,7 @@ var _ = Describe("OpenshiftAssistedControlPlane Controller", func() {
 				Expect(k8sClient.Get(ctx, typeNamespacedName, openshiftAssistedControlPlane)).To(Succeed())
 
 				condition := conditions.Get(openshiftAssistedControlPlane,
-					controlplanev1alpha2.MachinesCreatedCondition,
+					controlplanev1beta1.MachinesCreatedCondition,
 				)
 				Expect(condition).NotTo(BeNil())
 				Expect(condition.Message).To(Equal("version 4.12.0 is not supported, the minimum supported version is 4.14.0"))
@@ -322,7 +450,7 @@ var _ = Describe("Upgrade scenarios", func() {
 		mockKubernetesVersionDetector *version.MockKubernetesVersionDetector
 		mockUpgradeFactory            *upgrade.MockClusterUpgradeFactory
 		mockUpgrader                  *upgrade.MockClusterUpgrade
-		openshiftAssistedControlPlane *controlplanev1alpha2.OpenshiftAssistedControlPlane
+		openshiftAssistedControlPlane *controlplanev1beta1.OpenshiftAssistedControlPlane
 	)
 
 	BeforeEach(func() {
@@ -330,7 +458,7 @@ var _ = Describe("Upgrade scenarios", func() {
 		ctrl = gomock.NewController(GinkgoT())
 		k8sClient = fakeclient.NewClientBuilder().
 			WithScheme(testScheme).
-			WithStatusSubresource(&controlplanev1alpha2.OpenshiftAssistedControlPlane{}).Build()
+			WithStatusSubresource(&controlplanev1beta1.OpenshiftAssistedControlPlane{}).Build()
 
 		mockKubernetesVersionDetector = version.NewMockKubernetesVersionDetector(ctrl)
 		mockKubernetesVersionDetector.EXPECT().GetKubernetesVersion(gomock.Any(), gomock.Any()).Return(&k8sVersion, nil).AnyTimes()
@@ -371,8 +499,8 @@ var _ = Describe("Upgrade scenarios", func() {
 			*metav1.NewControllerRef(cluster, clusterv1.GroupVersion.WithKind(clusterv1.ClusterKind)),
 		})
 		openshiftAssistedControlPlane.Spec.DistributionVersion = desiredVersion
-		conditions.MarkTrue(openshiftAssistedControlPlane, controlplanev1alpha2.KubeconfigAvailableCondition)
-		conditions.MarkTrue(openshiftAssistedControlPlane, controlplanev1alpha2.ControlPlaneReadyCondition)
+		conditions.MarkTrue(openshiftAssistedControlPlane, controlplanev1beta1.KubeconfigAvailableCondition)
+		conditions.MarkTrue(openshiftAssistedControlPlane, controlplanev1beta1.ControlPlaneReadyCondition)
 		Expect(k8sClient.Create(ctx, openshiftAssistedControlPlane)).To(Succeed())
 	})
 
@@ -385,6 +513,7 @@ var _ = Describe("Upgrade scenarios", func() {
 		mockUpgrader.EXPECT().IsUpgradeInProgress(gomock.Any()).Return(false, nil)
 		mockUpgrader.EXPECT().GetCurrentVersion(gomock.Any()).Return(currentVersion, nil)
 		mockUpgrader.EXPECT().IsDesiredVersionUpdated(gomock.Any(), desiredVersion).Return(false, nil)
+		mockUpgrader.EXPECT().GetUpgradeStatus(gomock.Any()).Return(upgrade.UpgradeStatus{}, nil)
 		mockUpgrader.EXPECT().UpdateClusterVersionDesiredUpdate(gomock.Any(), desiredVersion, gomock.Any(), gomock.Any()).Return(nil)
 
 		result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
@@ -404,7 +533,8 @@ var _ = Describe("Upgrade scenarios", func() {
 		mockUpgrader.EXPECT().IsUpgradeInProgress(gomock.Any()).Return(true, nil)
 		mockUpgrader.EXPECT().GetCurrentVersion(gomock.Any()).Return(currentVersion, nil)
 		mockUpgrader.EXPECT().IsDesiredVersionUpdated(gomock.Any(), desiredVersion).Return(true, nil)
-		conditions.MarkFalse(openshiftAssistedControlPlane, controlplanev1alpha2.UpgradeCompletedCondition, controlplanev1alpha2.UpgradeInProgressReason, clusterv1.ConditionSeverityInfo, "upgrade in progress")
+		mockUpgrader.EXPECT().GetUpgradeStatus(gomock.Any()).Return(upgrade.UpgradeStatus{}, nil)
+		conditions.MarkFalse(openshiftAssistedControlPlane, controlplanev1beta1.UpgradeCompletedCondition, controlplanev1beta1.UpgradeInProgressReason, clusterv1.ConditionSeverityInfo, "upgrade in progress")
 
 		result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
 		Expect(err).NotTo(HaveOccurred())
@@ -412,11 +542,11 @@ var _ = Describe("Upgrade scenarios", func() {
 
 		err = k8sClient.Get(ctx, typeNamespacedName, openshiftAssistedControlPlane)
 		Expect(err).NotTo(HaveOccurred())
-		condition := conditions.Get(openshiftAssistedControlPlane, controlplanev1alpha2.UpgradeCompletedCondition)
+		condition := conditions.Get(openshiftAssistedControlPlane, controlplanev1beta1.UpgradeCompletedCondition)
 		// we expect the upgrade to be in progress
 		Expect(condition).NotTo(BeNil())
 		Expect(condition.Status).To(Equal(corev1.ConditionFalse))
-		Expect(condition.Reason).To(Equal(controlplanev1alpha2.UpgradeInProgressReason))
+		Expect(condition.Reason).To(Equal(controlplanev1beta1.UpgradeInProgressReason))
 	})
 
 	It("should handle completed upgrade", func() {
@@ -428,8 +558,8 @@ var _ = Describe("Upgrade scenarios", func() {
 		// Make sure upgrade is in progress: once we reconcile it should be marked as complete
 		conditions.MarkFalse(
 			openshiftAssistedControlPlane,
-			controlplanev1alpha2.UpgradeCompletedCondition,
-			controlplanev1alpha2.UpgradeInProgressReason,
+			controlplanev1beta1.UpgradeCompletedCondition,
+			controlplanev1beta1.UpgradeInProgressReason,
 			clusterv1.ConditionSeverityWarning,
 			"upgrade in progress",
 		)
@@ -443,7 +573,7 @@ var _ = Describe("Upgrade scenarios", func() {
 		Expect(err).NotTo(HaveOccurred())
 		Expect(openshiftAssistedControlPlane.Status.DistributionVersion).To(Equal(desiredVersion))
 
-		condition := conditions.Get(openshiftAssistedControlPlane, controlplanev1alpha2.UpgradeCompletedCondition)
+		condition := conditions.Get(openshiftAssistedControlPlane, controlplanev1beta1.UpgradeCompletedCondition)
 		Expect(condition).NotTo(BeNil())
 		Expect(condition.Status).To(Equal(corev1.ConditionTrue))
 	})
@@ -454,13 +584,14 @@ var _ = Describe("Upgrade scenarios", func() {
 		mockUpgrader.EXPECT().IsUpgradeInProgress(gomock.Any()).Return(false, nil)
 		mockUpgrader.EXPECT().GetCurrentVersion(gomock.Any()).Return(currentVersion, nil)
 		mockUpgrader.EXPECT().IsDesiredVersionUpdated(gomock.Any(), desiredVersion).Return(false, nil)
+		mockUpgrader.EXPECT().GetUpgradeStatus(gomock.Any()).Return(upgrade.UpgradeStatus{}, nil)
 		mockUpgrader.EXPECT().UpdateClusterVersionDesiredUpdate(gomock.Any(), desiredVersion, gomock.Any(), gomock.Any()).Return(expectedError)
 
 		// Make sure upgrade is in progress(not completed): even if we get no current version, now the upgrade is over
 		conditions.MarkFalse(
 			openshiftAssistedControlPlane,
-			controlplanev1alpha2.UpgradeCompletedCondition,
-			controlplanev1alpha2.UpgradeInProgressReason,
+			controlplanev1beta1.UpgradeCompletedCondition,
+			controlplanev1beta1.UpgradeInProgressReason,
 			clusterv1.ConditionSeverityWarning,
 			"upgrade in progress",
 		)
@@ -470,7 +601,7 @@ var _ = Describe("Upgrade scenarios", func() {
 		Expect(err).To(Equal(expectedError))
 
 		// Upgrade is in progres: spec.distributionVersion is 4.15 and status.distributionVersion is 4.14
-		condition := conditions.Get(openshiftAssistedControlPlane, controlplanev1alpha2.UpgradeCompletedCondition)
+		condition := conditions.Get(openshiftAssistedControlPlane, controlplanev1beta1.UpgradeCompletedCondition)
 		Expect(condition).NotTo(BeNil())
 		Expect(condition.Status).To(Equal(corev1.ConditionFalse))
 	})
@@ -481,6 +612,7 @@ var _ = Describe("Upgrade scenarios", func() {
 		mockUpgrader.EXPECT().IsUpgradeInProgress(gomock.Any()).Return(false, nil)
 		mockUpgrader.EXPECT().GetCurrentVersion(gomock.Any()).Return("", expectedError)
 		mockUpgrader.EXPECT().IsDesiredVersionUpdated(gomock.Any(), desiredVersion).Return(true, nil)
+		mockUpgrader.EXPECT().GetUpgradeStatus(gomock.Any()).Return(upgrade.UpgradeStatus{}, nil)
 		mockUpgrader.EXPECT().UpdateClusterVersionDesiredUpdate(ctx, desiredVersion, gomock.Any(), gomock.Any()).Return(nil)
 
 		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
@@ -491,10 +623,10 @@ var _ = Describe("Upgrade scenarios", func() {
 		Expect(openshiftAssistedControlPlane.Status.DistributionVersion).To(Equal(""))
 
 		// upgrade should start now
-		condition := conditions.Get(openshiftAssistedControlPlane, controlplanev1alpha2.UpgradeCompletedCondition)
+		condition := conditions.Get(openshiftAssistedControlPlane, controlplanev1beta1.UpgradeCompletedCondition)
 		Expect(condition).NotTo(BeNil())
 		Expect(condition.Status).To(Equal(corev1.ConditionFalse))
-		Expect(condition.Reason).To(Equal(controlplanev1alpha2.UpgradeInProgressReason))
+		Expect(condition.Reason).To(Equal(controlplanev1beta1.UpgradeInProgressReason))
 		Expect(condition.Message).To(Equal("upgrade to version 4.15.0 in progress"))
 	})
 
@@ -509,6 +641,7 @@ var _ = Describe("Upgrade scenarios", func() {
 		mockUpgrader.EXPECT().IsUpgradeInProgress(gomock.Any()).Return(false, nil)
 		mockUpgrader.EXPECT().GetCurrentVersion(gomock.Any()).Return(currentVersion, nil)
 		mockUpgrader.EXPECT().IsDesiredVersionUpdated(gomock.Any(), desiredVersion).Return(false, nil)
+		mockUpgrader.EXPECT().GetUpgradeStatus(gomock.Any()).Return(upgrade.UpgradeStatus{}, nil)
 		expectedParams := []upgrade.ClusterUpgradeOption{
 			{Name: upgrade.ReleaseImagePullSecretOption, Value: "{\"auths\":{\"fake-pull-secret\":{\"auth\":\"cGxhY2Vob2xkZXI6c2VjcmV0Cg==\"}}}"},
 			{Name: upgrade.ReleaseImageRepositoryOverrideOption, Value: repoOverride},
@@ -525,10 +658,471 @@ var _ = Describe("Upgrade scenarios", func() {
 
 		err = k8sClient.Get(ctx, typeNamespacedName, openshiftAssistedControlPlane)
 		Expect(err).NotTo(HaveOccurred())
-		condition := conditions.Get(openshiftAssistedControlPlane, controlplanev1alpha2.UpgradeCompletedCondition)
+		condition := conditions.Get(openshiftAssistedControlPlane, controlplanev1beta1.UpgradeCompletedCondition)
 		Expect(condition).NotTo(BeNil())
 		Expect(condition.Status).To(Equal(corev1.ConditionFalse))
 	})
+
+	Context("upgrade issues", func() {
+		BeforeEach(func() {
+			mockUpgradeFactory.EXPECT().NewUpgrader(gomock.Any()).Return(mockUpgrader, nil)
+			mockUpgrader.EXPECT().IsUpgradeInProgress(gomock.Any()).Return(true, nil)
+			mockUpgrader.EXPECT().GetCurrentVersion(gomock.Any()).Return(currentVersion, nil)
+			mockUpgrader.EXPECT().IsDesiredVersionUpdated(gomock.Any(), desiredVersion).Return(true, nil)
+		})
+
+		It("should report the most severe upgrade issue", func() {
+			mockUpgrader.EXPECT().GetUpgradeStatus(gomock.Any()).Return(upgrade.UpgradeStatus{
+				Issues: []upgrade.UpgradeIssue{
+					{Reason: upgrade.UpgradeFailing, Message: "Cluster operator etcd is degraded"},
+					{Reason: upgrade.UpgradeClusterOperatorsDegraded, Message: "cluster operators degraded or unavailable: etcd"},
+				},
+			}, nil)
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, openshiftAssistedControlPlane)).To(Succeed())
+			condition := conditions.Get(openshiftAssistedControlPlane, controlplanev1beta1.UpgradeCompletedCondition)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(corev1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(controlplanev1beta1.UpgradeFailingReason))
+			Expect(condition.Message).To(ContainSubstring("Cluster operator etcd is degraded"))
+			Expect(openshiftAssistedControlPlane.Status.FailureReason).To(BeNil())
+		})
+
+		It("should report a failure when the upgrade does not complete within the upgrade timeout", func() {
+			controllerReconciler.UpgradeTimeout = time.Hour
+			startedTime := metav1.NewTime(time.Now().Add(-2 * time.Hour))
+			mockUpgrader.EXPECT().GetUpgradeStatus(gomock.Any()).Return(upgrade.UpgradeStatus{
+				StartedTime: &startedTime,
+				Issues: []upgrade.UpgradeIssue{
+					{Reason: upgrade.UpgradeClusterOperatorsDegraded, Message: "cluster operators degraded or unavailable: ingress"},
+				},
+			}, nil)
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, openshiftAssistedControlPlane)).To(Succeed())
+			condition := conditions.Get(openshiftAssistedControlPlane, controlplanev1beta1.UpgradeCompletedCondition)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Reason).To(Equal(controlplanev1beta1.UpgradeClusterOperatorsDegradedReason))
+			Expect(openshiftAssistedControlPlane.Status.FailureReason).To(HaveValue(Equal(controlplanev1beta1.UpgradeTimedOutReason)))
+			Expect(openshiftAssistedControlPlane.Status.FailureMessage).To(HaveValue(ContainSubstring("ingress")))
+		})
+
+		It("should not report a failure before the upgrade timeout", func() {
+			controllerReconciler.UpgradeTimeout = time.Hour
+			startedTime := metav1.NewTime(time.Now().Add(-10 * time.Minute))
+			mockUpgrader.EXPECT().GetUpgradeStatus(gomock.Any()).Return(upgrade.UpgradeStatus{StartedTime: &startedTime}, nil)
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, openshiftAssistedControlPlane)).To(Succeed())
+			Expect(openshiftAssistedControlPlane.Status.FailureReason).To(BeNil())
+			Expect(conditions.GetReason(openshiftAssistedControlPlane, controlplanev1beta1.UpgradeCompletedCondition)).
+				To(Equal(controlplanev1beta1.UpgradeInProgressReason))
+		})
+	})
+
+	It("should clear the upgrade failure once the upgrade completes", func() {
+		mockUpgradeFactory.EXPECT().NewUpgrader(gomock.Any()).Return(mockUpgrader, nil)
+		mockUpgrader.EXPECT().IsUpgradeInProgress(gomock.Any()).Return(false, nil)
+		mockUpgrader.EXPECT().GetCurrentVersion(gomock.Any()).Return(desiredVersion, nil)
+		mockUpgrader.EXPECT().IsDesiredVersionUpdated(gomock.Any(), desiredVersion).Return(true, nil)
+		failureReason := controlplanev1beta1.UpgradeTimedOutReason
+		failureMessage := "upgrade to version 4.15.0 did not complete within 1h0m0s"
+		openshiftAssistedControlPlane.Status.FailureReason = &failureReason
+		openshiftAssistedControlPlane.Status.FailureMessage = &failureMessage
+		conditions.MarkFalse(
+			openshiftAssistedControlPlane,
+			controlplanev1beta1.UpgradeCompletedCondition,
+			controlplanev1beta1.UpgradeClusterOperatorsDegradedReason,
+			clusterv1.ConditionSeverityWarning,
+			"upgrade blocked",
+		)
+		Expect(k8sClient.Status().Update(ctx, openshiftAssistedControlPlane)).To(Succeed())
+
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(k8sClient.Get(ctx, typeNamespacedName, openshiftAssistedControlPlane)).To(Succeed())
+		Expect(conditions.IsTrue(openshiftAssistedControlPlane, controlplanev1beta1.UpgradeCompletedCondition)).To(BeTrue())
+		Expect(openshiftAssistedControlPlane.Status.FailureReason).To(BeNil())
+		Expect(openshiftAssistedControlPlane.Status.FailureMessage).To(BeNil())
+	})
+
+	It("should record the upgrade in the upgrade history", func() {
+		mockUpgradeFactory.EXPECT().NewUpgrader(gomock.Any()).Return(mockUpgrader, nil).Times(2)
+		mockUpgrader.EXPECT().IsUpgradeInProgress(gomock.Any()).Return(false, nil)
+		mockUpgrader.EXPECT().GetCurrentVersion(gomock.Any()).Return(currentVersion, nil)
+		mockUpgrader.EXPECT().IsDesiredVersionUpdated(gomock.Any(), desiredVersion).Return(false, nil)
+		mockUpgrader.EXPECT().GetUpgradeStatus(gomock.Any()).Return(upgrade.UpgradeStatus{}, nil)
+		mockUpgrader.EXPECT().UpdateClusterVersionDesiredUpdate(gomock.Any(), desiredVersion, gomock.Any(), gomock.Any()).Return(nil)
+		openshiftAssistedControlPlane.Annotations = map[string]string{controlplanev1beta1.UpgradeRequestedByAnnotation: "jane"}
+		Expect(k8sClient.Update(ctx, openshiftAssistedControlPlane)).To(Succeed())
+
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(k8sClient.Get(ctx, typeNamespacedName, openshiftAssistedControlPlane)).To(Succeed())
+		Expect(openshiftAssistedControlPlane.Status.UpgradeHistory).To(HaveLen(1))
+		Expect(openshiftAssistedControlPlane.Status.UpgradeHistory[0].Version).To(Equal(desiredVersion))
+		Expect(openshiftAssistedControlPlane.Status.UpgradeHistory[0].State).To(Equal(controlplanev1beta1.UpgradeStatePartial))
+		Expect(openshiftAssistedControlPlane.Status.UpgradeHistory[0].RequestedBy).To(Equal("jane"))
+
+		// the upgrade completes on the workload cluster
+		image := "quay.io/openshift-release-dev/ocp-release@sha256:0123"
+		completionTime := metav1.Now()
+		mockUpgrader.EXPECT().IsUpgradeInProgress(gomock.Any()).Return(false, nil)
+		mockUpgrader.EXPECT().GetCurrentVersion(gomock.Any()).Return(desiredVersion, nil)
+		mockUpgrader.EXPECT().IsDesiredVersionUpdated(gomock.Any(), desiredVersion).Return(true, nil)
+		mockUpgrader.EXPECT().GetUpdateHistory(gomock.Any()).Return([]configv1.UpdateHistory{
+			{State: configv1.CompletedUpdate, Version: desiredVersion, Image: image, CompletionTime: &completionTime},
+		}, nil)
+
+		_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(k8sClient.Get(ctx, typeNamespacedName, openshiftAssistedControlPlane)).To(Succeed())
+		Expect(openshiftAssistedControlPlane.Status.UpgradeHistory).To(HaveLen(1))
+		Expect(openshiftAssistedControlPlane.Status.UpgradeHistory[0].State).To(Equal(controlplanev1beta1.UpgradeStateCompleted))
+		Expect(openshiftAssistedControlPlane.Status.UpgradeHistory[0].Image).To(Equal(image))
+		Expect(openshiftAssistedControlPlane.Status.UpgradeHistory[0].CompletionTime).NotTo(BeNil())
+	})
+
+	It("should report an upgrade unavailable when the release signature is not valid", func() {
+		mockUpgradeFactory.EXPECT().NewUpgrader(gomock.Any()).Return(mockUpgrader, nil)
+		mockUpgrader.EXPECT().IsUpgradeInProgress(gomock.Any()).Return(false, nil)
+		mockUpgrader.EXPECT().GetCurrentVersion(gomock.Any()).Return(currentVersion, nil)
+		mockUpgrader.EXPECT().IsDesiredVersionUpdated(gomock.Any(), desiredVersion).Return(false, nil)
+		mockUpgrader.EXPECT().GetUpgradeStatus(gomock.Any()).Return(upgrade.UpgradeStatus{}, nil)
+		mockUpgrader.EXPECT().UpdateClusterVersionDesiredUpdate(gomock.Any(), desiredVersion, gomock.Any(), gomock.Any()).
+			Return(fmt.Errorf("%w: no valid signature", upgrade.ErrReleaseSignatureVerification))
+
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(k8sClient.Get(ctx, typeNamespacedName, openshiftAssistedControlPlane)).To(Succeed())
+		condition := conditions.Get(openshiftAssistedControlPlane, controlplanev1beta1.UpgradeAvailableCondition)
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Status).To(Equal(corev1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(controlplanev1beta1.UpgradeSignatureVerificationFailedReason))
+		Expect(condition.Message).To(ContainSubstring("no valid signature"))
+		Expect(openshiftAssistedControlPlane.Status.UpgradeHistory).To(BeEmpty())
+	})
+
+	It("should upgrade to a GA version by version without a release verifier and repository override", func() {
+		workloadClient := fakeclient.NewClientBuilder().WithScheme(testScheme).WithObjects(&configv1.ClusterVersion{
+			ObjectMeta: metav1.ObjectMeta{Name: upgrade.ClusterVersionName},
+			Status: configv1.ClusterVersionStatus{
+				Desired: configv1.Release{Version: currentVersion},
+				History: []configv1.UpdateHistory{{State: configv1.CompletedUpdate, Version: currentVersion}},
+			},
+		}).Build()
+		mockClientGenerator := workloadclient.NewMockClientGenerator(ctrl)
+		mockClientGenerator.EXPECT().GetWorkloadClusterClient([]byte("fake-kubeconfig")).Return(workloadClient, nil).AnyTimes()
+		controllerReconciler.WorkloadClientGenerator = mockClientGenerator
+		controllerReconciler.UpgradeFactory = upgrade.NewOpenshiftUpgradeFactory(containers.NewMockRemoteImage(ctrl), mockClientGenerator, nil, false, nil)
+
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+
+		clusterVersion := &configv1.ClusterVersion{}
+		Expect(workloadClient.Get(ctx, client.ObjectKey{Name: upgrade.ClusterVersionName}, clusterVersion)).To(Succeed())
+		Expect(clusterVersion.Spec.DesiredUpdate).NotTo(BeNil())
+		Expect(clusterVersion.Spec.DesiredUpdate.Version).To(Equal(desiredVersion))
+		Expect(clusterVersion.Spec.DesiredUpdate.Image).To(BeEmpty())
+	})
+
+	It("should report an upgrade unavailable when release verification is required and no release verifier is configured", func() {
+		const rcVersion = desiredVersion + "-rc.1"
+		openshiftAssistedControlPlane.Spec.DistributionVersion = rcVersion
+		Expect(k8sClient.Update(ctx, openshiftAssistedControlPlane)).To(Succeed())
+
+		workloadClient := fakeclient.NewClientBuilder().WithScheme(testScheme).WithObjects(&configv1.ClusterVersion{
+			ObjectMeta: metav1.ObjectMeta{Name: upgrade.ClusterVersionName},
+			Status: configv1.ClusterVersionStatus{
+				Desired: configv1.Release{Version: currentVersion},
+				History: []configv1.UpdateHistory{{State: configv1.CompletedUpdate, Version: currentVersion}},
+			},
+		}).Build()
+		mockClientGenerator := workloadclient.NewMockClientGenerator(ctrl)
+		mockClientGenerator.EXPECT().GetWorkloadClusterClient([]byte("fake-kubeconfig")).Return(workloadClient, nil).AnyTimes()
+		mockRemoteImage := containers.NewMockRemoteImage(ctrl)
+		mockRemoteImage.EXPECT().GetDigest(gomock.Any(), gomock.Any()).Return("sha256:123456", nil)
+		controllerReconciler.WorkloadClientGenerator = mockClientGenerator
+		controllerReconciler.UpgradeFactory = upgrade.NewOpenshiftUpgradeFactory(mockRemoteImage, mockClientGenerator, nil, true, nil)
+
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(k8sClient.Get(ctx, typeNamespacedName, openshiftAssistedControlPlane)).To(Succeed())
+		condition := conditions.Get(openshiftAssistedControlPlane, controlplanev1beta1.UpgradeAvailableCondition)
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Status).To(Equal(corev1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(controlplanev1beta1.UpgradeSignatureVerificationFailedReason))
+		Expect(condition.Message).To(ContainSubstring("no trusted release signature keys configured"))
+		Expect(openshiftAssistedControlPlane.Status.UpgradeHistory).To(BeEmpty())
+
+		clusterVersion := &configv1.ClusterVersion{}
+		Expect(workloadClient.Get(ctx, client.ObjectKey{Name: upgrade.ClusterVersionName}, clusterVersion)).To(Succeed())
+		Expect(clusterVersion.Spec.DesiredUpdate).To(BeNil())
+	})
+
+	It("should push the release signatures and report missing signatures with the Push release signatures mode", func() {
+		openshiftAssistedControlPlane.Spec.UpgradeStrategy = &controlplanev1beta1.UpgradeStrategy{
+			ReleaseSignatures: controlplanev1beta1.PushReleaseSignaturesMode,
+		}
+		Expect(k8sClient.Update(ctx, openshiftAssistedControlPlane)).To(Succeed())
+		mockUpgradeFactory.EXPECT().NewUpgrader(gomock.Any()).Return(mockUpgrader, nil)
+		mockUpgrader.EXPECT().IsUpgradeInProgress(gomock.Any()).Return(false, nil)
+		mockUpgrader.EXPECT().GetCurrentVersion(gomock.Any()).Return(currentVersion, nil)
+		mockUpgrader.EXPECT().IsDesiredVersionUpdated(gomock.Any(), desiredVersion).Return(false, nil)
+		mockUpgrader.EXPECT().GetUpgradeStatus(gomock.Any()).Return(upgrade.UpgradeStatus{}, nil)
+		mockUpgrader.EXPECT().UpdateClusterVersionDesiredUpdate(gomock.Any(), desiredVersion, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, _ string, options ...upgrade.ClusterUpgradeOption) error {
+				Expect(options).To(ContainElement(upgrade.ClusterUpgradeOption{Name: upgrade.PushReleaseSignaturesOption, Value: "true"}))
+				return fmt.Errorf("%w for release image", upgrade.ErrReleaseSignatureNotFound)
+			})
+
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(k8sClient.Get(ctx, typeNamespacedName, openshiftAssistedControlPlane)).To(Succeed())
+		condition := conditions.Get(openshiftAssistedControlPlane, controlplanev1beta1.UpgradeAvailableCondition)
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Status).To(Equal(corev1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(controlplanev1beta1.UpgradeSignatureNotFoundReason))
+		Expect(openshiftAssistedControlPlane.Status.UpgradeHistory).To(BeEmpty())
+	})
+
+	It("should not start an upgrade held by the hold annotation", func() {
+		mockUpgradeFactory.EXPECT().NewUpgrader(gomock.Any()).Return(mockUpgrader, nil)
+		mockUpgrader.EXPECT().IsUpgradeInProgress(gomock.Any()).Return(false, nil)
+		mockUpgrader.EXPECT().GetCurrentVersion(gomock.Any()).Return(currentVersion, nil)
+		mockUpgrader.EXPECT().IsDesiredVersionUpdated(gomock.Any(), desiredVersion).Return(false, nil)
+		mockUpgrader.EXPECT().GetUpgradeStatus(gomock.Any()).Return(upgrade.UpgradeStatus{}, nil)
+		openshiftAssistedControlPlane.Annotations = map[string]string{controlplanev1beta1.HoldUpgradeAnnotation: ""}
+		Expect(k8sClient.Update(ctx, openshiftAssistedControlPlane)).To(Succeed())
+
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(k8sClient.Get(ctx, typeNamespacedName, openshiftAssistedControlPlane)).To(Succeed())
+		condition := conditions.Get(openshiftAssistedControlPlane, controlplanev1beta1.UpgradeCompletedCondition)
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Status).To(Equal(corev1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(controlplanev1beta1.UpgradePendingReason))
+	})
+
+	Context("upgrade health gates", func() {
+		var (
+			mockClientGenerator *workloadclient.MockClientGenerator
+			passingGate         *upgrade.MockHealthGate
+			failingGate         *upgrade.MockHealthGate
+		)
+
+		BeforeEach(func() {
+			mockUpgradeFactory.EXPECT().NewUpgrader(gomock.Any()).Return(mockUpgrader, nil)
+			mockUpgrader.EXPECT().IsUpgradeInProgress(gomock.Any()).Return(false, nil)
+			mockUpgrader.EXPECT().GetCurrentVersion(gomock.Any()).Return(currentVersion, nil)
+			mockUpgrader.EXPECT().IsDesiredVersionUpdated(gomock.Any(), desiredVersion).Return(false, nil)
+			mockUpgrader.EXPECT().GetUpgradeStatus(gomock.Any()).Return(upgrade.UpgradeStatus{}, nil)
+
+			mockClientGenerator = workloadclient.NewMockClientGenerator(ctrl)
+			mockClientGenerator.EXPECT().GetWorkloadClusterClient([]byte("fake-kubeconfig")).
+				Return(fakeclient.NewClientBuilder().WithScheme(testScheme).Build(), nil)
+			passingGate = upgrade.NewMockHealthGate(ctrl)
+			passingGate.EXPECT().Name().Return(upgrade.NodesHealthGateName).AnyTimes()
+			failingGate = upgrade.NewMockHealthGate(ctrl)
+			failingGate.EXPECT().Name().Return(upgrade.EtcdHealthGateName).AnyTimes()
+
+			controllerReconciler.WorkloadClientGenerator = mockClientGenerator
+			controllerReconciler.UpgradeHealthGates = []upgrade.HealthGate{passingGate, failingGate}
+		})
+
+		It("should not start an upgrade when a health gate fails", func() {
+			passingGate.EXPECT().Check(gomock.Any(), gomock.Any()).Return("", nil)
+			failingGate.EXPECT().Check(gomock.Any(), gomock.Any()).Return("etcd members not healthy", nil)
+
+			result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(time.Minute))
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, openshiftAssistedControlPlane)).To(Succeed())
+			condition := conditions.Get(openshiftAssistedControlPlane, controlplanev1beta1.UpgradeCompletedCondition)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(corev1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(controlplanev1beta1.UpgradeHealthGatesFailedReason))
+			Expect(condition.Message).To(Equal("upgrade to version 4.15.0 blocked by health gates: Etcd: etcd members not healthy"))
+		})
+
+		It("should start an upgrade when the failing health gate is skipped", func() {
+			openshiftAssistedControlPlane.Annotations = map[string]string{
+				controlplanev1beta1.SkipUpgradeHealthGatesAnnotation: "MachineConfigPools, Etcd",
+			}
+			Expect(k8sClient.Update(ctx, openshiftAssistedControlPlane)).To(Succeed())
+			passingGate.EXPECT().Check(gomock.Any(), gomock.Any()).Return("", nil)
+			mockUpgrader.EXPECT().UpdateClusterVersionDesiredUpdate(gomock.Any(), desiredVersion, gomock.Any(), gomock.Any()).Return(nil)
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, openshiftAssistedControlPlane)).To(Succeed())
+			Expect(conditions.GetReason(openshiftAssistedControlPlane, controlplanev1beta1.UpgradeCompletedCondition)).
+				To(Equal(controlplanev1beta1.UpgradeInProgressReason))
+		})
+	})
+
+	It("should not check the health gates once the desired version is set on the workload cluster", func() {
+		failingGate := upgrade.NewMockHealthGate(ctrl)
+		failingGate.EXPECT().Name().Return(upgrade.EtcdHealthGateName).AnyTimes()
+		controllerReconciler.UpgradeHealthGates = []upgrade.HealthGate{failingGate}
+		mockUpgradeFactory.EXPECT().NewUpgrader(gomock.Any()).Return(mockUpgrader, nil)
+		mockUpgrader.EXPECT().IsUpgradeInProgress(gomock.Any()).Return(false, nil)
+		mockUpgrader.EXPECT().GetCurrentVersion(gomock.Any()).Return(currentVersion, nil)
+		mockUpgrader.EXPECT().IsDesiredVersionUpdated(gomock.Any(), desiredVersion).Return(true, nil)
+		mockUpgrader.EXPECT().GetUpgradeStatus(gomock.Any()).Return(upgrade.UpgradeStatus{}, nil)
+		mockUpgrader.EXPECT().UpdateClusterVersionDesiredUpdate(gomock.Any(), desiredVersion, gomock.Any(), gomock.Any()).Return(nil)
+
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(k8sClient.Get(ctx, typeNamespacedName, openshiftAssistedControlPlane)).To(Succeed())
+		Expect(conditions.GetReason(openshiftAssistedControlPlane, controlplanev1beta1.UpgradeCompletedCondition)).
+			To(Equal(controlplanev1beta1.UpgradeInProgressReason))
+	})
+
+	Context("upgrade path validation", func() {
+		expectUpgradeRefused := func(reason string) reconcile.Result {
+			result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, openshiftAssistedControlPlane)).To(Succeed())
+			condition := conditions.Get(openshiftAssistedControlPlane, controlplanev1beta1.UpgradeCompletedCondition)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(corev1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(reason))
+			return result
+		}
+
+		BeforeEach(func() {
+			mockUpgradeFactory.EXPECT().NewUpgrader(gomock.Any()).Return(mockUpgrader, nil)
+			mockUpgrader.EXPECT().IsUpgradeInProgress(gomock.Any()).Return(false, nil)
+			mockUpgrader.EXPECT().IsDesiredVersionUpdated(gomock.Any(), gomock.Any()).Return(false, nil)
+			mockUpgrader.EXPECT().GetUpgradeStatus(gomock.Any()).Return(upgrade.UpgradeStatus{}, nil)
+		})
+
+		It("should refuse downgrades", func() {
+			mockUpgrader.EXPECT().GetCurrentVersion(gomock.Any()).Return("4.15.3", nil)
+
+			expectUpgradeRefused(controlplanev1beta1.UpgradeDowngradeNotAllowedReason)
+		})
+
+		It("should refuse upgrades skipping a minor version", func() {
+			mockUpgrader.EXPECT().GetCurrentVersion(gomock.Any()).Return("4.13.10", nil)
+
+			expectUpgradeRefused(controlplanev1beta1.UpgradeSkipsMinorVersionReason)
+		})
+
+		It("should refuse desired versions that are not semantic versions", func() {
+			openshiftAssistedControlPlane.Spec.DistributionVersion = "latest"
+			Expect(k8sClient.Update(ctx, openshiftAssistedControlPlane)).To(Succeed())
+			mockUpgrader.EXPECT().GetCurrentVersion(gomock.Any()).Return(currentVersion, nil)
+
+			expectUpgradeRefused(controlplanev1beta1.UpgradeInvalidVersionReason)
+		})
+
+		When("an update graph is configured", func() {
+			var mockUpgradeGraphProvider *upgrade.MockUpgradeGraphProvider
+
+			BeforeEach(func() {
+				mockUpgradeGraphProvider = upgrade.NewMockUpgradeGraphProvider(ctrl)
+				controllerReconciler.UpgradeGraphProvider = mockUpgradeGraphProvider
+				mockUpgrader.EXPECT().GetCurrentVersion(gomock.Any()).Return(currentVersion, nil)
+			})
+
+			It("should upgrade along a recommended edge", func() {
+				mockUpgradeGraphProvider.EXPECT().GetUpgradeGraph(gomock.Any(), "stable-4.15", gomock.Any()).Return(&upgrade.UpgradeGraph{
+					Nodes: []upgrade.UpgradeGraphNode{{Version: currentVersion}, {Version: desiredVersion}},
+					Edges: [][2]int{{0, 1}},
+				}, nil)
+				mockUpgrader.EXPECT().UpdateClusterVersionDesiredUpdate(gomock.Any(), desiredVersion, gomock.Any(), gomock.Any()).Return(nil)
+
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(k8sClient.Get(ctx, typeNamespacedName, openshiftAssistedControlPlane)).To(Succeed())
+				condition := conditions.Get(openshiftAssistedControlPlane, controlplanev1beta1.UpgradeCompletedCondition)
+				Expect(condition).NotTo(BeNil())
+				Expect(condition.Reason).To(Equal(controlplanev1beta1.UpgradeInProgressReason))
+			})
+
+			It("should refuse upgrades without a recommended edge", func() {
+				mockUpgradeGraphProvider.EXPECT().GetUpgradeGraph(gomock.Any(), "stable-4.15", gomock.Any()).Return(&upgrade.UpgradeGraph{
+					Nodes: []upgrade.UpgradeGraphNode{{Version: currentVersion}, {Version: desiredVersion}},
+					ConditionalEdges: []upgrade.UpgradeGraphConditionalEdge{{
+						Edges: []upgrade.UpgradeGraphEdge{{From: currentVersion, To: desiredVersion}},
+						Risks: []upgrade.UpgradeGraphRisk{{Name: "SomeRisk"}},
+					}},
+				}, nil)
+
+				result := expectUpgradeRefused(controlplanev1beta1.UpgradePathBlockedReason)
+				Expect(result.RequeueAfter).NotTo(BeZero())
+			})
+
+			It("should validate the upgrade path again once the update graph recommends it", func() {
+				mockUpgradeGraphProvider.EXPECT().GetUpgradeGraph(gomock.Any(), "stable-4.15", gomock.Any()).Return(&upgrade.UpgradeGraph{
+					Nodes: []upgrade.UpgradeGraphNode{{Version: currentVersion}, {Version: desiredVersion}},
+				}, nil)
+
+				result := expectUpgradeRefused(controlplanev1beta1.UpgradePathBlockedReason)
+				Expect(result.RequeueAfter).NotTo(BeZero())
+
+				By("adding the edge to the update graph")
+				mockUpgradeFactory.EXPECT().NewUpgrader(gomock.Any()).Return(mockUpgrader, nil)
+				mockUpgrader.EXPECT().IsUpgradeInProgress(gomock.Any()).Return(false, nil)
+				mockUpgrader.EXPECT().IsDesiredVersionUpdated(gomock.Any(), gomock.Any()).Return(false, nil)
+				mockUpgrader.EXPECT().GetUpgradeStatus(gomock.Any()).Return(upgrade.UpgradeStatus{}, nil)
+				mockUpgrader.EXPECT().GetCurrentVersion(gomock.Any()).Return(currentVersion, nil)
+				mockUpgradeGraphProvider.EXPECT().GetUpgradeGraph(gomock.Any(), "stable-4.15", gomock.Any()).Return(&upgrade.UpgradeGraph{
+					Nodes: []upgrade.UpgradeGraphNode{{Version: currentVersion}, {Version: desiredVersion}},
+					Edges: [][2]int{{0, 1}},
+				}, nil)
+				mockUpgrader.EXPECT().UpdateClusterVersionDesiredUpdate(gomock.Any(), desiredVersion, gomock.Any(), gomock.Any()).Return(nil)
+
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should query the configured channel of the update graph", func() {
+				controllerReconciler.UpgradeChannel = "candidate"
+				mockUpgradeGraphProvider.EXPECT().GetUpgradeGraph(gomock.Any(), "candidate-4.15", gomock.Any()).Return(&upgrade.UpgradeGraph{
+					Nodes: []upgrade.UpgradeGraphNode{{Version: currentVersion}, {Version: desiredVersion}},
+					Edges: [][2]int{{0, 1}},
+				}, nil)
+				mockUpgrader.EXPECT().UpdateClusterVersionDesiredUpdate(gomock.Any(), desiredVersion, gomock.Any(), gomock.Any()).Return(nil)
+
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should not upgrade when the update graph is unavailable", func() {
+				mockUpgradeGraphProvider.EXPECT().GetUpgradeGraph(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("connection refused"))
+
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+				Expect(err).To(HaveOccurred())
+
+				Expect(k8sClient.Get(ctx, typeNamespacedName, openshiftAssistedControlPlane)).To(Succeed())
+				condition := conditions.Get(openshiftAssistedControlPlane, controlplanev1beta1.UpgradeCompletedCondition)
+				Expect(condition).NotTo(BeNil())
+				Expect(condition.Reason).To(Equal(controlplanev1beta1.UpgradeGraphUnavailableReason))
+			})
+		})
+	})
 })
 
 var _ = Describe("Scale operations and machine updates", func() {
@@ -546,7 +1140,7 @@ var _ = Describe("Scale operations and machine updates", func() {
 		k8sClient                     client.Client
 		ctrl                          *gomock.Controller
 		mockKubernetesVersionDetector *version.MockKubernetesVersionDetector
-		oacp                          *controlplanev1alpha2.OpenshiftAssistedControlPlane
+		oacp                          *controlplanev1beta1.OpenshiftAssistedControlPlane
 	)
 
 	BeforeEach(func() {
@@ -554,7 +1148,7 @@ var _ = Describe("Scale operations and machine updates", func() {
 		ctrl = gomock.NewController(GinkgoT())
 		k8sClient = fakeclient.NewClientBuilder().
 			WithScheme(testScheme).
-			WithStatusSubresource(&clusterv1.Cluster{}, &controlplanev1alpha2.OpenshiftAssistedControlPlane{}, &clusterv1.Machine{}).
+			WithStatusSubresource(&clusterv1.Cluster{}, &controlplanev1beta1.OpenshiftAssistedControlPlane{}, &clusterv1.Machine{}).
 			Build()
 
 		mockKubernetesVersionDetector = version.NewMockKubernetesVersionDetector(ctrl)
@@ -626,6 +1220,13 @@ var _ = Describe("Scale operations and machine updates", func() {
 				Expect(machine.Labels).To(HaveKeyWithValue(clusterv1.MachineControlPlaneLabel, ""))
 				Expect(machine.Annotations).To(HaveKeyWithValue("bmac.agent-install.openshift.io/role", "master"))
 			}
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, oacp)).To(Succeed())
+			Expect(oacp.Status.Selector).To(Equal("cluster.x-k8s.io/cluster-name=test-cluster,cluster.x-k8s.io/control-plane"))
+			Expect(v1beta2conditions.IsFalse(oacp, controlplanev1beta1.OpenshiftAssistedControlPlaneScalingUpV1Beta2Condition)).To(BeTrue())
+			Expect(v1beta2conditions.IsFalse(oacp, controlplanev1beta1.OpenshiftAssistedControlPlaneRollingOutV1Beta2Condition)).To(BeTrue())
+			Expect(v1beta2conditions.Get(oacp, controlplanev1beta1.OpenshiftAssistedControlPlaneAvailableV1Beta2Condition).Reason).
+				To(Equal(controlplanev1beta1.OpenshiftAssistedControlPlaneNotInitializedV1Beta2Reason))
 		})
 
 		It("should distribute machines across failure domains", func() {
@@ -839,6 +1440,68 @@ var _ = Describe("Scale operations and machine updates", func() {
 			Expect(oacp.Status.UpdatedReplicas).To(Equal(oacp.Status.Replicas))
 		})
 
+		It("should propagate machine template metadata to existing machines", func() {
+			Expect(k8sClient.Get(ctx, typeNamespacedName, oacp)).To(Succeed())
+			oacp.Spec.MachineTemplate.ObjectMeta.Labels = map[string]string{"topology.cluster.x-k8s.io/owned": ""}
+			oacp.Spec.MachineTemplate.ObjectMeta.Annotations = map[string]string{"example.com/annotation": "value"}
+			Expect(k8sClient.Update(ctx, oacp)).To(Succeed())
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			machineList := &clusterv1.MachineList{}
+			Expect(k8sClient.List(ctx, machineList, client.InNamespace(namespace))).To(Succeed())
+			Expect(machineList.Items).To(HaveLen(3))
+			for _, machine := range machineList.Items {
+				Expect(machine.Labels).To(HaveKeyWithValue("topology.cluster.x-k8s.io/owned", ""))
+				Expect(machine.Labels).To(HaveKeyWithValue(clusterv1.ClusterNameLabel, clusterName))
+				Expect(machine.Annotations).To(HaveKeyWithValue("example.com/annotation", "value"))
+				Expect(machine.Annotations).To(HaveKeyWithValue("bmac.agent-install.openshift.io/role", "master"))
+			}
+		})
+
+		It("should set the versions of the release on the machines", func() {
+			machineList := &clusterv1.MachineList{}
+			Expect(k8sClient.List(ctx, machineList, client.InNamespace(namespace))).To(Succeed())
+			Expect(machineList.Items).To(HaveLen(3))
+			for _, machine := range machineList.Items {
+				Expect(machine.Spec.Version).To(Equal(ptr.To("v1.30.0")))
+				Expect(machine.Annotations).To(HaveKeyWithValue(controlplanev1beta1.DistributionVersionAnnotation, "4.18.0"))
+			}
+		})
+
+		It("should refresh the versions of the machines once the workload cluster is upgraded, without replacing them", func() {
+			Expect(k8sClient.Get(ctx, typeNamespacedName, oacp)).To(Succeed())
+			oacp.Spec.DistributionVersion = "4.18.2"
+			Expect(k8sClient.Update(ctx, oacp)).To(Succeed())
+			oacp.Status.DistributionVersion = "4.18.0"
+			Expect(k8sClient.Status().Update(ctx, oacp)).To(Succeed())
+
+			By("keeping the versions while the upgrade is in progress")
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			machineList := &clusterv1.MachineList{}
+			Expect(k8sClient.List(ctx, machineList, client.InNamespace(namespace))).To(Succeed())
+			for _, machine := range machineList.Items {
+				Expect(machine.Annotations).To(HaveKeyWithValue(controlplanev1beta1.DistributionVersionAnnotation, "4.18.0"))
+			}
+
+			By("refreshing the versions once the upgrade completed")
+			Expect(k8sClient.Get(ctx, typeNamespacedName, oacp)).To(Succeed())
+			oacp.Status.DistributionVersion = "4.18.2"
+			Expect(k8sClient.Status().Update(ctx, oacp)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.List(ctx, machineList, client.InNamespace(namespace))).To(Succeed())
+			Expect(machineList.Items).To(HaveLen(3))
+			for _, machine := range machineList.Items {
+				Expect(machine.Spec.Version).To(Equal(ptr.To("v1.30.0")))
+				Expect(machine.Annotations).To(HaveKeyWithValue(controlplanev1beta1.DistributionVersionAnnotation, "4.18.2"))
+			}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, oacp)).To(Succeed())
+			Expect(oacp.Status.UpdatedReplicas).To(Equal(int32(3)))
+		})
+
 		It("should handle bootstrap config updates", func() {
 			// Modify bootstrap config spec
 			Expect(k8sClient.Get(ctx, typeNamespacedName, oacp)).To(Succeed())
@@ -857,6 +1520,295 @@ var _ = Describe("Scale operations and machine updates", func() {
 			Expect(oacp.Status.UpdatedReplicas).To(Equal(int32(0)))
 		})
 	})
+
+	Context("Rollout of out of date machines", func() {
+		markMachinesReady := func() {
+			machineList := &clusterv1.MachineList{}
+			Expect(k8sClient.List(ctx, machineList, client.InNamespace(namespace))).To(Succeed())
+			for i := range machineList.Items {
+				machine := &machineList.Items[i]
+				conditions.MarkTrue(machine, clusterv1.ReadyCondition)
+				Expect(k8sClient.Status().Update(ctx, machine)).To(Succeed())
+			}
+		}
+		listMachines := func() []clusterv1.Machine {
+			machineList := &clusterv1.MachineList{}
+			Expect(k8sClient.List(ctx, machineList, client.InNamespace(namespace))).To(Succeed())
+			return machineList.Items
+		}
+		// rollout reconciles until all machines are up to date, marking new machines as ready
+		rollout := func() {
+			for i := 0; i < 10; i++ {
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+				Expect(err).NotTo(HaveOccurred())
+				markMachinesReady()
+				Expect(k8sClient.Get(ctx, typeNamespacedName, oacp)).To(Succeed())
+				// the status reflects the machines before they are scaled in the same reconcile
+				if conditions.IsTrue(oacp, controlplanev1beta1.MachinesSpecUpToDateCondition) && oacp.Status.UpdatedReplicas == oacp.Spec.Replicas {
+					return
+				}
+			}
+			Fail("rollout did not complete")
+		}
+
+		BeforeEach(func() {
+			oacp.Spec.Replicas = 3
+			Expect(k8sClient.Create(ctx, oacp)).To(Succeed())
+			for i := 0; i < 3; i++ {
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+				Expect(err).NotTo(HaveOccurred())
+			}
+			markMachinesReady()
+			Expect(k8sClient.Get(ctx, typeNamespacedName, oacp)).To(Succeed())
+			oacp.Status.Ready = true
+			Expect(k8sClient.Status().Update(ctx, oacp)).To(Succeed())
+		})
+
+		It("should not replace machines when the spec is unchanged", func() {
+			oldMachines := listMachines()
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(listMachines()).To(HaveLen(3))
+			Expect(k8sClient.Get(ctx, typeNamespacedName, oacp)).To(Succeed())
+			Expect(oacp.Status.UpdatedReplicas).To(Equal(int32(3)))
+			Expect(conditions.IsTrue(oacp, controlplanev1beta1.MachinesSpecUpToDateCondition)).To(BeTrue())
+			for _, machine := range oldMachines {
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&machine), &clusterv1.Machine{})).To(Succeed())
+			}
+		})
+
+		It("should not replace machines when the drain timeout is already applied", func() {
+			Expect(k8sClient.Get(ctx, typeNamespacedName, oacp)).To(Succeed())
+			oacp.Spec.MachineTemplate.NodeDrainTimeout = &metav1.Duration{Duration: 10 * time.Minute}
+			Expect(k8sClient.Update(ctx, oacp)).To(Succeed())
+			for _, machine := range listMachines() {
+				machine.Spec.NodeDrainTimeout = &metav1.Duration{Duration: 10 * time.Minute}
+				Expect(k8sClient.Update(ctx, &machine)).To(Succeed())
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(listMachines()).To(HaveLen(3))
+			Expect(k8sClient.Get(ctx, typeNamespacedName, oacp)).To(Succeed())
+			Expect(oacp.Status.UpdatedReplicas).To(Equal(int32(3)))
+		})
+
+		It("should not replace machines whose bootstrap config is not found", func() {
+			machine := listMachines()[0]
+			Expect(k8sClient.Delete(ctx, &bootstrapv1beta1.OpenshiftAssistedConfig{ObjectMeta: metav1.ObjectMeta{
+				Name:      machine.Spec.Bootstrap.ConfigRef.Name,
+				Namespace: namespace,
+			}})).To(Succeed())
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(listMachines()).To(HaveLen(3))
+			Expect(k8sClient.Get(ctx, typeNamespacedName, oacp)).To(Succeed())
+			Expect(oacp.Status.UpdatedReplicas).To(Equal(int32(3)))
+		})
+
+		It("should not replace machines whose infrastructure is not found", func() {
+			machine := listMachines()[0]
+			Expect(k8sClient.Delete(ctx, &metal3v1beta1.Metal3Machine{ObjectMeta: metav1.ObjectMeta{
+				Name:      machine.Spec.InfrastructureRef.Name,
+				Namespace: namespace,
+			}})).To(Succeed())
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(listMachines()).To(HaveLen(3))
+			Expect(k8sClient.Get(ctx, typeNamespacedName, oacp)).To(Succeed())
+			Expect(oacp.Status.UpdatedReplicas).To(Equal(int32(3)))
+		})
+
+		It("should keep updating the status while a machine is being deleted", func() {
+			machine := listMachines()[0]
+			machine.Finalizers = append(machine.Finalizers, clusterv1.MachineFinalizer)
+			Expect(k8sClient.Update(ctx, &machine)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, &machine)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, &metal3v1beta1.Metal3Machine{ObjectMeta: metav1.ObjectMeta{
+				Name:      machine.Spec.InfrastructureRef.Name,
+				Namespace: namespace,
+			}})).To(Succeed())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, oacp)).To(Succeed())
+			oacp.Status.Selector = ""
+			Expect(k8sClient.Status().Update(ctx, oacp)).To(Succeed())
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, oacp)).To(Succeed())
+			Expect(oacp.Status.Selector).NotTo(BeEmpty())
+			Expect(oacp.Status.Replicas).To(Equal(int32(3)))
+		})
+
+		It("should not replace machines whose bootstrap config cannot be retrieved", func() {
+			controllerReconciler.Client = interceptor.NewClient(k8sClient.(client.WithWatch), interceptor.Funcs{
+				Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+					if _, ok := obj.(*bootstrapv1beta1.OpenshiftAssistedConfig); ok {
+						return fmt.Errorf("connection refused")
+					}
+					return c.Get(ctx, key, obj, opts...)
+				},
+			})
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).To(MatchError(ContainSubstring("connection refused")))
+			Expect(listMachines()).To(HaveLen(3))
+		})
+
+		It("should wait for the cluster to be installed before replacing machines", func() {
+			Expect(k8sClient.Get(ctx, typeNamespacedName, oacp)).To(Succeed())
+			oacp.Status.Ready = false
+			Expect(k8sClient.Status().Update(ctx, oacp)).To(Succeed())
+			oacp.Spec.OpenshiftAssistedConfigSpec.KernelArguments = []v1beta1.KernelArgument{{Operation: "append", Value: "foo=bar"}}
+			Expect(k8sClient.Update(ctx, oacp)).To(Succeed())
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(listMachines()).To(HaveLen(3))
+			Expect(k8sClient.Get(ctx, typeNamespacedName, oacp)).To(Succeed())
+			condition := conditions.Get(oacp, controlplanev1beta1.MachinesSpecUpToDateCondition)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(corev1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(controlplanev1beta1.RollingUpdateWaitingForInstallReason))
+		})
+
+		It("should add a new machine and retire an out of date one once the new machine is ready", func() {
+			Expect(k8sClient.Get(ctx, typeNamespacedName, oacp)).To(Succeed())
+			oacp.Spec.OpenshiftAssistedConfigSpec.KernelArguments = []v1beta1.KernelArgument{{Operation: "append", Value: "foo=bar"}}
+			Expect(k8sClient.Update(ctx, oacp)).To(Succeed())
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(listMachines()).To(HaveLen(4))
+			Expect(k8sClient.Get(ctx, typeNamespacedName, oacp)).To(Succeed())
+			condition := conditions.Get(oacp, controlplanev1beta1.MachinesSpecUpToDateCondition)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Reason).To(Equal(controlplanev1beta1.RollingUpdateInProgressReason))
+
+			By("waiting for the new machine to join the cluster")
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(listMachines()).To(HaveLen(4))
+
+			By("retiring an out of date machine once the new machine is ready")
+			markMachinesReady()
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(listMachines()).To(HaveLen(3))
+			Expect(k8sClient.Get(ctx, typeNamespacedName, oacp)).To(Succeed())
+			Expect(oacp.Status.UpdatedReplicas).To(Equal(int32(1)))
+
+			rollout()
+			Expect(listMachines()).To(HaveLen(3))
+			Expect(oacp.Status.UpdatedReplicas).To(Equal(int32(3)))
+		})
+
+		It("should replace machines cloned from a previous infrastructure template", func() {
+			machineTemplate := getMachineTemplate("infratemplate-2", namespace)
+			Expect(k8sClient.Create(ctx, &machineTemplate)).To(Succeed())
+			oldMachines := listMachines()
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, oacp)).To(Succeed())
+			oacp.Spec.MachineTemplate.InfrastructureRef.Name = "infratemplate-2"
+			Expect(k8sClient.Update(ctx, oacp)).To(Succeed())
+
+			rollout()
+			Expect(listMachines()).To(HaveLen(3))
+			for _, machine := range oldMachines {
+				err := k8sClient.Get(ctx, client.ObjectKeyFromObject(&machine), &clusterv1.Machine{})
+				Expect(errors.IsNotFound(err)).To(BeTrue())
+			}
+		})
+
+		It("should replace machines created before rolloutAfter", func() {
+			Expect(k8sClient.Get(ctx, typeNamespacedName, oacp)).To(Succeed())
+			oacp.Spec.RolloutAfter = &metav1.Time{Time: time.Now().Add(-time.Minute)}
+			Expect(k8sClient.Update(ctx, oacp)).To(Succeed())
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(listMachines()).To(HaveLen(4))
+			Expect(k8sClient.Get(ctx, typeNamespacedName, oacp)).To(Succeed())
+			condition := conditions.Get(oacp, controlplanev1beta1.MachinesSpecUpToDateCondition)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Reason).To(Equal(controlplanev1beta1.RollingUpdateInProgressReason))
+		})
+
+		It("should requeue until rolloutAfter is reached", func() {
+			Expect(k8sClient.Get(ctx, typeNamespacedName, oacp)).To(Succeed())
+			oacp.Spec.RolloutAfter = &metav1.Time{Time: time.Now().Add(time.Hour)}
+			Expect(k8sClient.Update(ctx, oacp)).To(Succeed())
+
+			result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeNumerically(">", 59*time.Minute))
+			Expect(listMachines()).To(HaveLen(3))
+		})
+
+		It("should delete an out of date machine before adding a new one when maxSurge is 0", func() {
+			maxSurge := intstr.FromInt32(0)
+			Expect(k8sClient.Get(ctx, typeNamespacedName, oacp)).To(Succeed())
+			oacp.Spec.RolloutStrategy = &controlplanev1beta1.RolloutStrategy{
+				Type:          controlplanev1beta1.RollingUpdateStrategyType,
+				RollingUpdate: &controlplanev1beta1.RollingUpdate{MaxSurge: &maxSurge},
+			}
+			oacp.Spec.OpenshiftAssistedConfigSpec.KernelArguments = []v1beta1.KernelArgument{{Operation: "append", Value: "foo=bar"}}
+			Expect(k8sClient.Update(ctx, oacp)).To(Succeed())
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(listMachines()).To(HaveLen(2))
+
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(listMachines()).To(HaveLen(3))
+
+			rollout()
+			Expect(listMachines()).To(HaveLen(3))
+			Expect(oacp.Status.UpdatedReplicas).To(Equal(int32(3)))
+		})
+	})
+
+	Context("Rollout of a single node control plane", func() {
+		BeforeEach(func() {
+			oacp.Spec.Replicas = 1
+			Expect(k8sClient.Create(ctx, oacp)).To(Succeed())
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			machineList := &clusterv1.MachineList{}
+			Expect(k8sClient.List(ctx, machineList, client.InNamespace(namespace))).To(Succeed())
+			Expect(machineList.Items).To(HaveLen(1))
+			conditions.MarkTrue(&machineList.Items[0], clusterv1.ReadyCondition)
+			Expect(k8sClient.Status().Update(ctx, &machineList.Items[0])).To(Succeed())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, oacp)).To(Succeed())
+			oacp.Status.Ready = true
+			Expect(k8sClient.Status().Update(ctx, oacp)).To(Succeed())
+		})
+
+		It("should not replace the out of date machine", func() {
+			Expect(k8sClient.Get(ctx, typeNamespacedName, oacp)).To(Succeed())
+			oacp.Spec.OpenshiftAssistedConfigSpec.KernelArguments = []v1beta1.KernelArgument{{Operation: "append", Value: "foo=bar"}}
+			Expect(k8sClient.Update(ctx, oacp)).To(Succeed())
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			machineList := &clusterv1.MachineList{}
+			Expect(k8sClient.List(ctx, machineList, client.InNamespace(namespace))).To(Succeed())
+			Expect(machineList.Items).To(HaveLen(1))
+			Expect(k8sClient.Get(ctx, typeNamespacedName, oacp)).To(Succeed())
+			condition := conditions.Get(oacp, controlplanev1beta1.MachinesSpecUpToDateCondition)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(corev1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(controlplanev1beta1.RollingUpdateNotSupportedReason))
+		})
+	})
 })
 
 // Create dummy machine template
@@ -874,7 +1826,7 @@ func getMachineTemplate(name string, namespace string) metal3v1beta1.Metal3Machi
 	}
 }
 
-func checkReadyConditions(expectedReadyConditions []clusterv1.ConditionType, openshiftAssistedControlPlane *controlplanev1alpha2.OpenshiftAssistedControlPlane) {
+func checkReadyConditions(expectedReadyConditions []clusterv1.ConditionType, openshiftAssistedControlPlane *controlplanev1beta1.OpenshiftAssistedControlPlane) {
 	for _, conditionType := range expectedReadyConditions {
 		By(fmt.Sprintf("checking condition %s ready", conditionType))
 		condition := conditions.Get(openshiftAssistedControlPlane,
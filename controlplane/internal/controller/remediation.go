@@ -0,0 +1,244 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	controlplanev1beta1 "github.com/openshift-assisted/cluster-api-agent/controlplane/api/v1beta1"
+	logutil "github.com/openshift-assisted/cluster-api-agent/util/log"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/collections"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/patch"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const (
+	defaultRemediationMaxRetry         = 3
+	defaultRemediationRetryPeriod      = 5 * time.Minute
+	defaultRemediationMinHealthyPeriod = time.Hour
+)
+
+// reconcileUnhealthyMachines implements the control plane remediation contract: machines marked as unhealthy by a
+// MachineHealthCheck are deleted, one at a time, and replaced by the scale up through the assisted day-2 flow.
+// A machine is only deleted when its etcd member can be removed without losing quorum on the workload cluster,
+// and its etcd member is removed beforehand.
+// A replacement machine failing within the minimum healthy period counts as a retry of the same remediation,
+// retries are delayed with an exponential backoff and stop after the maximum number of retries.
+func (r *OpenshiftAssistedControlPlaneReconciler) reconcileUnhealthyMachines(
+	ctx context.Context,
+	oacp *controlplanev1beta1.OpenshiftAssistedControlPlane,
+	cluster *clusterv1.Cluster,
+	machines collections.Machines,
+) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	// the replacement of the previously remediated machine is created by the scale up. When the control plane does not
+	// need to scale up anymore, e.g. spec.replicas was lowered, the remediation completes once the machine is gone
+	if remediationData, ok := oacp.Annotations[controlplanev1beta1.RemediationInProgressAnnotation]; ok {
+		remediation := &controlplanev1beta1.LastRemediationStatus{}
+		if err := json.Unmarshal([]byte(remediationData), remediation); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to parse %s annotation: %w", controlplanev1beta1.RemediationInProgressAnnotation, err)
+		}
+		if _, remediatedMachineExists := machines[remediation.Machine]; remediatedMachineExists || machines.Len() < int(oacp.Spec.Replicas) {
+			log.V(logutil.InfoLevel).Info("remediation in progress, waiting for the replacement machine to be created")
+			return ctrl.Result{}, nil
+		}
+		log.V(logutil.InfoLevel).Info("remediated machine deleted and no replacement machine needed, completing the remediation",
+			"machine", remediation.Machine)
+		delete(oacp.Annotations, controlplanev1beta1.RemediationInProgressAnnotation)
+	}
+
+	unhealthyMachines := machines.Filter(collections.IsUnhealthyAndOwnerRemediated)
+	if unhealthyMachines.Len() == 0 {
+		return ctrl.Result{}, nil
+	}
+	// remediate one machine at a time, a machine being deleted might be the previously remediated one
+	if machines.Filter(collections.HasDeletionTimestamp).Len() > 0 {
+		log.V(logutil.InfoLevel).Info("waiting for control plane machines to be deleted before remediating")
+		return ctrl.Result{}, nil
+	}
+	machineToBeRemediated := unhealthyMachines.Oldest()
+	log = log.WithValues("machine", machineToBeRemediated.Name)
+
+	patchHelper, err := patch.NewHelper(machineToBeRemediated, r.Client)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	remediation, result, err := r.checkRemediationAllowed(ctx, oacp, cluster, machineToBeRemediated)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if remediation != nil {
+		conditions.MarkFalse(machineToBeRemediated, clusterv1.MachineOwnerRemediatedCondition, clusterv1.RemediationInProgressReason,
+			clusterv1.ConditionSeverityWarning, "")
+	}
+	// the condition is patched before deleting the machine, so that it is reported while the machine is being deleted
+	if err := patchHelper.Patch(ctx, machineToBeRemediated, patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{
+		clusterv1.MachineOwnerRemediatedCondition,
+	}}); err != nil {
+		log.Error(err, "failed to patch unhealthy machine")
+		return ctrl.Result{}, err
+	}
+	if remediation == nil {
+		return result, nil
+	}
+
+	if err := r.removeEtcdMember(ctx, cluster, machineToBeRemediated); err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.Client.Delete(ctx, machineToBeRemediated); err != nil && !apierrors.IsNotFound(err) {
+		return ctrl.Result{}, fmt.Errorf("failed to delete unhealthy machine %s: %w", machineToBeRemediated.Name, err)
+	}
+	log.V(logutil.InfoLevel).Info("remediating unhealthy controlplane machine", "retryCount", remediation.RetryCount)
+
+	// the remediation data is passed to the replacement machine through the control plane annotation
+	remediationData, err := json.Marshal(remediation)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if oacp.Annotations == nil {
+		oacp.Annotations = map[string]string{}
+	}
+	oacp.Annotations[controlplanev1beta1.RemediationInProgressAnnotation] = string(remediationData)
+	oacp.Status.LastRemediation = remediation
+
+	// requeue to create the replacement machine once the deletion is reflected
+	return ctrl.Result{Requeue: true}, nil
+}
+
+// checkRemediationAllowed returns the remediation to perform on the unhealthy machine, or nil when the machine
+// cannot be remediated yet, in which case the reason is reported on the machine MachineOwnerRemediated condition.
+func (r *OpenshiftAssistedControlPlaneReconciler) checkRemediationAllowed(
+	ctx context.Context,
+	oacp *controlplanev1beta1.OpenshiftAssistedControlPlane,
+	cluster *clusterv1.Cluster,
+	machineToBeRemediated *clusterv1.Machine,
+) (*controlplanev1beta1.LastRemediationStatus, ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx).WithValues("machine", machineToBeRemediated.Name)
+
+	// a single control plane machine cannot be replaced, as the cluster would be lost along with it
+	if oacp.Spec.Replicas <= 1 {
+		conditions.MarkFalse(machineToBeRemediated, clusterv1.MachineOwnerRemediatedCondition, clusterv1.WaitingForRemediationReason,
+			clusterv1.ConditionSeverityWarning, "OpenshiftAssistedControlPlane can't remediate if current replicas are less or equal to 1")
+		return nil, ctrl.Result{}, nil
+	}
+	// replacements join the cluster through the assisted day-2 flow, which is available once the cluster is installed
+	if !oacp.Status.Ready {
+		conditions.MarkFalse(machineToBeRemediated, clusterv1.MachineOwnerRemediatedCondition, clusterv1.WaitingForRemediationReason,
+			clusterv1.ConditionSeverityWarning, "OpenshiftAssistedControlPlane can't remediate until the cluster is installed")
+		return nil, ctrl.Result{}, nil
+	}
+
+	remediation, err := nextRemediation(oacp, machineToBeRemediated)
+	if err != nil {
+		return nil, ctrl.Result{}, err
+	}
+	maxRetry, retryPeriod, _ := getRemediationStrategy(oacp)
+	if remediation.RetryCount > maxRetry {
+		log.V(logutil.WarningLevel).Info("machine remediation exceeded the maximum number of retries", "maxRetry", maxRetry)
+		conditions.MarkFalse(machineToBeRemediated, clusterv1.MachineOwnerRemediatedCondition, clusterv1.RemediationFailedReason,
+			clusterv1.ConditionSeverityError, "OpenshiftAssistedControlPlane can't remediate this machine because the maximum number of retries (%d) was reached",
+			maxRetry)
+		return nil, ctrl.Result{}, nil
+	}
+	if remediation.RetryCount > 0 {
+		lastRemediation := getLastRemediation(machineToBeRemediated)
+		backoff := retryPeriod * time.Duration(1<<(remediation.RetryCount-1))
+		if waitFor := time.Until(lastRemediation.Timestamp.Add(backoff)); waitFor > 0 {
+			conditions.MarkFalse(machineToBeRemediated, clusterv1.MachineOwnerRemediatedCondition, clusterv1.WaitingForRemediationReason,
+				clusterv1.ConditionSeverityWarning, "OpenshiftAssistedControlPlane can't remediate this machine before %s (retry %d)",
+				lastRemediation.Timestamp.Add(backoff).UTC().Format(time.RFC3339), remediation.RetryCount)
+			return nil, ctrl.Result{RequeueAfter: waitFor}, nil
+		}
+	}
+
+	canSafelyRemove, err := r.canSafelyRemoveEtcdMember(ctx, cluster, machineToBeRemediated)
+	if err != nil {
+		return nil, ctrl.Result{}, err
+	}
+	if !canSafelyRemove {
+		log.V(logutil.InfoLevel).Info("remediation blocked, removing the machine would cause etcd to lose quorum")
+		conditions.MarkFalse(machineToBeRemediated, clusterv1.MachineOwnerRemediatedCondition, clusterv1.WaitingForRemediationReason,
+			clusterv1.ConditionSeverityWarning, "OpenshiftAssistedControlPlane can't remediate this machine because this could result in etcd losing quorum")
+		return nil, ctrl.Result{RequeueAfter: etcdRequeueAfter}, nil
+	}
+	return remediation, ctrl.Result{}, nil
+}
+
+// nextRemediation returns the remediation data of the machine to be remediated. When the machine is itself the
+// replacement of a machine remediated within the minimum healthy period, the remediation is a retry.
+func nextRemediation(oacp *controlplanev1beta1.OpenshiftAssistedControlPlane, machine *clusterv1.Machine) (*controlplanev1beta1.LastRemediationStatus, error) {
+	remediation := &controlplanev1beta1.LastRemediationStatus{
+		Machine:   machine.Name,
+		Timestamp: metav1.Now(),
+	}
+	if _, ok := machine.Annotations[controlplanev1beta1.RemediationForAnnotation]; !ok {
+		return remediation, nil
+	}
+	lastRemediation := getLastRemediation(machine)
+	if lastRemediation == nil {
+		return nil, fmt.Errorf("failed to parse %s annotation of machine %s", controlplanev1beta1.RemediationForAnnotation, machine.Name)
+	}
+	_, _, minHealthyPeriod := getRemediationStrategy(oacp)
+	if lastRemediation.Timestamp.Add(minHealthyPeriod).Before(remediation.Timestamp.Time) {
+		return remediation, nil
+	}
+	remediation.RetryCount = lastRemediation.RetryCount + 1
+	return remediation, nil
+}
+
+// getLastRemediation returns the remediation that created the machine, if any
+func getLastRemediation(machine *clusterv1.Machine) *controlplanev1beta1.LastRemediationStatus {
+	value, ok := machine.Annotations[controlplanev1beta1.RemediationForAnnotation]
+	if !ok {
+		return nil
+	}
+	lastRemediation := &controlplanev1beta1.LastRemediationStatus{}
+	if err := json.Unmarshal([]byte(value), lastRemediation); err != nil {
+		return nil
+	}
+	return lastRemediation
+}
+
+// getRemediationStrategy returns the remediation settings of the control plane, or their default values
+func getRemediationStrategy(oacp *controlplanev1beta1.OpenshiftAssistedControlPlane) (int32, time.Duration, time.Duration) {
+	maxRetry := int32(defaultRemediationMaxRetry)
+	retryPeriod := defaultRemediationRetryPeriod
+	minHealthyPeriod := defaultRemediationMinHealthyPeriod
+	strategy := oacp.Spec.RemediationStrategy
+	if strategy == nil {
+		return maxRetry, retryPeriod, minHealthyPeriod
+	}
+	if strategy.MaxRetry != nil {
+		maxRetry = *strategy.MaxRetry
+	}
+	if strategy.RetryPeriod != nil {
+		retryPeriod = strategy.RetryPeriod.Duration
+	}
+	if strategy.MinHealthyPeriod != nil {
+		minHealthyPeriod = strategy.MinHealthyPeriod.Duration
+	}
+	return maxRetry, retryPeriod, minHealthyPeriod
+}
@@ -0,0 +1,374 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	controlplanev1beta1 "github.com/openshift-assisted/cluster-api-agent/controlplane/api/v1beta1"
+	"github.com/openshift-assisted/cluster-api-agent/controlplane/internal/etcd"
+	"github.com/openshift-assisted/cluster-api-agent/controlplane/internal/version"
+	"github.com/openshift-assisted/cluster-api-agent/pkg/workloadclient"
+	testutils "github.com/openshift-assisted/cluster-api-agent/test/utils"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+var _ = Describe("Remediation of unhealthy control plane machines", func() {
+	const (
+		openshiftAssistedControlPlaneName = "test-resource"
+		clusterName                       = "test-cluster"
+		namespace                         = "test"
+	)
+
+	var (
+		ctx                  context.Context
+		typeNamespacedName   types.NamespacedName
+		controllerReconciler *OpenshiftAssistedControlPlaneReconciler
+		k8sClient            client.Client
+		workloadClient       client.Client
+		mockMemberClient     *etcd.MockMemberClient
+		ctrl                 *gomock.Controller
+		oacp                 *controlplanev1beta1.OpenshiftAssistedControlPlane
+	)
+
+	listMachines := func() []clusterv1.Machine {
+		machineList := &clusterv1.MachineList{}
+		Expect(k8sClient.List(ctx, machineList, client.InNamespace(namespace))).To(Succeed())
+		return machineList.Items
+	}
+	createEtcdMember := func(nodeName string, ready bool) {
+		status := corev1.ConditionFalse
+		if ready {
+			status = corev1.ConditionTrue
+		}
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "etcd-" + nodeName,
+				Namespace: etcd.Namespace,
+				Labels:    map[string]string{"app": "etcd"},
+			},
+			Spec: corev1.PodSpec{NodeName: nodeName},
+			Status: corev1.PodStatus{
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: status}},
+			},
+		}
+		Expect(workloadClient.Create(ctx, pod)).To(Succeed())
+	}
+	// markMachineUnhealthy sets the conditions set by a MachineHealthCheck on an unhealthy machine
+	markMachineUnhealthy := func(machine *clusterv1.Machine) {
+		conditions.MarkFalse(machine, clusterv1.MachineHealthCheckSucceededCondition, clusterv1.UnhealthyNodeConditionReason,
+			clusterv1.ConditionSeverityWarning, "")
+		conditions.MarkFalse(machine, clusterv1.MachineOwnerRemediatedCondition, clusterv1.WaitingForRemediationReason,
+			clusterv1.ConditionSeverityWarning, "")
+		Expect(k8sClient.Status().Update(ctx, machine)).To(Succeed())
+	}
+	setRemediationForAnnotation := func(machine *clusterv1.Machine, remediation controlplanev1beta1.LastRemediationStatus) {
+		data, err := json.Marshal(remediation)
+		Expect(err).NotTo(HaveOccurred())
+		machine.Annotations[controlplanev1beta1.RemediationForAnnotation] = string(data)
+		Expect(k8sClient.Update(ctx, machine)).To(Succeed())
+	}
+	getOwnerRemediatedCondition := func(machine *clusterv1.Machine) *clusterv1.Condition {
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(machine), machine)).To(Succeed())
+		return conditions.Get(machine, clusterv1.MachineOwnerRemediatedCondition)
+	}
+
+	var unhealthyMachine *clusterv1.Machine
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		ctrl = gomock.NewController(GinkgoT())
+		k8sClient = fakeclient.NewClientBuilder().
+			WithScheme(testScheme).
+			WithStatusSubresource(&clusterv1.Cluster{}, &controlplanev1beta1.OpenshiftAssistedControlPlane{}, &clusterv1.Machine{}).
+			Build()
+		workloadClient = fakeclient.NewClientBuilder().WithScheme(testScheme).Build()
+
+		mockKubernetesVersionDetector := version.NewMockKubernetesVersionDetector(ctrl)
+		k8sVersion := "1.30.0"
+		mockKubernetesVersionDetector.EXPECT().GetKubernetesVersion(gomock.Any(), gomock.Any()).Return(&k8sVersion, nil).AnyTimes()
+		mockClientGenerator := workloadclient.NewMockClientGenerator(ctrl)
+		mockClientGenerator.EXPECT().GetWorkloadClusterClient(gomock.Any()).Return(workloadClient, nil).AnyTimes()
+		mockMemberClient = etcd.NewMockMemberClient(ctrl)
+		mockMemberClientGenerator := etcd.NewMockMemberClientGenerator(ctrl)
+		mockMemberClientGenerator.EXPECT().GetMemberClient(gomock.Any()).Return(mockMemberClient, nil).AnyTimes()
+
+		controllerReconciler = &OpenshiftAssistedControlPlaneReconciler{
+			Client:                    k8sClient,
+			Scheme:                    k8sClient.Scheme(),
+			K8sVersionDetector:        mockKubernetesVersionDetector,
+			WorkloadClientGenerator:   mockClientGenerator,
+			EtcdMemberClientGenerator: mockMemberClientGenerator,
+		}
+		typeNamespacedName = types.NamespacedName{
+			Name:      openshiftAssistedControlPlaneName,
+			Namespace: namespace,
+		}
+
+		machineTemplate := getMachineTemplate("infratemplate", namespace)
+		Expect(k8sClient.Create(ctx, &machineTemplate)).To(Succeed())
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}}
+		Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+		kubeconfigSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      clusterName + "-kubeconfig",
+				Namespace: namespace,
+			},
+			Data: map[string][]byte{"value": []byte("kubeconfig")},
+		}
+		Expect(k8sClient.Create(ctx, kubeconfigSecret)).To(Succeed())
+
+		cluster := testutils.NewCluster(clusterName, namespace)
+		Expect(k8sClient.Create(ctx, cluster)).To(Succeed())
+
+		oacp = testutils.NewOpenshiftAssistedControlPlane(namespace, openshiftAssistedControlPlaneName)
+		oacp.SetOwnerReferences([]metav1.OwnerReference{
+			*metav1.NewControllerRef(cluster, clusterv1.GroupVersion.WithKind(clusterv1.ClusterKind)),
+		})
+		oacp.Spec.MachineTemplate.InfrastructureRef = corev1.ObjectReference{
+			Kind:       "Metal3MachineTemplate",
+			Namespace:  namespace,
+			Name:       "infratemplate",
+			APIVersion: "infrastructure.cluster.x-k8s.io/v1beta1",
+		}
+		oacp.Spec.Replicas = 3
+		Expect(k8sClient.Create(ctx, oacp)).To(Succeed())
+		for i := 0; i < 3; i++ {
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		By("joining the machines to the installed cluster")
+		machines := listMachines()
+		Expect(machines).To(HaveLen(3))
+		for i := range machines {
+			machine := &machines[i]
+			nodeName := fmt.Sprintf("master-%d", i)
+			machine.Status.NodeRef = &corev1.ObjectReference{Kind: "Node", Name: nodeName}
+			conditions.MarkTrue(machine, clusterv1.ReadyCondition)
+			Expect(k8sClient.Status().Update(ctx, machine)).To(Succeed())
+			createEtcdMember(nodeName, i != 0)
+		}
+		Expect(k8sClient.Get(ctx, typeNamespacedName, oacp)).To(Succeed())
+		oacp.Status.Ready = true
+		Expect(k8sClient.Status().Update(ctx, oacp)).To(Succeed())
+
+		unhealthyMachine = &machines[0]
+		markMachineUnhealthy(unhealthyMachine)
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	It("should delete the unhealthy machine and replace it", func() {
+		members := []etcd.Member{{ID: 1, Name: "master-0"}, {ID: 2, Name: "master-1"}, {ID: 3, Name: "master-2"}}
+		mockMemberClient.EXPECT().ListMembers(gomock.Any()).Return(members, nil)
+		mockMemberClient.EXPECT().RemoveMember(gomock.Any(), members[0]).Return(nil)
+
+		result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Requeue).To(BeTrue())
+
+		err = k8sClient.Get(ctx, client.ObjectKeyFromObject(unhealthyMachine), &clusterv1.Machine{})
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+		Expect(listMachines()).To(HaveLen(2))
+		Expect(k8sClient.Get(ctx, typeNamespacedName, oacp)).To(Succeed())
+		Expect(oacp.Annotations).To(HaveKey(controlplanev1beta1.RemediationInProgressAnnotation))
+		Expect(oacp.Status.LastRemediation).NotTo(BeNil())
+		Expect(oacp.Status.LastRemediation.Machine).To(Equal(unhealthyMachine.Name))
+		Expect(oacp.Status.LastRemediation.RetryCount).To(Equal(int32(0)))
+
+		By("creating the replacement machine")
+		_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+		machines := listMachines()
+		Expect(machines).To(HaveLen(3))
+		Expect(k8sClient.Get(ctx, typeNamespacedName, oacp)).To(Succeed())
+		Expect(oacp.Annotations).NotTo(HaveKey(controlplanev1beta1.RemediationInProgressAnnotation))
+		replacements := 0
+		for _, machine := range machines {
+			if value, ok := machine.Annotations[controlplanev1beta1.RemediationForAnnotation]; ok {
+				replacements++
+				remediation := controlplanev1beta1.LastRemediationStatus{}
+				Expect(json.Unmarshal([]byte(value), &remediation)).To(Succeed())
+				Expect(remediation.Machine).To(Equal(unhealthyMachine.Name))
+			}
+		}
+		Expect(replacements).To(Equal(1))
+	})
+
+	It("should complete the remediation when the replicas are lowered before the machine is replaced", func() {
+		members := []etcd.Member{{ID: 1, Name: "master-0"}, {ID: 2, Name: "master-1"}, {ID: 3, Name: "master-2"}}
+		mockMemberClient.EXPECT().ListMembers(gomock.Any()).Return(members, nil)
+		mockMemberClient.EXPECT().RemoveMember(gomock.Any(), members[0]).Return(nil)
+
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(listMachines()).To(HaveLen(2))
+		Expect(k8sClient.Get(ctx, typeNamespacedName, oacp)).To(Succeed())
+		Expect(oacp.Annotations).To(HaveKey(controlplanev1beta1.RemediationInProgressAnnotation))
+
+		By("lowering the replicas to the number of remaining machines")
+		oacp.Spec.Replicas = 2
+		Expect(k8sClient.Update(ctx, oacp)).To(Succeed())
+
+		_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(listMachines()).To(HaveLen(2))
+		Expect(k8sClient.Get(ctx, typeNamespacedName, oacp)).To(Succeed())
+		Expect(oacp.Annotations).NotTo(HaveKey(controlplanev1beta1.RemediationInProgressAnnotation))
+		Expect(oacp.Status.LastRemediation).NotTo(BeNil())
+		Expect(oacp.Status.LastRemediation.Machine).To(Equal(unhealthyMachine.Name))
+	})
+
+	It("should not delete the unhealthy machine when etcd would lose quorum", func() {
+		Expect(workloadClient.Delete(ctx, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "etcd-master-1", Namespace: etcd.Namespace},
+		})).To(Succeed())
+		createEtcdMember("master-1", false)
+
+		result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(etcdRequeueAfter))
+
+		Expect(listMachines()).To(HaveLen(3))
+		condition := getOwnerRemediatedCondition(unhealthyMachine)
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Reason).To(Equal(clusterv1.WaitingForRemediationReason))
+		Expect(condition.Message).To(ContainSubstring("etcd losing quorum"))
+		Expect(k8sClient.Get(ctx, typeNamespacedName, oacp)).To(Succeed())
+		Expect(oacp.Status.LastRemediation).To(BeNil())
+	})
+
+	It("should not delete the unhealthy machine when its etcd member cannot be removed", func() {
+		mockMemberClient.EXPECT().ListMembers(gomock.Any()).Return(nil, fmt.Errorf("etcdctl failed"))
+
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).To(HaveOccurred())
+
+		Expect(listMachines()).To(HaveLen(3))
+		Expect(k8sClient.Get(ctx, typeNamespacedName, oacp)).To(Succeed())
+		Expect(oacp.Status.LastRemediation).To(BeNil())
+	})
+
+	It("should not remediate before the cluster is installed", func() {
+		Expect(k8sClient.Get(ctx, typeNamespacedName, oacp)).To(Succeed())
+		oacp.Status.Ready = false
+		Expect(k8sClient.Status().Update(ctx, oacp)).To(Succeed())
+
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(listMachines()).To(HaveLen(3))
+		condition := getOwnerRemediatedCondition(unhealthyMachine)
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Reason).To(Equal(clusterv1.WaitingForRemediationReason))
+		Expect(condition.Message).To(ContainSubstring("until the cluster is installed"))
+	})
+
+	It("should wait for the retry period before remediating a replacement machine again", func() {
+		setRemediationForAnnotation(unhealthyMachine, controlplanev1beta1.LastRemediationStatus{
+			Machine:    "previous-machine",
+			Timestamp:  metav1.Now(),
+			RetryCount: 1,
+		})
+
+		result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+		// second retry, the default retry period is doubled
+		Expect(result.RequeueAfter).To(BeNumerically(">", 9*time.Minute))
+		Expect(result.RequeueAfter).To(BeNumerically("<=", 10*time.Minute))
+
+		Expect(listMachines()).To(HaveLen(3))
+		condition := getOwnerRemediatedCondition(unhealthyMachine)
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Reason).To(Equal(clusterv1.WaitingForRemediationReason))
+	})
+
+	It("should retry the remediation once the retry period is over", func() {
+		mockMemberClient.EXPECT().ListMembers(gomock.Any()).Return([]etcd.Member{{ID: 1, Name: "master-0"}}, nil)
+		mockMemberClient.EXPECT().RemoveMember(gomock.Any(), gomock.Any()).Return(nil)
+		setRemediationForAnnotation(unhealthyMachine, controlplanev1beta1.LastRemediationStatus{
+			Machine:    "previous-machine",
+			Timestamp:  metav1.NewTime(time.Now().Add(-6 * time.Minute)),
+			RetryCount: 0,
+		})
+
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(listMachines()).To(HaveLen(2))
+		Expect(k8sClient.Get(ctx, typeNamespacedName, oacp)).To(Succeed())
+		Expect(oacp.Status.LastRemediation).NotTo(BeNil())
+		Expect(oacp.Status.LastRemediation.RetryCount).To(Equal(int32(1)))
+	})
+
+	It("should not count a failure after the min healthy period as a retry", func() {
+		mockMemberClient.EXPECT().ListMembers(gomock.Any()).Return([]etcd.Member{{ID: 1, Name: "master-0"}}, nil)
+		mockMemberClient.EXPECT().RemoveMember(gomock.Any(), gomock.Any()).Return(nil)
+		setRemediationForAnnotation(unhealthyMachine, controlplanev1beta1.LastRemediationStatus{
+			Machine:    "previous-machine",
+			Timestamp:  metav1.NewTime(time.Now().Add(-2 * time.Hour)),
+			RetryCount: 3,
+		})
+
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(listMachines()).To(HaveLen(2))
+		Expect(k8sClient.Get(ctx, typeNamespacedName, oacp)).To(Succeed())
+		Expect(oacp.Status.LastRemediation).NotTo(BeNil())
+		Expect(oacp.Status.LastRemediation.RetryCount).To(Equal(int32(0)))
+	})
+
+	It("should stop remediating once the maximum number of retries is reached", func() {
+		maxRetry := int32(1)
+		Expect(k8sClient.Get(ctx, typeNamespacedName, oacp)).To(Succeed())
+		oacp.Spec.RemediationStrategy = &controlplanev1beta1.RemediationStrategy{MaxRetry: &maxRetry}
+		Expect(k8sClient.Update(ctx, oacp)).To(Succeed())
+		setRemediationForAnnotation(unhealthyMachine, controlplanev1beta1.LastRemediationStatus{
+			Machine:    "previous-machine",
+			Timestamp:  metav1.NewTime(time.Now().Add(-30 * time.Minute)),
+			RetryCount: 1,
+		})
+
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(listMachines()).To(HaveLen(3))
+		condition := getOwnerRemediatedCondition(unhealthyMachine)
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Reason).To(Equal(clusterv1.RemediationFailedReason))
+		Expect(condition.Severity).To(Equal(clusterv1.ConditionSeverityError))
+	})
+})
@@ -0,0 +1,108 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	controlplanev1beta1 "github.com/openshift-assisted/cluster-api-agent/controlplane/api/v1beta1"
+	"github.com/openshift-assisted/cluster-api-agent/controlplane/internal/etcd"
+	"github.com/openshift-assisted/cluster-api-agent/pkg/workloadclient"
+	logutil "github.com/openshift-assisted/cluster-api-agent/util/log"
+	configv1 "github.com/openshift/api/config/v1"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/collections"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// infrastructureName is the name of the cluster-scoped Infrastructure resource of OpenShift clusters
+const infrastructureName = "cluster"
+
+// reconcileControlPlaneTopology switches the workload cluster to a highly available control plane topology once
+// a Single Node OpenShift control plane is scaled up. The new control plane machines join the installed cluster
+// through the assisted day-2 flow, and the cluster-etcd-operator adds their etcd members.
+// The topology is updated once all the control plane machines are ready and all the etcd members are healthy,
+// so that the operators relying on it (etcd quorum guard, ingress, monitoring...) scale to the new control plane.
+func (r *OpenshiftAssistedControlPlaneReconciler) reconcileControlPlaneTopology(
+	ctx context.Context,
+	oacp *controlplanev1beta1.OpenshiftAssistedControlPlane,
+	cluster *clusterv1.Cluster,
+) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	// a highly available control plane cannot be scaled down to a single replica, so the topology only changes once
+	if !oacp.Status.Ready || oacp.Spec.Replicas <= 1 ||
+		conditions.IsTrue(oacp, controlplanev1beta1.ControlPlaneTopologyUpToDateCondition) {
+		return ctrl.Result{}, nil
+	}
+
+	workloadClient, err := workloadclient.GetWorkloadClientFromClusterName(ctx, r.Client, r.WorkloadClientGenerator, cluster.Name, cluster.Namespace)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	infrastructure := &configv1.Infrastructure{}
+	if err := workloadClient.Get(ctx, client.ObjectKey{Name: infrastructureName}, infrastructure); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to get workload cluster infrastructure: %w", err)
+	}
+	if infrastructure.Status.ControlPlaneTopology != configv1.SingleReplicaTopologyMode {
+		conditions.MarkTrue(oacp, controlplanev1beta1.ControlPlaneTopologyUpToDateCondition)
+		return ctrl.Result{}, nil
+	}
+
+	machines, err := collections.GetFilteredMachinesForCluster(ctx, r.Client, cluster, collections.OwnedMachines(oacp))
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if machines.Len() < int(oacp.Spec.Replicas) || !isControlPlaneStable(machines) {
+		log.V(logutil.InfoLevel).Info("waiting for control plane machines to join the cluster before updating the control plane topology")
+		conditions.MarkFalse(oacp, controlplanev1beta1.ControlPlaneTopologyUpToDateCondition,
+			controlplanev1beta1.ControlPlaneExpansionInProgressReason, clusterv1.ConditionSeverityInfo,
+			"waiting for %d control plane machines to be ready", oacp.Spec.Replicas)
+		return ctrl.Result{}, nil
+	}
+	healthy, err := etcd.HasHealthyMembers(ctx, workloadClient, int(oacp.Spec.Replicas))
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !healthy {
+		log.V(logutil.InfoLevel).Info("waiting for etcd members to be healthy before updating the control plane topology")
+		conditions.MarkFalse(oacp, controlplanev1beta1.ControlPlaneTopologyUpToDateCondition,
+			controlplanev1beta1.ControlPlaneExpansionInProgressReason, clusterv1.ConditionSeverityInfo,
+			"waiting for %d etcd members to be healthy", oacp.Spec.Replicas)
+		return ctrl.Result{RequeueAfter: etcdRequeueAfter}, nil
+	}
+
+	original := infrastructure.DeepCopy()
+	infrastructure.Status.ControlPlaneTopology = configv1.HighlyAvailableTopologyMode
+	// the control plane nodes of a single node cluster are schedulable, hence they run the infrastructure workloads
+	if infrastructure.Status.InfrastructureTopology == configv1.SingleReplicaTopologyMode {
+		infrastructure.Status.InfrastructureTopology = configv1.HighlyAvailableTopologyMode
+	}
+	if err := workloadClient.Status().Patch(ctx, infrastructure, client.MergeFrom(original)); err != nil {
+		conditions.MarkFalse(oacp, controlplanev1beta1.ControlPlaneTopologyUpToDateCondition,
+			controlplanev1beta1.ControlPlaneTopologyUpdateFailedReason, clusterv1.ConditionSeverityWarning,
+			"failed to update the control plane topology: %v", err)
+		return ctrl.Result{}, fmt.Errorf("failed to update workload cluster infrastructure: %w", err)
+	}
+	log.V(logutil.InfoLevel).Info("updated the workload cluster to a highly available control plane topology")
+	conditions.MarkTrue(oacp, controlplanev1beta1.ControlPlaneTopologyUpToDateCondition)
+	return ctrl.Result{}, nil
+}
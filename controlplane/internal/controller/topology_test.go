@@ -0,0 +1,241 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	configv1 "github.com/openshift/api/config/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	controlplanev1beta1 "github.com/openshift-assisted/cluster-api-agent/controlplane/api/v1beta1"
+	"github.com/openshift-assisted/cluster-api-agent/controlplane/internal/etcd"
+	"github.com/openshift-assisted/cluster-api-agent/controlplane/internal/upgrade"
+	"github.com/openshift-assisted/cluster-api-agent/controlplane/internal/version"
+	"github.com/openshift-assisted/cluster-api-agent/pkg/workloadclient"
+	testutils "github.com/openshift-assisted/cluster-api-agent/test/utils"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+var _ = Describe("Single Node OpenShift control plane expansion", func() {
+	const (
+		openshiftAssistedControlPlaneName = "test-resource"
+		clusterName                       = "test-cluster"
+		namespace                         = "test"
+	)
+
+	var (
+		ctx                  context.Context
+		typeNamespacedName   types.NamespacedName
+		controllerReconciler *OpenshiftAssistedControlPlaneReconciler
+		k8sClient            client.Client
+		workloadClient       client.Client
+		ctrl                 *gomock.Controller
+		oacp                 *controlplanev1beta1.OpenshiftAssistedControlPlane
+	)
+
+	listMachines := func() []clusterv1.Machine {
+		machineList := &clusterv1.MachineList{}
+		Expect(k8sClient.List(ctx, machineList, client.InNamespace(namespace))).To(Succeed())
+		return machineList.Items
+	}
+	reconcileControlPlane := func() reconcile.Result {
+		result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+		return result
+	}
+	// joinMachines simulates the machines joining the workload cluster, each of them running an etcd member
+	joinMachines := func() {
+		machines := listMachines()
+		for i := range machines {
+			machine := &machines[i]
+			nodeName := fmt.Sprintf("master-%d", i)
+			machine.Status.NodeRef = &corev1.ObjectReference{Kind: "Node", Name: nodeName}
+			conditions.MarkTrue(machine, clusterv1.ReadyCondition)
+			Expect(k8sClient.Status().Update(ctx, machine)).To(Succeed())
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "etcd-" + nodeName,
+					Namespace: etcd.Namespace,
+					Labels:    map[string]string{"app": "etcd"},
+				},
+				Spec: corev1.PodSpec{NodeName: nodeName},
+				Status: corev1.PodStatus{
+					Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+				},
+			}
+			_ = workloadClient.Delete(ctx, pod)
+			Expect(workloadClient.Create(ctx, pod)).To(Succeed())
+		}
+	}
+	getInfrastructure := func() *configv1.Infrastructure {
+		infrastructure := &configv1.Infrastructure{}
+		Expect(workloadClient.Get(ctx, client.ObjectKey{Name: infrastructureName}, infrastructure)).To(Succeed())
+		return infrastructure
+	}
+	expectExpansionInProgress := func() {
+		Expect(k8sClient.Get(ctx, typeNamespacedName, oacp)).To(Succeed())
+		condition := conditions.Get(oacp, controlplanev1beta1.ControlPlaneTopologyUpToDateCondition)
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Status).To(Equal(corev1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(controlplanev1beta1.ControlPlaneExpansionInProgressReason))
+		Expect(getInfrastructure().Status.ControlPlaneTopology).To(Equal(configv1.SingleReplicaTopologyMode))
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		ctrl = gomock.NewController(GinkgoT())
+		k8sClient = fakeclient.NewClientBuilder().
+			WithScheme(testScheme).
+			WithStatusSubresource(&clusterv1.Cluster{}, &controlplanev1beta1.OpenshiftAssistedControlPlane{}, &clusterv1.Machine{}).
+			Build()
+		workloadClient = fakeclient.NewClientBuilder().
+			WithScheme(testScheme).
+			WithStatusSubresource(&configv1.Infrastructure{}).
+			WithObjects(&configv1.Infrastructure{
+				ObjectMeta: metav1.ObjectMeta{Name: infrastructureName},
+				Status: configv1.InfrastructureStatus{
+					ControlPlaneTopology:   configv1.SingleReplicaTopologyMode,
+					InfrastructureTopology: configv1.SingleReplicaTopologyMode,
+				},
+			}).
+			Build()
+
+		mockKubernetesVersionDetector := version.NewMockKubernetesVersionDetector(ctrl)
+		k8sVersion := "1.30.0"
+		mockKubernetesVersionDetector.EXPECT().GetKubernetesVersion(gomock.Any(), gomock.Any()).Return(&k8sVersion, nil).AnyTimes()
+		mockClientGenerator := workloadclient.NewMockClientGenerator(ctrl)
+		mockClientGenerator.EXPECT().GetWorkloadClusterClient(gomock.Any()).Return(workloadClient, nil).AnyTimes()
+		mockUpgrader := upgrade.NewMockClusterUpgrade(ctrl)
+		mockUpgrader.EXPECT().IsUpgradeInProgress(gomock.Any()).Return(false, nil).AnyTimes()
+		mockUpgrader.EXPECT().GetCurrentVersion(gomock.Any()).Return("4.18.0", nil).AnyTimes()
+		mockUpgrader.EXPECT().IsDesiredVersionUpdated(gomock.Any(), gomock.Any()).Return(true, nil).AnyTimes()
+		mockUpgrader.EXPECT().GetUpgradeStatus(gomock.Any()).Return(upgrade.UpgradeStatus{}, nil).AnyTimes()
+		mockUpgradeFactory := upgrade.NewMockClusterUpgradeFactory(ctrl)
+		mockUpgradeFactory.EXPECT().NewUpgrader(gomock.Any()).Return(mockUpgrader, nil).AnyTimes()
+
+		controllerReconciler = &OpenshiftAssistedControlPlaneReconciler{
+			Client:                  k8sClient,
+			Scheme:                  k8sClient.Scheme(),
+			K8sVersionDetector:      mockKubernetesVersionDetector,
+			UpgradeFactory:          mockUpgradeFactory,
+			WorkloadClientGenerator: mockClientGenerator,
+		}
+		typeNamespacedName = types.NamespacedName{
+			Name:      openshiftAssistedControlPlaneName,
+			Namespace: namespace,
+		}
+
+		machineTemplate := getMachineTemplate("infratemplate", namespace)
+		Expect(k8sClient.Create(ctx, &machineTemplate)).To(Succeed())
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}}
+		Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+		kubeconfigSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      clusterName + "-kubeconfig",
+				Namespace: namespace,
+			},
+			Data: map[string][]byte{"value": []byte("kubeconfig")},
+		}
+		Expect(k8sClient.Create(ctx, kubeconfigSecret)).To(Succeed())
+
+		cluster := testutils.NewCluster(clusterName, namespace)
+		Expect(k8sClient.Create(ctx, cluster)).To(Succeed())
+
+		By("installing a single node cluster")
+		oacp = testutils.NewOpenshiftAssistedControlPlane(namespace, openshiftAssistedControlPlaneName)
+		oacp.SetOwnerReferences([]metav1.OwnerReference{
+			*metav1.NewControllerRef(cluster, clusterv1.GroupVersion.WithKind(clusterv1.ClusterKind)),
+		})
+		oacp.Spec.MachineTemplate.InfrastructureRef = corev1.ObjectReference{
+			Kind:       "Metal3MachineTemplate",
+			Namespace:  namespace,
+			Name:       "infratemplate",
+			APIVersion: "infrastructure.cluster.x-k8s.io/v1beta1",
+		}
+		oacp.Spec.Replicas = 1
+		oacp.Status.Ready = true
+		conditions.MarkTrue(oacp, controlplanev1beta1.KubeconfigAvailableCondition)
+		Expect(k8sClient.Create(ctx, oacp)).To(Succeed())
+		reconcileControlPlane()
+		joinMachines()
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	It("should keep the single replica topology of a single node control plane", func() {
+		reconcileControlPlane()
+
+		Expect(k8sClient.Get(ctx, typeNamespacedName, oacp)).To(Succeed())
+		Expect(conditions.Get(oacp, controlplanev1beta1.ControlPlaneTopologyUpToDateCondition)).To(BeNil())
+		Expect(getInfrastructure().Status.ControlPlaneTopology).To(Equal(configv1.SingleReplicaTopologyMode))
+	})
+
+	It("should switch to a highly available topology once the new control plane machines joined", func() {
+		Expect(k8sClient.Get(ctx, typeNamespacedName, oacp)).To(Succeed())
+		oacp.Spec.Replicas = 3
+		Expect(k8sClient.Update(ctx, oacp)).To(Succeed())
+
+		By("adding the control plane machines")
+		reconcileControlPlane()
+		reconcileControlPlane()
+		Expect(listMachines()).To(HaveLen(3))
+		expectExpansionInProgress()
+
+		By("waiting for the etcd members of the new machines")
+		joinMachines()
+		Expect(workloadClient.DeleteAllOf(ctx, &corev1.Pod{}, client.InNamespace(etcd.Namespace))).To(Succeed())
+		Expect(reconcileControlPlane().RequeueAfter).To(Equal(etcdRequeueAfter))
+		expectExpansionInProgress()
+
+		By("updating the topology once etcd is healthy")
+		joinMachines()
+		reconcileControlPlane()
+		Expect(k8sClient.Get(ctx, typeNamespacedName, oacp)).To(Succeed())
+		Expect(conditions.IsTrue(oacp, controlplanev1beta1.ControlPlaneTopologyUpToDateCondition)).To(BeTrue())
+		infrastructure := getInfrastructure()
+		Expect(infrastructure.Status.ControlPlaneTopology).To(Equal(configv1.HighlyAvailableTopologyMode))
+		Expect(infrastructure.Status.InfrastructureTopology).To(Equal(configv1.HighlyAvailableTopologyMode))
+	})
+
+	It("should not change the topology of a highly available control plane", func() {
+		infrastructure := getInfrastructure()
+		infrastructure.Status.ControlPlaneTopology = configv1.HighlyAvailableTopologyMode
+		infrastructure.Status.InfrastructureTopology = configv1.HighlyAvailableTopologyMode
+		Expect(workloadClient.Status().Update(ctx, infrastructure)).To(Succeed())
+		Expect(k8sClient.Get(ctx, typeNamespacedName, oacp)).To(Succeed())
+		oacp.Spec.Replicas = 3
+		Expect(k8sClient.Update(ctx, oacp)).To(Succeed())
+
+		reconcileControlPlane()
+
+		Expect(k8sClient.Get(ctx, typeNamespacedName, oacp)).To(Succeed())
+		Expect(conditions.IsTrue(oacp, controlplanev1beta1.ControlPlaneTopologyUpToDateCondition)).To(BeTrue())
+	})
+})
@@ -0,0 +1,81 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	controlplanev1beta1 "github.com/openshift-assisted/cluster-api-agent/controlplane/api/v1beta1"
+	"github.com/openshift-assisted/cluster-api-agent/pkg/workloadclient"
+	logutil "github.com/openshift-assisted/cluster-api-agent/util/log"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// checkUpgradeHealthGates runs the upgrade health gates against the workload cluster, except the gates skipped by the
+// skip-upgrade-health-gates annotation, and returns the messages of the gates that do not pass.
+func (r *OpenshiftAssistedControlPlaneReconciler) checkUpgradeHealthGates(
+	ctx context.Context,
+	oacp *controlplanev1beta1.OpenshiftAssistedControlPlane,
+	cluster *clusterv1.Cluster,
+) ([]string, error) {
+	log := ctrl.LoggerFrom(ctx)
+	if len(r.UpgradeHealthGates) == 0 {
+		return nil, nil
+	}
+
+	workloadClient, err := workloadclient.GetWorkloadClientFromClusterName(ctx, r.Client, r.WorkloadClientGenerator, cluster.Name, cluster.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	skippedGates := GetSkippedUpgradeHealthGates(oacp)
+	failedGates := make([]string, 0)
+	for _, gate := range r.UpgradeHealthGates {
+		if slices.Contains(skippedGates, gate.Name()) {
+			log.V(logutil.InfoLevel).Info("skipping upgrade health gate", "gate", gate.Name())
+			continue
+		}
+		message, err := gate.Check(ctx, workloadClient)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check upgrade health gate %s: %w", gate.Name(), err)
+		}
+		if message != "" {
+			failedGates = append(failedGates, fmt.Sprintf("%s: %s", gate.Name(), message))
+		}
+	}
+	return failedGates, nil
+}
+
+// GetSkippedUpgradeHealthGates returns the names of the upgrade health gates listed by the skip-upgrade-health-gates
+// annotation
+func GetSkippedUpgradeHealthGates(oacp *controlplanev1beta1.OpenshiftAssistedControlPlane) []string {
+	value, ok := oacp.Annotations[controlplanev1beta1.SkipUpgradeHealthGatesAnnotation]
+	if !ok {
+		return nil
+	}
+	skippedGates := make([]string, 0)
+	for _, gate := range strings.Split(value, ",") {
+		if gate = strings.TrimSpace(gate); gate != "" {
+			skippedGates = append(skippedGates, gate)
+		}
+	}
+	return skippedGates
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	controlplanev1beta1 "github.com/openshift-assisted/cluster-api-agent/controlplane/api/v1beta1"
+	"github.com/openshift-assisted/cluster-api-agent/controlplane/internal/upgrade"
+	configv1 "github.com/openshift/api/config/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// maxUpgradeHistory is the number of upgrades kept in the upgrade history
+const maxUpgradeHistory = 10
+
+// recordUpgradeStarted adds the upgrade to spec.distributionVersion to the upgrade history, unless it is already the
+// most recent upgrade
+func recordUpgradeStarted(oacp *controlplanev1beta1.OpenshiftAssistedControlPlane, now time.Time) {
+	history := oacp.Status.UpgradeHistory
+	if len(history) > 0 && history[0].Version == oacp.Spec.DistributionVersion {
+		return
+	}
+	entry := controlplanev1beta1.UpgradeHistory{
+		State:       controlplanev1beta1.UpgradeStatePartial,
+		StartedTime: metav1.NewTime(now),
+		Version:     oacp.Spec.DistributionVersion,
+		RequestedBy: getUpgradeRequester(oacp),
+	}
+	history = append([]controlplanev1beta1.UpgradeHistory{entry}, history...)
+	if len(history) > maxUpgradeHistory {
+		history = history[:maxUpgradeHistory]
+	}
+	oacp.Status.UpgradeHistory = history
+}
+
+// syncUpgradeHistory updates the most recent upgrade of the upgrade history from the ClusterVersion history of the
+// workload cluster, until it is completed
+func syncUpgradeHistory(
+	ctx context.Context,
+	oacp *controlplanev1beta1.OpenshiftAssistedControlPlane,
+	upgrader upgrade.ClusterUpgrade,
+) error {
+	if len(oacp.Status.UpgradeHistory) == 0 ||
+		oacp.Status.UpgradeHistory[0].State == controlplanev1beta1.UpgradeStateCompleted {
+		return nil
+	}
+	clusterVersionHistory, err := upgrader.GetUpdateHistory(ctx)
+	if err != nil {
+		return err
+	}
+	entry := &oacp.Status.UpgradeHistory[0]
+	for _, update := range clusterVersionHistory {
+		if update.Version != entry.Version {
+			continue
+		}
+		entry.StartedTime = update.StartedTime
+		entry.CompletionTime = update.CompletionTime
+		entry.Image = update.Image
+		if update.State == configv1.CompletedUpdate {
+			entry.State = controlplanev1beta1.UpgradeStateCompleted
+		}
+		return nil
+	}
+	return nil
+}
+
+// getUpgradeRequester returns who requested the upgrade to spec.distributionVersion: the upgrade-requested-by
+// annotation or, when not set, the field manager that last set spec.distributionVersion
+func getUpgradeRequester(oacp *controlplanev1beta1.OpenshiftAssistedControlPlane) string {
+	if requester := oacp.Annotations[controlplanev1beta1.UpgradeRequestedByAnnotation]; requester != "" {
+		return requester
+	}
+	var requester string
+	var requestTime *metav1.Time
+	for _, entry := range oacp.ManagedFields {
+		if entry.Subresource != "" || !isManagingDistributionVersion(entry) {
+			continue
+		}
+		if requester == "" || (entry.Time != nil && (requestTime == nil || entry.Time.After(requestTime.Time))) {
+			requester = entry.Manager
+			requestTime = entry.Time
+		}
+	}
+	return requester
+}
+
+func isManagingDistributionVersion(entry metav1.ManagedFieldsEntry) bool {
+	if entry.FieldsV1 == nil {
+		return false
+	}
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal(entry.FieldsV1.Raw, &fields); err != nil {
+		return false
+	}
+	spec, ok := fields["f:spec"].(map[string]interface{})
+	if !ok {
+		return false
+	}
+	_, ok = spec["f:distributionVersion"]
+	return ok
+}
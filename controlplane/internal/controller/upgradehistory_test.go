@@ -0,0 +1,165 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	controlplanev1beta1 "github.com/openshift-assisted/cluster-api-agent/controlplane/api/v1beta1"
+	"github.com/openshift-assisted/cluster-api-agent/controlplane/internal/upgrade"
+	testutils "github.com/openshift-assisted/cluster-api-agent/test/utils"
+	configv1 "github.com/openshift/api/config/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Upgrade history", func() {
+	now := time.Date(2024, time.March, 9, 23, 0, 0, 0, time.UTC)
+
+	var oacp *controlplanev1beta1.OpenshiftAssistedControlPlane
+
+	BeforeEach(func() {
+		oacp = testutils.NewOpenshiftAssistedControlPlane("test", "test-resource")
+		oacp.Spec.DistributionVersion = "4.16.2"
+	})
+
+	managedFields := func(manager string, time time.Time, fields string) metav1.ManagedFieldsEntry {
+		return metav1.ManagedFieldsEntry{
+			Manager:    manager,
+			Operation:  metav1.ManagedFieldsOperationUpdate,
+			Time:       &metav1.Time{Time: time},
+			FieldsType: "FieldsV1",
+			FieldsV1:   &metav1.FieldsV1{Raw: []byte(fields)},
+		}
+	}
+
+	Describe("recordUpgradeStarted", func() {
+		It("adds the upgrade as the most recent one", func() {
+			oacp.Status.UpgradeHistory = []controlplanev1beta1.UpgradeHistory{
+				{State: controlplanev1beta1.UpgradeStateCompleted, Version: "4.16.1"},
+			}
+			oacp.Annotations = map[string]string{controlplanev1beta1.UpgradeRequestedByAnnotation: "jane"}
+
+			recordUpgradeStarted(oacp, now)
+			Expect(oacp.Status.UpgradeHistory).To(HaveLen(2))
+			Expect(oacp.Status.UpgradeHistory[0]).To(Equal(controlplanev1beta1.UpgradeHistory{
+				State:       controlplanev1beta1.UpgradeStatePartial,
+				StartedTime: metav1.NewTime(now),
+				Version:     "4.16.2",
+				RequestedBy: "jane",
+			}))
+		})
+
+		It("does not add an upgrade twice", func() {
+			recordUpgradeStarted(oacp, now)
+			recordUpgradeStarted(oacp, now.Add(time.Minute))
+			Expect(oacp.Status.UpgradeHistory).To(HaveLen(1))
+			Expect(oacp.Status.UpgradeHistory[0].StartedTime).To(Equal(metav1.NewTime(now)))
+		})
+
+		It("keeps the most recent upgrades only", func() {
+			for i := 0; i < maxUpgradeHistory; i++ {
+				oacp.Status.UpgradeHistory = append(oacp.Status.UpgradeHistory, controlplanev1beta1.UpgradeHistory{
+					State:   controlplanev1beta1.UpgradeStateCompleted,
+					Version: fmt.Sprintf("4.15.%d", i),
+				})
+			}
+
+			recordUpgradeStarted(oacp, now)
+			Expect(oacp.Status.UpgradeHistory).To(HaveLen(maxUpgradeHistory))
+			Expect(oacp.Status.UpgradeHistory[0].Version).To(Equal("4.16.2"))
+			Expect(oacp.Status.UpgradeHistory[maxUpgradeHistory-1].Version).To(Equal("4.15.8"))
+		})
+	})
+
+	Describe("getUpgradeRequester", func() {
+		It("returns the field manager that last set the distribution version", func() {
+			oacp.ManagedFields = []metav1.ManagedFieldsEntry{
+				managedFields("capi-controller", now.Add(-2*time.Hour), `{"f:spec":{"f:distributionVersion":{},"f:replicas":{}}}`),
+				managedFields("kubectl-edit", now.Add(-time.Hour), `{"f:spec":{"f:distributionVersion":{}}}`),
+				managedFields("kubectl-label", now, `{"f:metadata":{"f:labels":{}}}`),
+			}
+			Expect(getUpgradeRequester(oacp)).To(Equal("kubectl-edit"))
+		})
+
+		It("prefers the upgrade-requested-by annotation", func() {
+			oacp.Annotations = map[string]string{controlplanev1beta1.UpgradeRequestedByAnnotation: "jane"}
+			oacp.ManagedFields = []metav1.ManagedFieldsEntry{
+				managedFields("kubectl-edit", now, `{"f:spec":{"f:distributionVersion":{}}}`),
+			}
+			Expect(getUpgradeRequester(oacp)).To(Equal("jane"))
+		})
+
+		It("returns an empty requester when unknown", func() {
+			Expect(getUpgradeRequester(oacp)).To(BeEmpty())
+		})
+	})
+
+	Describe("syncUpgradeHistory", func() {
+		var (
+			ctx          context.Context
+			mockCtrl     *gomock.Controller
+			mockUpgrader *upgrade.MockClusterUpgrade
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			mockCtrl = gomock.NewController(GinkgoT())
+			mockUpgrader = upgrade.NewMockClusterUpgrade(mockCtrl)
+			recordUpgradeStarted(oacp, now)
+		})
+
+		AfterEach(func() {
+			mockCtrl.Finish()
+		})
+
+		It("mirrors the ClusterVersion history of the upgrade", func() {
+			startedTime := metav1.NewTime(now.Add(time.Minute))
+			completionTime := metav1.NewTime(now.Add(time.Hour))
+			image := "quay.io/openshift-release-dev/ocp-release@sha256:0123"
+			mockUpgrader.EXPECT().GetUpdateHistory(ctx).Return([]configv1.UpdateHistory{
+				{State: configv1.CompletedUpdate, Version: "4.16.2", Image: image, StartedTime: startedTime, CompletionTime: &completionTime},
+				{State: configv1.CompletedUpdate, Version: "4.16.1"},
+			}, nil)
+
+			Expect(syncUpgradeHistory(ctx, oacp, mockUpgrader)).To(Succeed())
+			Expect(oacp.Status.UpgradeHistory[0].State).To(Equal(controlplanev1beta1.UpgradeStateCompleted))
+			Expect(oacp.Status.UpgradeHistory[0].StartedTime).To(Equal(startedTime))
+			Expect(oacp.Status.UpgradeHistory[0].CompletionTime).To(HaveValue(Equal(completionTime)))
+			Expect(oacp.Status.UpgradeHistory[0].Image).To(Equal(image))
+		})
+
+		It("keeps the upgrade partial until the ClusterVersion completes it", func() {
+			mockUpgrader.EXPECT().GetUpdateHistory(ctx).Return([]configv1.UpdateHistory{
+				{State: configv1.PartialUpdate, Version: "4.16.2"},
+			}, nil)
+
+			Expect(syncUpgradeHistory(ctx, oacp, mockUpgrader)).To(Succeed())
+			Expect(oacp.Status.UpgradeHistory[0].State).To(Equal(controlplanev1beta1.UpgradeStatePartial))
+			Expect(oacp.Status.UpgradeHistory[0].CompletionTime).To(BeNil())
+		})
+
+		It("does not query the ClusterVersion once the upgrade is completed", func() {
+			oacp.Status.UpgradeHistory[0].State = controlplanev1beta1.UpgradeStateCompleted
+			Expect(syncUpgradeHistory(ctx, oacp, mockUpgrader)).To(Succeed())
+		})
+	})
+})
@@ -0,0 +1,174 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+
+	controlplanev1beta1 "github.com/openshift-assisted/cluster-api-agent/controlplane/api/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	v1beta2conditions "sigs.k8s.io/cluster-api/util/conditions/v1beta2"
+)
+
+// v1beta2OwnedConditions are the v1beta2 conditions set by the OpenshiftAssistedControlPlane controller
+var v1beta2OwnedConditions = []string{
+	controlplanev1beta1.OpenshiftAssistedControlPlaneAvailableV1Beta2Condition,
+	controlplanev1beta1.OpenshiftAssistedControlPlaneRollingOutV1Beta2Condition,
+	controlplanev1beta1.OpenshiftAssistedControlPlaneScalingUpV1Beta2Condition,
+	controlplanev1beta1.OpenshiftAssistedControlPlaneScalingDownV1Beta2Condition,
+	controlplanev1beta1.OpenshiftAssistedControlPlaneRemediatingV1Beta2Condition,
+	controlplanev1beta1.OpenshiftAssistedControlPlaneDeletingV1Beta2Condition,
+}
+
+// setV1Beta2Conditions sets the v1beta2 conditions of the OpenshiftAssistedControlPlane from its status
+func setV1Beta2Conditions(oacp *controlplanev1beta1.OpenshiftAssistedControlPlane) {
+	setAvailableV1Beta2Condition(oacp)
+	setRollingOutV1Beta2Condition(oacp)
+	setScalingUpV1Beta2Condition(oacp)
+	setScalingDownV1Beta2Condition(oacp)
+	setRemediatingV1Beta2Condition(oacp)
+	setDeletingV1Beta2Condition(oacp)
+}
+
+func setAvailableV1Beta2Condition(oacp *controlplanev1beta1.OpenshiftAssistedControlPlane) {
+	if !oacp.Status.Initialized {
+		v1beta2conditions.Set(oacp, metav1.Condition{
+			Type:    controlplanev1beta1.OpenshiftAssistedControlPlaneAvailableV1Beta2Condition,
+			Status:  metav1.ConditionFalse,
+			Reason:  controlplanev1beta1.OpenshiftAssistedControlPlaneNotInitializedV1Beta2Reason,
+			Message: "Control plane not yet initialized",
+		})
+		return
+	}
+	// a majority of the control plane machines is required to keep etcd quorum
+	quorum := oacp.Spec.Replicas/2 + 1
+	if oacp.Status.ReadyReplicas < quorum {
+		v1beta2conditions.Set(oacp, metav1.Condition{
+			Type:   controlplanev1beta1.OpenshiftAssistedControlPlaneAvailableV1Beta2Condition,
+			Status: metav1.ConditionFalse,
+			Reason: controlplanev1beta1.OpenshiftAssistedControlPlaneNotAvailableV1Beta2Reason,
+			Message: fmt.Sprintf("%d of %d control plane machines ready, at least %d required",
+				oacp.Status.ReadyReplicas, oacp.Spec.Replicas, quorum),
+		})
+		return
+	}
+	v1beta2conditions.Set(oacp, metav1.Condition{
+		Type:   controlplanev1beta1.OpenshiftAssistedControlPlaneAvailableV1Beta2Condition,
+		Status: metav1.ConditionTrue,
+		Reason: controlplanev1beta1.OpenshiftAssistedControlPlaneAvailableV1Beta2Reason,
+	})
+}
+
+func setRollingOutV1Beta2Condition(oacp *controlplanev1beta1.OpenshiftAssistedControlPlane) {
+	if notUpToDate := oacp.Status.Replicas - oacp.Status.UpdatedReplicas; notUpToDate > 0 {
+		v1beta2conditions.Set(oacp, metav1.Condition{
+			Type:    controlplanev1beta1.OpenshiftAssistedControlPlaneRollingOutV1Beta2Condition,
+			Status:  metav1.ConditionTrue,
+			Reason:  controlplanev1beta1.OpenshiftAssistedControlPlaneRollingOutV1Beta2Reason,
+			Message: fmt.Sprintf("Rolling out %d not up-to-date replicas", notUpToDate),
+		})
+		return
+	}
+	v1beta2conditions.Set(oacp, metav1.Condition{
+		Type:   controlplanev1beta1.OpenshiftAssistedControlPlaneRollingOutV1Beta2Condition,
+		Status: metav1.ConditionFalse,
+		Reason: controlplanev1beta1.OpenshiftAssistedControlPlaneNotRollingOutV1Beta2Reason,
+	})
+}
+
+func setScalingUpV1Beta2Condition(oacp *controlplanev1beta1.OpenshiftAssistedControlPlane) {
+	if oacp.DeletionTimestamp.IsZero() && oacp.Status.Replicas < oacp.Spec.Replicas {
+		v1beta2conditions.Set(oacp, metav1.Condition{
+			Type:    controlplanev1beta1.OpenshiftAssistedControlPlaneScalingUpV1Beta2Condition,
+			Status:  metav1.ConditionTrue,
+			Reason:  controlplanev1beta1.OpenshiftAssistedControlPlaneScalingUpV1Beta2Reason,
+			Message: fmt.Sprintf("Scaling up from %d to %d replicas", oacp.Status.Replicas, oacp.Spec.Replicas),
+		})
+		return
+	}
+	v1beta2conditions.Set(oacp, metav1.Condition{
+		Type:   controlplanev1beta1.OpenshiftAssistedControlPlaneScalingUpV1Beta2Condition,
+		Status: metav1.ConditionFalse,
+		Reason: controlplanev1beta1.OpenshiftAssistedControlPlaneNotScalingUpV1Beta2Reason,
+	})
+}
+
+func setScalingDownV1Beta2Condition(oacp *controlplanev1beta1.OpenshiftAssistedControlPlane) {
+	desiredReplicas := oacp.Spec.Replicas
+	if !oacp.DeletionTimestamp.IsZero() {
+		desiredReplicas = 0
+	}
+	if oacp.Status.Replicas > desiredReplicas {
+		message := fmt.Sprintf("Scaling down from %d to %d replicas", oacp.Status.Replicas, desiredReplicas)
+		if conditions.IsFalse(oacp, controlplanev1beta1.ScaleDownAllowedCondition) {
+			message = fmt.Sprintf("%s, blocked: %s", message,
+				conditions.GetMessage(oacp, controlplanev1beta1.ScaleDownAllowedCondition))
+		}
+		v1beta2conditions.Set(oacp, metav1.Condition{
+			Type:    controlplanev1beta1.OpenshiftAssistedControlPlaneScalingDownV1Beta2Condition,
+			Status:  metav1.ConditionTrue,
+			Reason:  controlplanev1beta1.OpenshiftAssistedControlPlaneScalingDownV1Beta2Reason,
+			Message: message,
+		})
+		return
+	}
+	v1beta2conditions.Set(oacp, metav1.Condition{
+		Type:   controlplanev1beta1.OpenshiftAssistedControlPlaneScalingDownV1Beta2Condition,
+		Status: metav1.ConditionFalse,
+		Reason: controlplanev1beta1.OpenshiftAssistedControlPlaneNotScalingDownV1Beta2Reason,
+	})
+}
+
+func setRemediatingV1Beta2Condition(oacp *controlplanev1beta1.OpenshiftAssistedControlPlane) {
+	if _, ok := oacp.Annotations[controlplanev1beta1.RemediationInProgressAnnotation]; ok {
+		message := "Remediation in progress"
+		if oacp.Status.LastRemediation != nil {
+			message = fmt.Sprintf("Remediating machine %s, retry %d",
+				oacp.Status.LastRemediation.Machine, oacp.Status.LastRemediation.RetryCount)
+		}
+		v1beta2conditions.Set(oacp, metav1.Condition{
+			Type:    controlplanev1beta1.OpenshiftAssistedControlPlaneRemediatingV1Beta2Condition,
+			Status:  metav1.ConditionTrue,
+			Reason:  controlplanev1beta1.OpenshiftAssistedControlPlaneRemediatingV1Beta2Reason,
+			Message: message,
+		})
+		return
+	}
+	v1beta2conditions.Set(oacp, metav1.Condition{
+		Type:   controlplanev1beta1.OpenshiftAssistedControlPlaneRemediatingV1Beta2Condition,
+		Status: metav1.ConditionFalse,
+		Reason: controlplanev1beta1.OpenshiftAssistedControlPlaneNotRemediatingV1Beta2Reason,
+	})
+}
+
+func setDeletingV1Beta2Condition(oacp *controlplanev1beta1.OpenshiftAssistedControlPlane) {
+	if !oacp.DeletionTimestamp.IsZero() {
+		v1beta2conditions.Set(oacp, metav1.Condition{
+			Type:    controlplanev1beta1.OpenshiftAssistedControlPlaneDeletingV1Beta2Condition,
+			Status:  metav1.ConditionTrue,
+			Reason:  controlplanev1beta1.OpenshiftAssistedControlPlaneDeletingV1Beta2Reason,
+			Message: "Deleting the ClusterDeployment and the control plane machines",
+		})
+		return
+	}
+	v1beta2conditions.Set(oacp, metav1.Condition{
+		Type:   controlplanev1beta1.OpenshiftAssistedControlPlaneDeletingV1Beta2Condition,
+		Status: metav1.ConditionFalse,
+		Reason: controlplanev1beta1.OpenshiftAssistedControlPlaneNotDeletingV1Beta2Reason,
+	})
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	controlplanev1beta1 "github.com/openshift-assisted/cluster-api-agent/controlplane/api/v1beta1"
+	testutils "github.com/openshift-assisted/cluster-api-agent/test/utils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	v1beta2conditions "sigs.k8s.io/cluster-api/util/conditions/v1beta2"
+)
+
+var _ = Describe("v1beta2 conditions", func() {
+	var oacp *controlplanev1beta1.OpenshiftAssistedControlPlane
+
+	BeforeEach(func() {
+		oacp = testutils.NewOpenshiftAssistedControlPlane("test", "test-resource")
+		oacp.Spec.Replicas = 3
+		oacp.Status.Initialized = true
+		oacp.Status.Replicas = 3
+		oacp.Status.ReadyReplicas = 3
+		oacp.Status.UpdatedReplicas = 3
+	})
+
+	expectCondition := func(conditionType string, status metav1.ConditionStatus, reason string) {
+		condition := v1beta2conditions.Get(oacp, conditionType)
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Status).To(Equal(status))
+		Expect(condition.Reason).To(Equal(reason))
+	}
+
+	It("should report an available control plane at rest", func() {
+		setV1Beta2Conditions(oacp)
+
+		expectCondition(controlplanev1beta1.OpenshiftAssistedControlPlaneAvailableV1Beta2Condition,
+			metav1.ConditionTrue, controlplanev1beta1.OpenshiftAssistedControlPlaneAvailableV1Beta2Reason)
+		expectCondition(controlplanev1beta1.OpenshiftAssistedControlPlaneRollingOutV1Beta2Condition,
+			metav1.ConditionFalse, controlplanev1beta1.OpenshiftAssistedControlPlaneNotRollingOutV1Beta2Reason)
+		expectCondition(controlplanev1beta1.OpenshiftAssistedControlPlaneScalingUpV1Beta2Condition,
+			metav1.ConditionFalse, controlplanev1beta1.OpenshiftAssistedControlPlaneNotScalingUpV1Beta2Reason)
+		expectCondition(controlplanev1beta1.OpenshiftAssistedControlPlaneScalingDownV1Beta2Condition,
+			metav1.ConditionFalse, controlplanev1beta1.OpenshiftAssistedControlPlaneNotScalingDownV1Beta2Reason)
+		expectCondition(controlplanev1beta1.OpenshiftAssistedControlPlaneRemediatingV1Beta2Condition,
+			metav1.ConditionFalse, controlplanev1beta1.OpenshiftAssistedControlPlaneNotRemediatingV1Beta2Reason)
+		expectCondition(controlplanev1beta1.OpenshiftAssistedControlPlaneDeletingV1Beta2Condition,
+			metav1.ConditionFalse, controlplanev1beta1.OpenshiftAssistedControlPlaneNotDeletingV1Beta2Reason)
+	})
+
+	It("should report a control plane without etcd quorum as not available", func() {
+		oacp.Status.ReadyReplicas = 1
+
+		setV1Beta2Conditions(oacp)
+
+		expectCondition(controlplanev1beta1.OpenshiftAssistedControlPlaneAvailableV1Beta2Condition,
+			metav1.ConditionFalse, controlplanev1beta1.OpenshiftAssistedControlPlaneNotAvailableV1Beta2Reason)
+	})
+
+	It("should report a rollout and a scale down", func() {
+		oacp.Status.Replicas = 4
+		oacp.Status.UpdatedReplicas = 1
+
+		setV1Beta2Conditions(oacp)
+
+		expectCondition(controlplanev1beta1.OpenshiftAssistedControlPlaneRollingOutV1Beta2Condition,
+			metav1.ConditionTrue, controlplanev1beta1.OpenshiftAssistedControlPlaneRollingOutV1Beta2Reason)
+		Expect(v1beta2conditions.Get(oacp, controlplanev1beta1.OpenshiftAssistedControlPlaneRollingOutV1Beta2Condition).Message).
+			To(Equal("Rolling out 3 not up-to-date replicas"))
+		expectCondition(controlplanev1beta1.OpenshiftAssistedControlPlaneScalingDownV1Beta2Condition,
+			metav1.ConditionTrue, controlplanev1beta1.OpenshiftAssistedControlPlaneScalingDownV1Beta2Reason)
+	})
+
+	It("should report a scale up", func() {
+		oacp.Status.Replicas = 1
+
+		setV1Beta2Conditions(oacp)
+
+		expectCondition(controlplanev1beta1.OpenshiftAssistedControlPlaneScalingUpV1Beta2Condition,
+			metav1.ConditionTrue, controlplanev1beta1.OpenshiftAssistedControlPlaneScalingUpV1Beta2Reason)
+		Expect(v1beta2conditions.Get(oacp, controlplanev1beta1.OpenshiftAssistedControlPlaneScalingUpV1Beta2Condition).Message).
+			To(Equal("Scaling up from 1 to 3 replicas"))
+	})
+
+	It("should report a remediation in progress", func() {
+		oacp.Annotations = map[string]string{controlplanev1beta1.RemediationInProgressAnnotation: ""}
+		oacp.Status.LastRemediation = &controlplanev1beta1.LastRemediationStatus{Machine: "test-resource-abcde", RetryCount: 1}
+
+		setV1Beta2Conditions(oacp)
+
+		expectCondition(controlplanev1beta1.OpenshiftAssistedControlPlaneRemediatingV1Beta2Condition,
+			metav1.ConditionTrue, controlplanev1beta1.OpenshiftAssistedControlPlaneRemediatingV1Beta2Reason)
+		Expect(v1beta2conditions.Get(oacp, controlplanev1beta1.OpenshiftAssistedControlPlaneRemediatingV1Beta2Condition).Message).
+			To(Equal("Remediating machine test-resource-abcde, retry 1"))
+	})
+
+	It("should report a deletion", func() {
+		oacp.DeletionTimestamp = ptr.To(metav1.Now())
+
+		setV1Beta2Conditions(oacp)
+
+		expectCondition(controlplanev1beta1.OpenshiftAssistedControlPlaneDeletingV1Beta2Condition,
+			metav1.ConditionTrue, controlplanev1beta1.OpenshiftAssistedControlPlaneDeletingV1Beta2Reason)
+		expectCondition(controlplanev1beta1.OpenshiftAssistedControlPlaneScalingDownV1Beta2Condition,
+			metav1.ConditionTrue, controlplanev1beta1.OpenshiftAssistedControlPlaneScalingDownV1Beta2Reason)
+	})
+})
@@ -0,0 +1,96 @@
+package etcd
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// Namespace is the namespace of the etcd static pods on OpenShift clusters
+	Namespace = "openshift-etcd"
+	// podAppLabel is the label identifying the etcd static pods, as opposed to the etcd guard pods
+	podAppLabel      = "app"
+	podAppLabelValue = "etcd"
+)
+
+// CanSafelyRemoveMember returns true if the etcd member running on the given node can be removed without losing quorum,
+// i.e. if the healthy members left are a majority of the remaining members.
+// Each control plane node runs an etcd static pod, whose readiness reflects the health of its member.
+func CanSafelyRemoveMember(ctx context.Context, workloadClient client.Client, nodeName string) (bool, error) {
+	pods, err := getMemberPods(ctx, workloadClient)
+	if err != nil {
+		return false, err
+	}
+
+	members := 0
+	healthyMembers := 0
+	for _, pod := range pods {
+		if pod.Spec.NodeName == nodeName {
+			continue
+		}
+		members++
+		if isPodReady(pod) {
+			healthyMembers++
+		}
+	}
+	return healthyMembers >= members/2+1, nil
+}
+
+// IsHealthy returns true if every etcd member of the cluster is healthy
+func IsHealthy(ctx context.Context, workloadClient client.Client) (bool, error) {
+	return HasHealthyMembers(ctx, workloadClient, 1)
+}
+
+// HasHealthyMembers returns true if the etcd cluster has at least the given number of members, all of them healthy
+func HasHealthyMembers(ctx context.Context, workloadClient client.Client, members int) (bool, error) {
+	pods, err := getMemberPods(ctx, workloadClient)
+	if err != nil {
+		return false, err
+	}
+	if len(pods) < members {
+		return false, nil
+	}
+	for _, pod := range pods {
+		if !isPodReady(pod) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// RemoveNodeMember removes the etcd member running on the given node, if any.
+// Members are named after the node they run on.
+func RemoveNodeMember(ctx context.Context, memberClient MemberClient, nodeName string) error {
+	members, err := memberClient.ListMembers(ctx)
+	if err != nil {
+		return err
+	}
+	for _, member := range members {
+		if member.Name == nodeName {
+			return memberClient.RemoveMember(ctx, member)
+		}
+	}
+	return nil
+}
+
+func getMemberPods(ctx context.Context, workloadClient client.Client) ([]corev1.Pod, error) {
+	pods := &corev1.PodList{}
+	if err := workloadClient.List(ctx, pods,
+		client.InNamespace(Namespace),
+		client.MatchingLabels{podAppLabel: podAppLabelValue},
+	); err != nil {
+		return nil, err
+	}
+	return pods.Items, nil
+}
+
+func isPodReady(pod corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
@@ -0,0 +1,219 @@
+package etcd_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/openshift-assisted/cluster-api-agent/controlplane/internal/etcd"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Etcd quorum", func() {
+	var (
+		ctx        context.Context
+		fakeClient client.Client
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		fakeClient = fake.NewClientBuilder().WithScheme(testScheme).Build()
+	})
+
+	createMemberPod := func(nodeName string, ready bool) {
+		status := corev1.ConditionFalse
+		if ready {
+			status = corev1.ConditionTrue
+		}
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "etcd-" + nodeName,
+				Namespace: etcd.Namespace,
+				Labels:    map[string]string{"app": "etcd"},
+			},
+			Spec: corev1.PodSpec{NodeName: nodeName},
+			Status: corev1.PodStatus{
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: status}},
+			},
+		}
+		Expect(fakeClient.Create(ctx, pod)).To(Succeed())
+	}
+
+	It("allows removing a member when the other members are healthy", func() {
+		createMemberPod("master-0", true)
+		createMemberPod("master-1", true)
+		createMemberPod("master-2", true)
+
+		ok, err := etcd.CanSafelyRemoveMember(ctx, fakeClient, "master-0")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+	})
+
+	It("allows removing an unhealthy member when the other members are healthy", func() {
+		createMemberPod("master-0", false)
+		createMemberPod("master-1", true)
+		createMemberPod("master-2", true)
+
+		ok, err := etcd.CanSafelyRemoveMember(ctx, fakeClient, "master-0")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+	})
+
+	It("refuses removing a member when another member is unhealthy", func() {
+		createMemberPod("master-0", true)
+		createMemberPod("master-1", false)
+		createMemberPod("master-2", true)
+
+		ok, err := etcd.CanSafelyRemoveMember(ctx, fakeClient, "master-0")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeFalse())
+	})
+
+	It("allows removing a machine without member when quorum is kept", func() {
+		createMemberPod("master-0", true)
+		createMemberPod("master-1", true)
+		createMemberPod("master-2", false)
+
+		ok, err := etcd.CanSafelyRemoveMember(ctx, fakeClient, "master-3")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+	})
+
+	It("ignores pods that are not etcd members", func() {
+		createMemberPod("master-0", true)
+		createMemberPod("master-1", true)
+		guard := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "etcd-guard-master-1",
+				Namespace: etcd.Namespace,
+				Labels:    map[string]string{"app": "guard"},
+			},
+			Spec: corev1.PodSpec{NodeName: "master-1"},
+		}
+		Expect(fakeClient.Create(ctx, guard)).To(Succeed())
+
+		ok, err := etcd.CanSafelyRemoveMember(ctx, fakeClient, "master-0")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+	})
+
+	It("refuses removing the last member", func() {
+		createMemberPod("master-0", true)
+
+		ok, err := etcd.CanSafelyRemoveMember(ctx, fakeClient, "master-0")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeFalse())
+	})
+})
+
+var _ = Describe("Etcd health", func() {
+	var (
+		ctx        context.Context
+		fakeClient client.Client
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		fakeClient = fake.NewClientBuilder().WithScheme(testScheme).Build()
+	})
+
+	createMemberPod := func(nodeName string, ready bool) {
+		status := corev1.ConditionFalse
+		if ready {
+			status = corev1.ConditionTrue
+		}
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "etcd-" + nodeName,
+				Namespace: etcd.Namespace,
+				Labels:    map[string]string{"app": "etcd"},
+			},
+			Spec: corev1.PodSpec{NodeName: nodeName},
+			Status: corev1.PodStatus{
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: status}},
+			},
+		}
+		Expect(fakeClient.Create(ctx, pod)).To(Succeed())
+	}
+
+	It("reports a healthy cluster when all members are ready", func() {
+		createMemberPod("master-0", true)
+		createMemberPod("master-1", true)
+
+		healthy, err := etcd.IsHealthy(ctx, fakeClient)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(healthy).To(BeTrue())
+	})
+
+	It("reports an unhealthy cluster when a member is not ready", func() {
+		createMemberPod("master-0", true)
+		createMemberPod("master-1", false)
+
+		healthy, err := etcd.IsHealthy(ctx, fakeClient)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(healthy).To(BeFalse())
+	})
+
+	It("reports an unhealthy cluster when no member is found", func() {
+		healthy, err := etcd.IsHealthy(ctx, fakeClient)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(healthy).To(BeFalse())
+	})
+
+	It("waits for the expected number of healthy members", func() {
+		createMemberPod("master-0", true)
+		createMemberPod("master-1", true)
+
+		healthy, err := etcd.HasHealthyMembers(ctx, fakeClient, 3)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(healthy).To(BeFalse())
+
+		createMemberPod("master-2", true)
+		healthy, err = etcd.HasHealthyMembers(ctx, fakeClient, 3)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(healthy).To(BeTrue())
+	})
+})
+
+var _ = Describe("Etcd member removal", func() {
+	var (
+		ctx              context.Context
+		ctrl             *gomock.Controller
+		mockMemberClient *etcd.MockMemberClient
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		ctrl = gomock.NewController(GinkgoT())
+		mockMemberClient = etcd.NewMockMemberClient(ctrl)
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	It("removes the member named after the node", func() {
+		members := []etcd.Member{{ID: 1, Name: "master-0"}, {ID: 2, Name: "master-1"}}
+		mockMemberClient.EXPECT().ListMembers(ctx).Return(members, nil)
+		mockMemberClient.EXPECT().RemoveMember(ctx, members[1]).Return(nil)
+
+		Expect(etcd.RemoveNodeMember(ctx, mockMemberClient, "master-1")).To(Succeed())
+	})
+
+	It("does nothing when the node does not run a member", func() {
+		mockMemberClient.EXPECT().ListMembers(ctx).Return([]etcd.Member{{ID: 1, Name: "master-0"}}, nil)
+
+		Expect(etcd.RemoveNodeMember(ctx, mockMemberClient, "master-1")).To(Succeed())
+	})
+
+	It("fails when members cannot be listed", func() {
+		mockMemberClient.EXPECT().ListMembers(ctx).Return(nil, errors.New("etcdctl failed"))
+
+		Expect(etcd.RemoveNodeMember(ctx, mockMemberClient, "master-1")).NotTo(Succeed())
+	})
+})
@@ -0,0 +1,139 @@
+package etcd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// etcdctlContainer is the container of the etcd static pods configured to run etcdctl against the local member
+const etcdctlContainer = "etcdctl"
+
+// Member is an etcd member, as reported by etcdctl
+type Member struct {
+	ID         uint64   `json:"ID"`
+	Name       string   `json:"name"`
+	PeerURLs   []string `json:"peerURLs"`
+	ClientURLs []string `json:"clientURLs"`
+}
+
+type memberListResponse struct {
+	Members []Member `json:"members"`
+}
+
+//go:generate mockgen -destination=mock_members.go -package=etcd -source members.go MemberClient MemberClientGenerator
+type MemberClient interface {
+	// ListMembers returns the members of the etcd cluster
+	ListMembers(ctx context.Context) ([]Member, error)
+	// RemoveMember removes the member from the etcd cluster
+	RemoveMember(ctx context.Context, member Member) error
+}
+
+type MemberClientGenerator interface {
+	GetMemberClient(kubeconfig []byte) (MemberClient, error)
+}
+
+type WorkloadMemberClientGenerator struct{}
+
+func NewMemberClientGenerator() *WorkloadMemberClientGenerator {
+	return &WorkloadMemberClientGenerator{}
+}
+
+func (g *WorkloadMemberClientGenerator) GetMemberClient(kubeconfig []byte) (MemberClient, error) {
+	restConfig, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return nil, errors.Join(err, fmt.Errorf("failed to get restconfig for kube client"))
+	}
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	return &execMemberClient{restConfig: restConfig, clientset: clientset}, nil
+}
+
+// execMemberClient manages the etcd members by running etcdctl in the etcd static pods of the workload cluster,
+// as the etcd client endpoints are not reachable from the management cluster.
+type execMemberClient struct {
+	restConfig *rest.Config
+	clientset  kubernetes.Interface
+}
+
+func (c *execMemberClient) ListMembers(ctx context.Context) ([]Member, error) {
+	pod, err := c.getExecPod(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	out, err := c.exec(ctx, pod, "etcdctl", "member", "list", "-w", "json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list etcd members: %w", err)
+	}
+	response := memberListResponse{}
+	if err := json.Unmarshal(out, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse etcd member list: %w", err)
+	}
+	return response.Members, nil
+}
+
+func (c *execMemberClient) RemoveMember(ctx context.Context, member Member) error {
+	// etcdctl cannot run from the member being removed
+	pod, err := c.getExecPod(ctx, member.Name)
+	if err != nil {
+		return err
+	}
+	if _, err := c.exec(ctx, pod, "etcdctl", "member", "remove", strconv.FormatUint(member.ID, 16)); err != nil {
+		return fmt.Errorf("failed to remove etcd member %s: %w", member.Name, err)
+	}
+	return nil
+}
+
+// getExecPod returns a ready etcd pod, not running on the excluded node
+func (c *execMemberClient) getExecPod(ctx context.Context, excludedNodeName string) (*corev1.Pod, error) {
+	pods, err := c.clientset.CoreV1().Pods(Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(labels.Set{podAppLabel: podAppLabelValue}).String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Spec.NodeName != excludedNodeName && isPodReady(*pod) {
+			return pod, nil
+		}
+	}
+	return nil, errors.New("no ready etcd pod found")
+}
+
+func (c *execMemberClient) exec(ctx context.Context, pod *corev1.Pod, command ...string) ([]byte, error) {
+	req := c.clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(pod.Namespace).
+		Name(pod.Name).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: etcdctlContainer,
+			Command:   command,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+	executor, err := remotecommand.NewSPDYExecutor(c.restConfig, "POST", req.URL())
+	if err != nil {
+		return nil, err
+	}
+	var stdout, stderr bytes.Buffer
+	if err := executor.StreamWithContext(ctx, remotecommand.StreamOptions{Stdout: &stdout, Stderr: &stderr}); err != nil {
+		return nil, errors.Join(err, fmt.Errorf("%s", stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
@@ -0,0 +1,102 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: members.go
+
+// Package etcd is a generated GoMock package.
+package etcd
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockMemberClient is a mock of MemberClient interface.
+type MockMemberClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockMemberClientMockRecorder
+}
+
+// MockMemberClientMockRecorder is the mock recorder for MockMemberClient.
+type MockMemberClientMockRecorder struct {
+	mock *MockMemberClient
+}
+
+// NewMockMemberClient creates a new mock instance.
+func NewMockMemberClient(ctrl *gomock.Controller) *MockMemberClient {
+	mock := &MockMemberClient{ctrl: ctrl}
+	mock.recorder = &MockMemberClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMemberClient) EXPECT() *MockMemberClientMockRecorder {
+	return m.recorder
+}
+
+// ListMembers mocks base method.
+func (m *MockMemberClient) ListMembers(ctx context.Context) ([]Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMembers", ctx)
+	ret0, _ := ret[0].([]Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMembers indicates an expected call of ListMembers.
+func (mr *MockMemberClientMockRecorder) ListMembers(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMembers", reflect.TypeOf((*MockMemberClient)(nil).ListMembers), ctx)
+}
+
+// RemoveMember mocks base method.
+func (m *MockMemberClient) RemoveMember(ctx context.Context, member Member) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMember", ctx, member)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveMember indicates an expected call of RemoveMember.
+func (mr *MockMemberClientMockRecorder) RemoveMember(ctx, member interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*MockMemberClient)(nil).RemoveMember), ctx, member)
+}
+
+// MockMemberClientGenerator is a mock of MemberClientGenerator interface.
+type MockMemberClientGenerator struct {
+	ctrl     *gomock.Controller
+	recorder *MockMemberClientGeneratorMockRecorder
+}
+
+// MockMemberClientGeneratorMockRecorder is the mock recorder for MockMemberClientGenerator.
+type MockMemberClientGeneratorMockRecorder struct {
+	mock *MockMemberClientGenerator
+}
+
+// NewMockMemberClientGenerator creates a new mock instance.
+func NewMockMemberClientGenerator(ctrl *gomock.Controller) *MockMemberClientGenerator {
+	mock := &MockMemberClientGenerator{ctrl: ctrl}
+	mock.recorder = &MockMemberClientGeneratorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMemberClientGenerator) EXPECT() *MockMemberClientGeneratorMockRecorder {
+	return m.recorder
+}
+
+// GetMemberClient mocks base method.
+func (m *MockMemberClientGenerator) GetMemberClient(kubeconfig []byte) (MemberClient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMemberClient", kubeconfig)
+	ret0, _ := ret[0].(MemberClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMemberClient indicates an expected call of GetMemberClient.
+func (mr *MockMemberClientGeneratorMockRecorder) GetMemberClient(kubeconfig interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMemberClient", reflect.TypeOf((*MockMemberClientGenerator)(nil).GetMemberClient), kubeconfig)
+}
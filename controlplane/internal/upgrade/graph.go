@@ -0,0 +1,254 @@
+package upgrade
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/blang/semver/v4"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// UpgradeGraphConfigMapKey is the key of the ConfigMap holding the update graph
+	UpgradeGraphConfigMapKey = "graph.json"
+	// DefaultUpgradeChannel is the channel of the update graph queried when none is configured
+	DefaultUpgradeChannel = "stable"
+
+	upgradeGraphRequestTimeout = 30 * time.Second
+)
+
+// UpgradeGraph is an OpenShift update graph, in the format served by the Cincinnati graph API of the
+// OpenShift Update Service (OSUS)
+type UpgradeGraph struct {
+	Nodes            []UpgradeGraphNode            `json:"nodes"`
+	Edges            [][2]int                      `json:"edges"`
+	ConditionalEdges []UpgradeGraphConditionalEdge `json:"conditionalEdges,omitempty"`
+}
+
+type UpgradeGraphNode struct {
+	Version  string            `json:"version"`
+	Payload  string            `json:"payload"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// UpgradeGraphConditionalEdge lists edges that are only recommended when the cluster is not exposed to the risks
+type UpgradeGraphConditionalEdge struct {
+	Edges []UpgradeGraphEdge `json:"edges"`
+	Risks []UpgradeGraphRisk `json:"risks"`
+}
+
+type UpgradeGraphEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type UpgradeGraphRisk struct {
+	URL     string `json:"url"`
+	Name    string `json:"name"`
+	Message string `json:"message"`
+}
+
+// UpgradePathReason is the reason why an upgrade path is refused
+type UpgradePathReason string
+
+const (
+	UpgradePathDowngrade         UpgradePathReason = "Downgrade"
+	UpgradePathSkipsMinorVersion UpgradePathReason = "SkipsMinorVersion"
+	UpgradePathBlocked           UpgradePathReason = "Blocked"
+	UpgradePathInvalidVersion    UpgradePathReason = "InvalidVersion"
+)
+
+// UpgradePathError is returned when the upgrade from the current version to the desired version is not allowed
+type UpgradePathError struct {
+	Reason  UpgradePathReason
+	Message string
+}
+
+func (e *UpgradePathError) Error() string {
+	return e.Message
+}
+
+//go:generate mockgen -destination=mock_graph.go -package=upgrade -source graph.go UpgradeGraphProvider
+type UpgradeGraphProvider interface {
+	GetUpgradeGraph(ctx context.Context, channel string, architecture string) (*UpgradeGraph, error)
+}
+
+// NewURLUpgradeGraphProvider returns a provider querying the graph API of an OpenShift Update Service
+func NewURLUpgradeGraphProvider(graphURL string) *URLUpgradeGraphProvider {
+	return &URLUpgradeGraphProvider{
+		url:        graphURL,
+		httpClient: &http.Client{Timeout: upgradeGraphRequestTimeout},
+	}
+}
+
+type URLUpgradeGraphProvider struct {
+	url        string
+	httpClient *http.Client
+}
+
+func (p *URLUpgradeGraphProvider) GetUpgradeGraph(ctx context.Context, channel string, architecture string) (*UpgradeGraph, error) {
+	graphURL, err := url.Parse(p.url)
+	if err != nil {
+		return nil, fmt.Errorf("invalid update graph URL %s: %w", p.url, err)
+	}
+	query := graphURL.Query()
+	query.Set("channel", channel)
+	query.Set("arch", getGraphArchitecture(architecture))
+	graphURL.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, graphURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get update graph: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get update graph from %s: unexpected status %s", graphURL.String(), resp.Status)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read update graph: %w", err)
+	}
+	return parseUpgradeGraph(body)
+}
+
+// NewConfigMapUpgradeGraphProvider returns a provider reading the update graph from a ConfigMap, for disconnected
+// environments without an OpenShift Update Service. The graph is expected to cover the channels of the clusters.
+func NewConfigMapUpgradeGraphProvider(c client.Reader, namespace, name string) *ConfigMapUpgradeGraphProvider {
+	return &ConfigMapUpgradeGraphProvider{
+		client:    c,
+		namespace: namespace,
+		name:      name,
+	}
+}
+
+type ConfigMapUpgradeGraphProvider struct {
+	client    client.Reader
+	namespace string
+	name      string
+}
+
+func (p *ConfigMapUpgradeGraphProvider) GetUpgradeGraph(ctx context.Context, _ string, _ string) (*UpgradeGraph, error) {
+	configMap := &corev1.ConfigMap{}
+	if err := p.client.Get(ctx, client.ObjectKey{Namespace: p.namespace, Name: p.name}, configMap); err != nil {
+		return nil, fmt.Errorf("failed to get update graph ConfigMap %s/%s: %w", p.namespace, p.name, err)
+	}
+	data, ok := configMap.Data[UpgradeGraphConfigMapKey]
+	if !ok {
+		return nil, fmt.Errorf("update graph ConfigMap %s/%s has no %s key", p.namespace, p.name, UpgradeGraphConfigMapKey)
+	}
+	return parseUpgradeGraph([]byte(data))
+}
+
+func parseUpgradeGraph(data []byte) (*UpgradeGraph, error) {
+	graph := &UpgradeGraph{}
+	if err := json.Unmarshal(data, graph); err != nil {
+		return nil, fmt.Errorf("failed to parse update graph: %w", err)
+	}
+	for _, edge := range graph.Edges {
+		if edge[0] < 0 || edge[0] >= len(graph.Nodes) || edge[1] < 0 || edge[1] >= len(graph.Nodes) {
+			return nil, fmt.Errorf("invalid update graph: edge %v references an unknown node", edge)
+		}
+	}
+	return graph, nil
+}
+
+// GetUpgradeChannel returns the channel of the desired version, which holds the edges leading to it, e.g. stable-4.16
+// for the stable channel. The stable channel is used when channel is empty.
+func GetUpgradeChannel(channel, desiredVersion string) (string, error) {
+	desired, err := semver.ParseTolerant(desiredVersion)
+	if err != nil {
+		return "", fmt.Errorf("invalid version %s: %w", desiredVersion, err)
+	}
+	if channel == "" {
+		channel = DefaultUpgradeChannel
+	}
+	return fmt.Sprintf("%s-%d.%d", channel, desired.Major, desired.Minor), nil
+}
+
+// ValidateUpgradeVersions refuses downgrades and upgrades skipping a minor version, which the cluster-version-operator
+// does not support, and versions that are not semantic versions
+func ValidateUpgradeVersions(currentVersion, desiredVersion string) error {
+	current, err := semver.ParseTolerant(currentVersion)
+	if err != nil {
+		return &UpgradePathError{
+			Reason:  UpgradePathInvalidVersion,
+			Message: fmt.Sprintf("invalid current version %s: %s", currentVersion, err.Error()),
+		}
+	}
+	desired, err := semver.ParseTolerant(desiredVersion)
+	if err != nil {
+		return &UpgradePathError{
+			Reason:  UpgradePathInvalidVersion,
+			Message: fmt.Sprintf("invalid desired version %s: %s", desiredVersion, err.Error()),
+		}
+	}
+	if desired.LT(current) {
+		return &UpgradePathError{
+			Reason:  UpgradePathDowngrade,
+			Message: fmt.Sprintf("downgrade from %s to %s is not supported", currentVersion, desiredVersion),
+		}
+	}
+	if desired.Major != current.Major || desired.Minor > current.Minor+1 {
+		return &UpgradePathError{
+			Reason: UpgradePathSkipsMinorVersion,
+			Message: fmt.Sprintf("upgrade from %s to %s skips a minor version, upgrade to %d.%d first",
+				currentVersion, desiredVersion, current.Major, current.Minor+1),
+		}
+	}
+	return nil
+}
+
+// ValidateUpgradePath refuses upgrades that are not recommended by the update graph: the graph must have an
+// unconditional edge from the current version to the desired version. Conditional edges are refused, as the
+// exposure of the cluster to their risks is not evaluated.
+func ValidateUpgradePath(graph *UpgradeGraph, currentVersion, desiredVersion string) error {
+	if err := ValidateUpgradeVersions(currentVersion, desiredVersion); err != nil {
+		return err
+	}
+	for _, edge := range graph.Edges {
+		if graph.Nodes[edge[0]].Version == currentVersion && graph.Nodes[edge[1]].Version == desiredVersion {
+			return nil
+		}
+	}
+	for _, conditionalEdge := range graph.ConditionalEdges {
+		for _, edge := range conditionalEdge.Edges {
+			if edge.From == currentVersion && edge.To == desiredVersion {
+				risks := make([]string, 0, len(conditionalEdge.Risks))
+				for _, risk := range conditionalEdge.Risks {
+					risks = append(risks, risk.Name)
+				}
+				return &UpgradePathError{
+					Reason:  UpgradePathBlocked,
+					Message: fmt.Sprintf("upgrade from %s to %s is not recommended, known risks: %v", currentVersion, desiredVersion, risks),
+				}
+			}
+		}
+	}
+	return &UpgradePathError{
+		Reason:  UpgradePathBlocked,
+		Message: fmt.Sprintf("upgrade from %s to %s is not in the update graph", currentVersion, desiredVersion),
+	}
+}
+
+// getGraphArchitecture returns the architecture name used by the update graph
+func getGraphArchitecture(architecture string) string {
+	switch architecture {
+	case "x86_64":
+		return "amd64"
+	case "aarch64":
+		return "arm64"
+	default:
+		return architecture
+	}
+}
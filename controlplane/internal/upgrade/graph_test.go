@@ -0,0 +1,167 @@
+package upgrade_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/openshift-assisted/cluster-api-agent/controlplane/internal/upgrade"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const upgradeGraph = `
+{
+  "nodes": [
+    {"version": "4.15.10", "payload": "quay.io/openshift-release-dev/ocp-release@sha256:a"},
+    {"version": "4.15.12", "payload": "quay.io/openshift-release-dev/ocp-release@sha256:b"},
+    {"version": "4.16.0", "payload": "quay.io/openshift-release-dev/ocp-release@sha256:c"},
+    {"version": "4.16.2", "payload": "quay.io/openshift-release-dev/ocp-release@sha256:d"}
+  ],
+  "edges": [[0, 1], [1, 3], [2, 3]],
+  "conditionalEdges": [
+    {
+      "edges": [{"from": "4.15.10", "to": "4.16.0"}],
+      "risks": [{"url": "https://issues.redhat.com/browse/OCPBUGS-1", "name": "SomeRisk", "message": "some risk"}]
+    }
+  ]
+}`
+
+var _ = Describe("Upgrade graph", func() {
+	Describe("ValidateUpgradeVersions", func() {
+		DescribeTable("should validate the versions",
+			func(currentVersion, desiredVersion string, expectedReason upgrade.UpgradePathReason) {
+				err := upgrade.ValidateUpgradeVersions(currentVersion, desiredVersion)
+				if expectedReason == "" {
+					Expect(err).NotTo(HaveOccurred())
+					return
+				}
+				var pathErr *upgrade.UpgradePathError
+				Expect(err).To(BeAssignableToTypeOf(pathErr))
+				Expect(err.(*upgrade.UpgradePathError).Reason).To(Equal(expectedReason))
+			},
+			Entry("z-stream upgrade", "4.15.10", "4.15.12", upgrade.UpgradePathReason("")),
+			Entry("minor upgrade", "4.15.12", "4.16.2", upgrade.UpgradePathReason("")),
+			Entry("z-stream downgrade", "4.15.12", "4.15.10", upgrade.UpgradePathDowngrade),
+			Entry("minor downgrade", "4.16.0", "4.15.12", upgrade.UpgradePathDowngrade),
+			Entry("skipped minor", "4.14.5", "4.16.2", upgrade.UpgradePathSkipsMinorVersion),
+			Entry("major upgrade", "4.16.2", "5.0.0", upgrade.UpgradePathSkipsMinorVersion),
+			Entry("invalid desired version", "4.15.10", "latest", upgrade.UpgradePathInvalidVersion),
+			Entry("invalid current version", "invalid", "4.15.12", upgrade.UpgradePathInvalidVersion),
+		)
+	})
+
+	Describe("ValidateUpgradePath", func() {
+		var graph *upgrade.UpgradeGraph
+
+		BeforeEach(func() {
+			var err error
+			graph, err = upgrade.NewConfigMapUpgradeGraphProvider(
+				fake.NewClientBuilder().WithObjects(getUpgradeGraphConfigMap()).Build(), "default", "graph",
+			).GetUpgradeGraph(context.Background(), "", "")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		DescribeTable("should validate the upgrade path",
+			func(currentVersion, desiredVersion string, expectedReason upgrade.UpgradePathReason) {
+				err := upgrade.ValidateUpgradePath(graph, currentVersion, desiredVersion)
+				if expectedReason == "" {
+					Expect(err).NotTo(HaveOccurred())
+					return
+				}
+				var pathErr *upgrade.UpgradePathError
+				Expect(err).To(BeAssignableToTypeOf(pathErr))
+				Expect(err.(*upgrade.UpgradePathError).Reason).To(Equal(expectedReason))
+			},
+			Entry("recommended z-stream edge", "4.15.10", "4.15.12", upgrade.UpgradePathReason("")),
+			Entry("recommended minor edge", "4.15.12", "4.16.2", upgrade.UpgradePathReason("")),
+			Entry("conditional edge", "4.15.10", "4.16.0", upgrade.UpgradePathBlocked),
+			Entry("missing edge", "4.15.10", "4.16.2", upgrade.UpgradePathBlocked),
+			Entry("version not in the graph", "4.15.12", "4.16.1", upgrade.UpgradePathBlocked),
+			Entry("downgrade", "4.16.2", "4.16.0", upgrade.UpgradePathDowngrade),
+		)
+
+		It("should report the risks of conditional edges", func() {
+			err := upgrade.ValidateUpgradePath(graph, "4.15.10", "4.16.0")
+			Expect(err).To(MatchError(ContainSubstring("SomeRisk")))
+		})
+	})
+
+	Describe("URLUpgradeGraphProvider", func() {
+		It("should query the graph of the channel and architecture", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer GinkgoRecover()
+				Expect(r.URL.Path).To(Equal("/api/upgrades_info/v1/graph"))
+				Expect(r.URL.Query().Get("channel")).To(Equal("stable-4.16"))
+				Expect(r.URL.Query().Get("arch")).To(Equal("amd64"))
+				Expect(r.Header.Get("Accept")).To(Equal("application/json"))
+				_, _ = w.Write([]byte(upgradeGraph))
+			}))
+			defer server.Close()
+
+			graph, err := upgrade.NewURLUpgradeGraphProvider(server.URL+"/api/upgrades_info/v1/graph").
+				GetUpgradeGraph(context.Background(), "stable-4.16", "x86_64")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(graph.Nodes).To(HaveLen(4))
+			Expect(graph.Edges).To(HaveLen(3))
+			Expect(graph.ConditionalEdges).To(HaveLen(1))
+		})
+
+		It("should fail when the graph cannot be retrieved", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}))
+			defer server.Close()
+
+			_, err := upgrade.NewURLUpgradeGraphProvider(server.URL).GetUpgradeGraph(context.Background(), "stable-4.16", "amd64")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("ConfigMapUpgradeGraphProvider", func() {
+		var scheme *runtime.Scheme
+
+		BeforeEach(func() {
+			scheme = runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		})
+
+		It("should fail when the ConfigMap does not exist", func() {
+			provider := upgrade.NewConfigMapUpgradeGraphProvider(fake.NewClientBuilder().WithScheme(scheme).Build(), "default", "graph")
+			_, err := provider.GetUpgradeGraph(context.Background(), "stable-4.16", "amd64")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should fail when an edge references an unknown node", func() {
+			configMap := getUpgradeGraphConfigMap()
+			configMap.Data[upgrade.UpgradeGraphConfigMapKey] = `{"nodes": [{"version": "4.16.0"}], "edges": [[0, 1]]}`
+			provider := upgrade.NewConfigMapUpgradeGraphProvider(fake.NewClientBuilder().WithScheme(scheme).WithObjects(configMap).Build(), "default", "graph")
+			_, err := provider.GetUpgradeGraph(context.Background(), "stable-4.16", "amd64")
+			Expect(err).To(MatchError(ContainSubstring("unknown node")))
+		})
+	})
+
+	It("should return the stable channel of the desired version", func() {
+		channel, err := upgrade.GetUpgradeChannel("", "4.16.2")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(channel).To(Equal("stable-4.16"))
+	})
+
+	It("should return the configured channel of the desired version", func() {
+		channel, err := upgrade.GetUpgradeChannel("candidate", "4.17.0-rc.1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(channel).To(Equal("candidate-4.17"))
+	})
+})
+
+func getUpgradeGraphConfigMap() *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "graph", Namespace: "default"},
+		Data:       map[string]string{upgrade.UpgradeGraphConfigMapKey: upgradeGraph},
+	}
+}
@@ -0,0 +1,162 @@
+package upgrade
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/openshift-assisted/cluster-api-agent/controlplane/internal/etcd"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	ClusterOperatorsHealthGateName   = "ClusterOperators"
+	MachineConfigPoolsHealthGateName = "MachineConfigPools"
+	NodesHealthGateName              = "Nodes"
+	EtcdHealthGateName               = "Etcd"
+)
+
+var machineConfigPoolListGVK = schema.GroupVersionKind{
+	Group:   "machineconfiguration.openshift.io",
+	Version: "v1",
+	Kind:    "MachineConfigPoolList",
+}
+
+// HealthGate is a preflight check of the workload cluster, that must pass before an upgrade starts
+//
+//go:generate mockgen -destination=mock_healthgates.go -package=upgrade -source healthgates.go HealthGate
+type HealthGate interface {
+	Name() string
+	// Check returns an empty message when the workload cluster passes the gate, or why it does not pass it
+	Check(ctx context.Context, workloadClient client.Client) (string, error)
+}
+
+// DefaultHealthGates returns the health gates checked before upgrading a workload cluster
+func DefaultHealthGates() []HealthGate {
+	return []HealthGate{
+		&ClusterOperatorsHealthGate{},
+		&MachineConfigPoolsHealthGate{},
+		&NodesHealthGate{},
+		&EtcdHealthGate{},
+	}
+}
+
+// ClusterOperatorsHealthGate passes when all the cluster operators are available and not degraded
+type ClusterOperatorsHealthGate struct{}
+
+func (g *ClusterOperatorsHealthGate) Name() string {
+	return ClusterOperatorsHealthGateName
+}
+
+func (g *ClusterOperatorsHealthGate) Check(ctx context.Context, workloadClient client.Client) (string, error) {
+	degradedOperators, err := getDegradedClusterOperators(ctx, workloadClient)
+	if err != nil {
+		return "", fmt.Errorf("failed to list cluster operators: %w", err)
+	}
+	if len(degradedOperators) > 0 {
+		return fmt.Sprintf("cluster operators degraded or unavailable: %s", strings.Join(degradedOperators, ", ")), nil
+	}
+	return "", nil
+}
+
+// MachineConfigPoolsHealthGate passes when all the machine config pools are updated and not degraded
+type MachineConfigPoolsHealthGate struct{}
+
+func (g *MachineConfigPoolsHealthGate) Name() string {
+	return MachineConfigPoolsHealthGateName
+}
+
+func (g *MachineConfigPoolsHealthGate) Check(ctx context.Context, workloadClient client.Client) (string, error) {
+	pools := &unstructured.UnstructuredList{}
+	pools.SetGroupVersionKind(machineConfigPoolListGVK)
+	if err := workloadClient.List(ctx, pools); err != nil {
+		return "", fmt.Errorf("failed to list machine config pools: %w", err)
+	}
+	notUpdatedPools := make([]string, 0)
+	for _, pool := range pools.Items {
+		conditions, _, err := unstructured.NestedSlice(pool.Object, "status", "conditions")
+		if err != nil {
+			return "", fmt.Errorf("failed to read conditions of machine config pool %s: %w", pool.GetName(), err)
+		}
+		if getConditionStatus(conditions, "Updated") != string(corev1.ConditionTrue) ||
+			getConditionStatus(conditions, "Degraded") == string(corev1.ConditionTrue) {
+			notUpdatedPools = append(notUpdatedPools, pool.GetName())
+		}
+	}
+	if len(notUpdatedPools) > 0 {
+		return fmt.Sprintf("machine config pools not updated: %s", strings.Join(notUpdatedPools, ", ")), nil
+	}
+	return "", nil
+}
+
+// NodesHealthGate passes when all the nodes are ready and under no resource pressure
+type NodesHealthGate struct{}
+
+func (g *NodesHealthGate) Name() string {
+	return NodesHealthGateName
+}
+
+func (g *NodesHealthGate) Check(ctx context.Context, workloadClient client.Client) (string, error) {
+	nodes := &corev1.NodeList{}
+	if err := workloadClient.List(ctx, nodes); err != nil {
+		return "", fmt.Errorf("failed to list nodes: %w", err)
+	}
+	unhealthyNodes := make([]string, 0)
+	for _, node := range nodes.Items {
+		if !isNodeHealthy(node) {
+			unhealthyNodes = append(unhealthyNodes, node.Name)
+		}
+	}
+	if len(unhealthyNodes) > 0 {
+		return fmt.Sprintf("nodes not healthy: %s", strings.Join(unhealthyNodes, ", ")), nil
+	}
+	return "", nil
+}
+
+func isNodeHealthy(node corev1.Node) bool {
+	ready := false
+	for _, condition := range node.Status.Conditions {
+		switch condition.Type {
+		case corev1.NodeReady:
+			ready = condition.Status == corev1.ConditionTrue
+		case corev1.NodeMemoryPressure, corev1.NodeDiskPressure, corev1.NodePIDPressure:
+			if condition.Status == corev1.ConditionTrue {
+				return false
+			}
+		}
+	}
+	return ready
+}
+
+// EtcdHealthGate passes when all the etcd members are healthy
+type EtcdHealthGate struct{}
+
+func (g *EtcdHealthGate) Name() string {
+	return EtcdHealthGateName
+}
+
+func (g *EtcdHealthGate) Check(ctx context.Context, workloadClient client.Client) (string, error) {
+	healthy, err := etcd.IsHealthy(ctx, workloadClient)
+	if err != nil {
+		return "", fmt.Errorf("failed to check etcd health: %w", err)
+	}
+	if !healthy {
+		return "etcd members not healthy", nil
+	}
+	return "", nil
+}
+
+func getConditionStatus(conditions []interface{}, conditionType string) string {
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok || condition["type"] != conditionType {
+			continue
+		}
+		status, _ := condition["status"].(string)
+		return status
+	}
+	return ""
+}
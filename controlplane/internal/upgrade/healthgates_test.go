@@ -0,0 +1,145 @@
+package upgrade_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/openshift-assisted/cluster-api-agent/controlplane/internal/etcd"
+	"github.com/openshift-assisted/cluster-api-agent/controlplane/internal/upgrade"
+	configv1 "github.com/openshift/api/config/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Upgrade health gates", func() {
+	var (
+		ctx        context.Context
+		fakeClient client.Client
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		fakeClient = fake.NewClientBuilder().WithScheme(testScheme).Build()
+	})
+
+	Describe("ClusterOperatorsHealthGate", func() {
+		gate := &upgrade.ClusterOperatorsHealthGate{}
+
+		It("passes when all the cluster operators are available", func() {
+			Expect(fakeClient.Create(ctx, getClusterOperator("etcd", configv1.OperatorAvailable, configv1.ConditionTrue))).To(Succeed())
+
+			message, err := gate.Check(ctx, fakeClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(message).To(BeEmpty())
+		})
+
+		It("reports the degraded cluster operators", func() {
+			Expect(fakeClient.Create(ctx, getClusterOperator("etcd", configv1.OperatorAvailable, configv1.ConditionTrue))).To(Succeed())
+			Expect(fakeClient.Create(ctx, getClusterOperator("ingress", configv1.OperatorDegraded, configv1.ConditionTrue))).To(Succeed())
+
+			message, err := gate.Check(ctx, fakeClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(message).To(Equal("cluster operators degraded or unavailable: ingress"))
+		})
+	})
+
+	Describe("MachineConfigPoolsHealthGate", func() {
+		gate := &upgrade.MachineConfigPoolsHealthGate{}
+
+		createPool := func(name, updated, degraded string) {
+			pool := &unstructured.Unstructured{}
+			pool.SetAPIVersion("machineconfiguration.openshift.io/v1")
+			pool.SetKind("MachineConfigPool")
+			pool.SetName(name)
+			Expect(unstructured.SetNestedSlice(pool.Object, []interface{}{
+				map[string]interface{}{"type": "Updated", "status": updated},
+				map[string]interface{}{"type": "Degraded", "status": degraded},
+			}, "status", "conditions")).To(Succeed())
+			Expect(fakeClient.Create(ctx, pool)).To(Succeed())
+		}
+
+		It("passes when all the pools are updated", func() {
+			createPool("master", "True", "False")
+			createPool("worker", "True", "False")
+
+			message, err := gate.Check(ctx, fakeClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(message).To(BeEmpty())
+		})
+
+		It("reports the pools updating or degraded", func() {
+			createPool("master", "True", "True")
+			createPool("worker", "False", "False")
+			createPool("infra", "True", "False")
+
+			message, err := gate.Check(ctx, fakeClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(message).To(Equal("machine config pools not updated: master, worker"))
+		})
+	})
+
+	Describe("NodesHealthGate", func() {
+		gate := &upgrade.NodesHealthGate{}
+
+		createNode := func(name string, conditions ...corev1.NodeCondition) {
+			node := &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Status:     corev1.NodeStatus{Conditions: conditions},
+			}
+			Expect(fakeClient.Create(ctx, node)).To(Succeed())
+		}
+
+		It("passes when all the nodes are ready", func() {
+			createNode("master-0", corev1.NodeCondition{Type: corev1.NodeReady, Status: corev1.ConditionTrue})
+
+			message, err := gate.Check(ctx, fakeClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(message).To(BeEmpty())
+		})
+
+		It("reports the nodes not ready or under pressure", func() {
+			createNode("master-0", corev1.NodeCondition{Type: corev1.NodeReady, Status: corev1.ConditionTrue})
+			createNode("master-1", corev1.NodeCondition{Type: corev1.NodeReady, Status: corev1.ConditionFalse})
+			createNode("master-2",
+				corev1.NodeCondition{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+				corev1.NodeCondition{Type: corev1.NodeDiskPressure, Status: corev1.ConditionTrue},
+			)
+
+			message, err := gate.Check(ctx, fakeClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(message).To(Equal("nodes not healthy: master-1, master-2"))
+		})
+	})
+
+	Describe("EtcdHealthGate", func() {
+		gate := &upgrade.EtcdHealthGate{}
+
+		It("passes when all the etcd members are ready", func() {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "etcd-master-0",
+					Namespace: etcd.Namespace,
+					Labels:    map[string]string{"app": "etcd"},
+				},
+				Status: corev1.PodStatus{
+					Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+				},
+			}
+			Expect(fakeClient.Create(ctx, pod)).To(Succeed())
+
+			message, err := gate.Check(ctx, fakeClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(message).To(BeEmpty())
+		})
+
+		It("reports unhealthy etcd members", func() {
+			message, err := gate.Check(ctx, fakeClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(message).To(Equal("etcd members not healthy"))
+		})
+	})
+})
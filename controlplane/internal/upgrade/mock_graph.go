@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: graph.go
+
+// Package upgrade is a generated GoMock package.
+package upgrade
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUpgradeGraphProvider is a mock of UpgradeGraphProvider interface.
+type MockUpgradeGraphProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockUpgradeGraphProviderMockRecorder
+}
+
+// MockUpgradeGraphProviderMockRecorder is the mock recorder for MockUpgradeGraphProvider.
+type MockUpgradeGraphProviderMockRecorder struct {
+	mock *MockUpgradeGraphProvider
+}
+
+// NewMockUpgradeGraphProvider creates a new mock instance.
+func NewMockUpgradeGraphProvider(ctrl *gomock.Controller) *MockUpgradeGraphProvider {
+	mock := &MockUpgradeGraphProvider{ctrl: ctrl}
+	mock.recorder = &MockUpgradeGraphProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUpgradeGraphProvider) EXPECT() *MockUpgradeGraphProviderMockRecorder {
+	return m.recorder
+}
+
+// GetUpgradeGraph mocks base method.
+func (m *MockUpgradeGraphProvider) GetUpgradeGraph(ctx context.Context, channel, architecture string) (*UpgradeGraph, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUpgradeGraph", ctx, channel, architecture)
+	ret0, _ := ret[0].(*UpgradeGraph)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUpgradeGraph indicates an expected call of GetUpgradeGraph.
+func (mr *MockUpgradeGraphProviderMockRecorder) GetUpgradeGraph(ctx, channel, architecture interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUpgradeGraph", reflect.TypeOf((*MockUpgradeGraphProvider)(nil).GetUpgradeGraph), ctx, channel, architecture)
+}
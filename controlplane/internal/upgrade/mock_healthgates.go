@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: healthgates.go
+
+// Package upgrade is a generated GoMock package.
+package upgrade
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	client "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// MockHealthGate is a mock of HealthGate interface.
+type MockHealthGate struct {
+	ctrl     *gomock.Controller
+	recorder *MockHealthGateMockRecorder
+}
+
+// MockHealthGateMockRecorder is the mock recorder for MockHealthGate.
+type MockHealthGateMockRecorder struct {
+	mock *MockHealthGate
+}
+
+// NewMockHealthGate creates a new mock instance.
+func NewMockHealthGate(ctrl *gomock.Controller) *MockHealthGate {
+	mock := &MockHealthGate{ctrl: ctrl}
+	mock.recorder = &MockHealthGateMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHealthGate) EXPECT() *MockHealthGateMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *MockHealthGate) Check(ctx context.Context, workloadClient client.Client) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", ctx, workloadClient)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockHealthGateMockRecorder) Check(ctx, workloadClient interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockHealthGate)(nil).Check), ctx, workloadClient)
+}
+
+// Name mocks base method.
+func (m *MockHealthGate) Name() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Name")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Name indicates an expected call of Name.
+func (mr *MockHealthGateMockRecorder) Name() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockHealthGate)(nil).Name))
+}
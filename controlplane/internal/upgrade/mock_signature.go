@@ -0,0 +1,88 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: signature.go
+
+// Package upgrade is a generated GoMock package.
+package upgrade
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	authn "github.com/google/go-containerregistry/pkg/authn"
+)
+
+// MockReleaseVerifier is a mock of ReleaseVerifier interface.
+type MockReleaseVerifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockReleaseVerifierMockRecorder
+}
+
+// MockReleaseVerifierMockRecorder is the mock recorder for MockReleaseVerifier.
+type MockReleaseVerifierMockRecorder struct {
+	mock *MockReleaseVerifier
+}
+
+// NewMockReleaseVerifier creates a new mock instance.
+func NewMockReleaseVerifier(ctrl *gomock.Controller) *MockReleaseVerifier {
+	mock := &MockReleaseVerifier{ctrl: ctrl}
+	mock.recorder = &MockReleaseVerifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReleaseVerifier) EXPECT() *MockReleaseVerifierMockRecorder {
+	return m.recorder
+}
+
+// Verify mocks base method.
+func (m *MockReleaseVerifier) Verify(ctx context.Context, releaseImage string, keychain authn.Keychain) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", ctx, releaseImage, keychain)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockReleaseVerifierMockRecorder) Verify(ctx, releaseImage, keychain interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockReleaseVerifier)(nil).Verify), ctx, releaseImage, keychain)
+}
+
+// MockReleaseSignatureSource is a mock of ReleaseSignatureSource interface.
+type MockReleaseSignatureSource struct {
+	ctrl     *gomock.Controller
+	recorder *MockReleaseSignatureSourceMockRecorder
+}
+
+// MockReleaseSignatureSourceMockRecorder is the mock recorder for MockReleaseSignatureSource.
+type MockReleaseSignatureSourceMockRecorder struct {
+	mock *MockReleaseSignatureSource
+}
+
+// NewMockReleaseSignatureSource creates a new mock instance.
+func NewMockReleaseSignatureSource(ctrl *gomock.Controller) *MockReleaseSignatureSource {
+	mock := &MockReleaseSignatureSource{ctrl: ctrl}
+	mock.recorder = &MockReleaseSignatureSourceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReleaseSignatureSource) EXPECT() *MockReleaseSignatureSourceMockRecorder {
+	return m.recorder
+}
+
+// GetSignatures mocks base method.
+func (m *MockReleaseSignatureSource) GetSignatures(ctx context.Context, digest string) ([][]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSignatures", ctx, digest)
+	ret0, _ := ret[0].([][]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSignatures indicates an expected call of GetSignatures.
+func (mr *MockReleaseSignatureSourceMockRecorder) GetSignatures(ctx, digest interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSignatures", reflect.TypeOf((*MockReleaseSignatureSource)(nil).GetSignatures), ctx, digest)
+}
@@ -9,6 +9,7 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	v1 "github.com/openshift/api/config/v1"
 )
 
 // MockClusterUpgradeFactory is a mock of ClusterUpgradeFactory interface.
@@ -87,6 +88,36 @@ func (mr *MockClusterUpgradeMockRecorder) GetCurrentVersion(ctx interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentVersion", reflect.TypeOf((*MockClusterUpgrade)(nil).GetCurrentVersion), ctx)
 }
 
+// GetUpdateHistory mocks base method.
+func (m *MockClusterUpgrade) GetUpdateHistory(ctx context.Context) ([]v1.UpdateHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUpdateHistory", ctx)
+	ret0, _ := ret[0].([]v1.UpdateHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUpdateHistory indicates an expected call of GetUpdateHistory.
+func (mr *MockClusterUpgradeMockRecorder) GetUpdateHistory(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUpdateHistory", reflect.TypeOf((*MockClusterUpgrade)(nil).GetUpdateHistory), ctx)
+}
+
+// GetUpgradeStatus mocks base method.
+func (m *MockClusterUpgrade) GetUpgradeStatus(ctx context.Context) (UpgradeStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUpgradeStatus", ctx)
+	ret0, _ := ret[0].(UpgradeStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUpgradeStatus indicates an expected call of GetUpgradeStatus.
+func (mr *MockClusterUpgradeMockRecorder) GetUpgradeStatus(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUpgradeStatus", reflect.TypeOf((*MockClusterUpgrade)(nil).GetUpgradeStatus), ctx)
+}
+
 // IsDesiredVersionUpdated mocks base method.
 func (m *MockClusterUpgrade) IsDesiredVersionUpdated(ctx context.Context, desiredVersion string) (bool, error) {
 	m.ctrl.T.Helper()
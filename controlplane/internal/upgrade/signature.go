@@ -0,0 +1,404 @@
+package upgrade
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/openshift-assisted/cluster-api-agent/pkg/containers"
+	"golang.org/x/crypto/openpgp" //nolint:staticcheck // OpenShift release signatures are OpenPGP signatures
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// DefaultReleaseSignatureStoreURL is the store of the OpenPGP signatures of the OpenShift releases
+	DefaultReleaseSignatureStoreURL = "https://mirror.openshift.com/pub/openshift-v4/signatures/openshift/release"
+
+	// ReleaseSignaturesNamespace is the namespace of the workload cluster holding the signature ConfigMaps read by the
+	// cluster-version-operator
+	ReleaseSignaturesNamespace = "openshift-config-managed"
+	// ReleaseSignaturesLabel labels the ConfigMaps holding release signatures
+	ReleaseSignaturesLabel = "release.openshift.io/verification-signatures"
+
+	// cosignSignatureAnnotation is the annotation of a cosign signature layer holding the signature of its payload
+	cosignSignatureAnnotation = "dev.cosignproject.cosign/signature"
+
+	// maxReleaseSignatures is the number of signatures looked up in the signature store for a release
+	maxReleaseSignatures    = 16
+	signatureRequestTimeout = 30 * time.Second
+)
+
+var (
+	// ErrReleaseSignatureVerification is returned when a release image has no valid signature from a trusted key
+	ErrReleaseSignatureVerification = errors.New("release signature verification failed")
+	// ErrReleaseSignatureNotFound is returned when the signatures of a release image cannot be found
+	ErrReleaseSignatureNotFound = errors.New("release signature not found")
+)
+
+//go:generate mockgen -destination=mock_signature.go -package=upgrade -source signature.go ReleaseVerifier,ReleaseSignatureSource
+type ReleaseVerifier interface {
+	// Verify checks that the release image, referenced by digest, is signed by a trusted key
+	Verify(ctx context.Context, releaseImage string, keychain authn.Keychain) error
+}
+
+// ReleaseSignatureSource provides the OpenPGP signatures of release images
+type ReleaseSignatureSource interface {
+	// GetSignatures returns the signatures of the release image with the given digest, none when it is not signed
+	GetSignatures(ctx context.Context, digest string) ([][]byte, error)
+}
+
+// NewStoreReleaseSignatureSource returns a source reading the signatures of a signature store, served at
+// <store>/<algorithm>=<hash>/signature-<n>
+func NewStoreReleaseSignatureSource(storeURL string) *StoreReleaseSignatureSource {
+	return &StoreReleaseSignatureSource{
+		url:        strings.TrimSuffix(storeURL, "/"),
+		httpClient: &http.Client{Timeout: signatureRequestTimeout},
+	}
+}
+
+type StoreReleaseSignatureSource struct {
+	url        string
+	httpClient *http.Client
+}
+
+func (s *StoreReleaseSignatureSource) GetSignatures(ctx context.Context, digest string) ([][]byte, error) {
+	signatures := make([][]byte, 0)
+	for i := 1; i <= maxReleaseSignatures; i++ {
+		signature, err := s.getSignature(ctx, digest, i)
+		if err != nil {
+			return nil, err
+		}
+		if signature == nil {
+			break
+		}
+		signatures = append(signatures, signature)
+	}
+	return signatures, nil
+}
+
+func (s *StoreReleaseSignatureSource) getSignature(ctx context.Context, digest string, index int) ([]byte, error) {
+	signatureURL := fmt.Sprintf("%s/%s/signature-%d", s.url, strings.Replace(digest, ":", "=", 1), index)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, signatureURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get release signature: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get release signature from %s: unexpected status %s", signatureURL, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// NewConfigMapReleaseSignatureSource returns a source reading the signatures of a ConfigMap, for disconnected
+// environments. The ConfigMap holds the signatures in the format of the signature ConfigMaps of the
+// cluster-version-operator: the binary data key <algorithm>-<hash>-<n> is the n-th signature of a release.
+func NewConfigMapReleaseSignatureSource(c client.Reader, namespace, name string) *ConfigMapReleaseSignatureSource {
+	return &ConfigMapReleaseSignatureSource{
+		client:    c,
+		namespace: namespace,
+		name:      name,
+	}
+}
+
+type ConfigMapReleaseSignatureSource struct {
+	client    client.Reader
+	namespace string
+	name      string
+}
+
+func (s *ConfigMapReleaseSignatureSource) GetSignatures(ctx context.Context, digest string) ([][]byte, error) {
+	configMap := &corev1.ConfigMap{}
+	if err := s.client.Get(ctx, client.ObjectKey{Namespace: s.namespace, Name: s.name}, configMap); err != nil {
+		return nil, fmt.Errorf("failed to get release signatures ConfigMap %s/%s: %w", s.namespace, s.name, err)
+	}
+	signatures := make([][]byte, 0)
+	for i := 1; i <= maxReleaseSignatures; i++ {
+		signature, ok := configMap.BinaryData[getSignatureKey(digest, i)]
+		if !ok {
+			break
+		}
+		signatures = append(signatures, signature)
+	}
+	return signatures, nil
+}
+
+// PushReleaseSignatures writes the signatures of the release image with the given digest to the signature ConfigMap
+// of the workload cluster, read by the cluster-version-operator to verify the release
+func PushReleaseSignatures(ctx context.Context, workloadClient client.Client, digest string, signatures [][]byte) error {
+	binaryData := make(map[string][]byte, len(signatures))
+	for i, signature := range signatures {
+		binaryData[getSignatureKey(digest, i+1)] = signature
+	}
+	configMap := &corev1.ConfigMap{}
+	key := client.ObjectKey{Namespace: ReleaseSignaturesNamespace, Name: strings.Replace(digest, ":", "-", 1)}
+	err := workloadClient.Get(ctx, key, configMap)
+	if apierrors.IsNotFound(err) {
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: key.Namespace,
+				Name:      key.Name,
+				Labels:    map[string]string{ReleaseSignaturesLabel: ""},
+			},
+			BinaryData: binaryData,
+		}
+		return workloadClient.Create(ctx, configMap)
+	}
+	if err != nil {
+		return err
+	}
+	if configMap.Labels == nil {
+		configMap.Labels = map[string]string{}
+	}
+	configMap.Labels[ReleaseSignaturesLabel] = ""
+	configMap.BinaryData = binaryData
+	return workloadClient.Update(ctx, configMap)
+}
+
+// getSignatureKey returns the <algorithm>-<hash>-<n> key of the n-th signature of a release
+func getSignatureKey(digest string, index int) string {
+	return fmt.Sprintf("%s-%d", strings.Replace(digest, ":", "-", 1), index)
+}
+
+// NewReleaseSignatureVerifier returns a verifier of release images trusting the keys of a Secret. The Secret keys ending
+// with .gpg or .asc hold OpenPGP public keyrings, verifying the release signatures of the signature source. The Secret
+// keys ending with .pub or .pem hold PEM public keys, verifying the cosign signatures stored next to the release image.
+func NewReleaseSignatureVerifier(
+	c client.Reader,
+	namespace, name string,
+	signatureSource ReleaseSignatureSource,
+	remoteImage containers.RemoteImage,
+) *ReleaseSignatureVerifier {
+	return &ReleaseSignatureVerifier{
+		client:          c,
+		namespace:       namespace,
+		name:            name,
+		signatureSource: signatureSource,
+		remoteImage:     remoteImage,
+	}
+}
+
+type ReleaseSignatureVerifier struct {
+	client          client.Reader
+	namespace       string
+	name            string
+	signatureSource ReleaseSignatureSource
+	remoteImage     containers.RemoteImage
+}
+
+// simpleSigning is the payload signed by both OpenPGP release signatures and cosign signatures
+type simpleSigning struct {
+	Critical struct {
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+		Type string `json:"type"`
+	} `json:"critical"`
+}
+
+func (v *ReleaseSignatureVerifier) Verify(ctx context.Context, releaseImage string, keychain authn.Keychain) error {
+	ref, err := name.NewDigest(releaseImage)
+	if err != nil {
+		return fmt.Errorf("%w: release image %s is not referenced by digest", ErrReleaseSignatureVerification, releaseImage)
+	}
+	keyring, publicKeys, err := v.getTrustedKeys(ctx)
+	if err != nil {
+		return err
+	}
+
+	failures := make([]string, 0)
+	if len(keyring) > 0 {
+		err := v.verifyReleaseSignatures(ctx, keyring, ref.DigestStr())
+		if err == nil {
+			return nil
+		}
+		failures = append(failures, err.Error())
+	}
+	if len(publicKeys) > 0 {
+		err := v.verifyCosignSignatures(ref, publicKeys, keychain)
+		if err == nil {
+			return nil
+		}
+		failures = append(failures, err.Error())
+	}
+	if len(failures) == 0 {
+		failures = append(failures, fmt.Sprintf("no trusted key in secret %s/%s", v.namespace, v.name))
+	}
+	return fmt.Errorf("%w for %s: %s", ErrReleaseSignatureVerification, releaseImage, strings.Join(failures, "; "))
+}
+
+func (v *ReleaseSignatureVerifier) getTrustedKeys(ctx context.Context) (openpgp.EntityList, []crypto.PublicKey, error) {
+	secret := &corev1.Secret{}
+	if err := v.client.Get(ctx, client.ObjectKey{Namespace: v.namespace, Name: v.name}, secret); err != nil {
+		return nil, nil, fmt.Errorf("failed to get release signature keys secret %s/%s: %w", v.namespace, v.name, err)
+	}
+	keyring := openpgp.EntityList{}
+	publicKeys := make([]crypto.PublicKey, 0)
+	for key, data := range secret.Data {
+		switch filepath.Ext(key) {
+		case ".gpg", ".asc":
+			entities, err := readKeyRing(data)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid OpenPGP keyring %s in secret %s/%s: %w", key, v.namespace, v.name, err)
+			}
+			keyring = append(keyring, entities...)
+		case ".pub", ".pem":
+			publicKey, err := readPublicKey(data)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid public key %s in secret %s/%s: %w", key, v.namespace, v.name, err)
+			}
+			publicKeys = append(publicKeys, publicKey)
+		}
+	}
+	return keyring, publicKeys, nil
+}
+
+// verifyReleaseSignatures checks the OpenPGP signatures of the release, until a valid signature is found
+func (v *ReleaseSignatureVerifier) verifyReleaseSignatures(ctx context.Context, keyring openpgp.EntityList, digest string) error {
+	signatures, err := v.signatureSource.GetSignatures(ctx, digest)
+	if err != nil {
+		return err
+	}
+	for _, signature := range signatures {
+		if err := verifyOpenPGPSignature(keyring, signature, digest); err == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("no valid OpenPGP signature")
+}
+
+// verifyCosignSignatures checks the cosign signatures of the release, stored in the <repository>:<algorithm>-<hash>.sig
+// image, until a valid signature is found
+func (v *ReleaseSignatureVerifier) verifyCosignSignatures(ref name.Digest, publicKeys []crypto.PublicKey, keychain authn.Keychain) error {
+	signatureImageRef := ref.Context().Tag(strings.Replace(ref.DigestStr(), ":", "-", 1) + ".sig").String()
+	signatureImage, err := v.remoteImage.GetImage(signatureImageRef, keychain)
+	if err != nil {
+		return fmt.Errorf("no cosign signature image %s", signatureImageRef)
+	}
+	manifest, err := signatureImage.Manifest()
+	if err != nil {
+		return fmt.Errorf("failed to get cosign signature manifest: %w", err)
+	}
+	for _, descriptor := range manifest.Layers {
+		signature, err := base64.StdEncoding.DecodeString(descriptor.Annotations[cosignSignatureAnnotation])
+		if err != nil || len(signature) == 0 {
+			continue
+		}
+		layer, err := signatureImage.LayerByDigest(descriptor.Digest)
+		if err != nil {
+			return fmt.Errorf("failed to get cosign signature payload: %w", err)
+		}
+		payload, err := readLayer(layer)
+		if err != nil {
+			return fmt.Errorf("failed to read cosign signature payload: %w", err)
+		}
+		if verifyCosignSignature(publicKeys, payload, signature) && verifySimpleSigning(payload, ref.DigestStr()) == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("no valid cosign signature in %s", signatureImageRef)
+}
+
+func verifyOpenPGPSignature(keyring openpgp.EntityList, signature []byte, digest string) error {
+	message, err := openpgp.ReadMessage(bytes.NewReader(signature), keyring, nil, nil)
+	if err != nil {
+		return err
+	}
+	if !message.IsSigned || message.SignedBy == nil {
+		return fmt.Errorf("not signed by a trusted key")
+	}
+	// the signature is only checked once the whole body is read
+	payload, err := io.ReadAll(message.UnverifiedBody)
+	if err != nil {
+		return err
+	}
+	if message.SignatureError != nil {
+		return message.SignatureError
+	}
+	return verifySimpleSigning(payload, digest)
+}
+
+func verifyCosignSignature(publicKeys []crypto.PublicKey, payload, signature []byte) bool {
+	hash := sha256.Sum256(payload)
+	for _, publicKey := range publicKeys {
+		switch key := publicKey.(type) {
+		case *ecdsa.PublicKey:
+			if ecdsa.VerifyASN1(key, hash[:], signature) {
+				return true
+			}
+		case *rsa.PublicKey:
+			if rsa.VerifyPKCS1v15(key, crypto.SHA256, hash[:], signature) == nil {
+				return true
+			}
+		case ed25519.PublicKey:
+			if ed25519.Verify(key, payload, signature) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// verifySimpleSigning checks the signed payload is about the release image
+func verifySimpleSigning(payload []byte, digest string) error {
+	signed := simpleSigning{}
+	if err := json.Unmarshal(payload, &signed); err != nil {
+		return fmt.Errorf("invalid signature payload: %w", err)
+	}
+	if signed.Critical.Image.DockerManifestDigest != digest {
+		return fmt.Errorf("signature is for %s, not %s", signed.Critical.Image.DockerManifestDigest, digest)
+	}
+	return nil
+}
+
+func readKeyRing(data []byte) (openpgp.EntityList, error) {
+	if keyring, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(data)); err == nil {
+		return keyring, nil
+	}
+	return openpgp.ReadKeyRing(bytes.NewReader(data))
+}
+
+func readPublicKey(data []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM block found")
+	}
+	return x509.ParsePKIXPublicKey(block.Bytes)
+}
+
+// readLayer returns the content of a layer as stored in the registry: cosign payloads are not compressed
+func readLayer(layer v1.Layer) ([]byte, error) {
+	reader, err := layer.Compressed()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return io.ReadAll(reader)
+}
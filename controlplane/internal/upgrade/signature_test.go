@@ -0,0 +1,250 @@
+package upgrade_test
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-containerregistry/pkg/authn"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/openshift-assisted/cluster-api-agent/controlplane/internal/upgrade"
+	"github.com/openshift-assisted/cluster-api-agent/external_mocks"
+	"github.com/openshift-assisted/cluster-api-agent/pkg/containers"
+	"golang.org/x/crypto/openpgp" //nolint:staticcheck
+	"golang.org/x/crypto/openpgp/armor"
+	"golang.org/x/crypto/openpgp/packet"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	signedDigest = "sha256:573a86d57acab6dfb90799f568421e80a41f85aaef1e94a16e13af13339524c1"
+	signedImage  = "quay.io/openshift-release-dev/ocp-release@" + signedDigest
+)
+
+var _ = Describe("ReleaseSignatureVerifier", func() {
+	var (
+		ctx             context.Context
+		mockCtrl        *gomock.Controller
+		mockRemoteImage *containers.MockRemoteImage
+		fakeClient      client.Client
+		signatures      map[string][]byte
+		store           *httptest.Server
+		verifier        *upgrade.ReleaseSignatureVerifier
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockRemoteImage = containers.NewMockRemoteImage(mockCtrl)
+		fakeClient = fake.NewClientBuilder().WithScheme(testScheme).Build()
+		signatures = map[string][]byte{}
+		store = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			signature, ok := signatures[r.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write(signature)
+		}))
+		verifier = upgrade.NewReleaseSignatureVerifier(fakeClient, "test-namespace", "release-keys", upgrade.NewStoreReleaseSignatureSource(store.URL), mockRemoteImage)
+	})
+
+	AfterEach(func() {
+		store.Close()
+		mockCtrl.Finish()
+	})
+
+	createKeysSecret := func(data map[string][]byte) {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "release-keys"},
+			Data:       data,
+		}
+		Expect(fakeClient.Create(ctx, secret)).To(Succeed())
+	}
+
+	getPayload := func(digest string) []byte {
+		return []byte(fmt.Sprintf(`{"critical":{"identity":{"docker-reference":"quay.io/openshift-release-dev/ocp-release"},`+
+			`"image":{"docker-manifest-digest":"%s"},"type":"atomic container signature"},"optional":{}}`, digest))
+	}
+
+	Context("with OpenPGP release signatures", func() {
+		var signer *openpgp.Entity
+		signingConfig := &packet.Config{DefaultHash: crypto.SHA256}
+
+		BeforeEach(func() {
+			var err error
+			signer, err = openpgp.NewEntity("release", "", "release@example.com", signingConfig)
+			Expect(err).NotTo(HaveOccurred())
+			keyring := &bytes.Buffer{}
+			writer, err := armor.Encode(keyring, openpgp.PublicKeyType, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(signer.Serialize(writer)).To(Succeed())
+			Expect(writer.Close()).To(Succeed())
+			createKeysSecret(map[string][]byte{"release.asc": keyring.Bytes()})
+		})
+
+		sign := func(entity *openpgp.Entity, payload []byte) []byte {
+			signature := &bytes.Buffer{}
+			writer, err := openpgp.Sign(signature, entity, nil, signingConfig)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = writer.Write(payload)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(writer.Close()).To(Succeed())
+			return signature.Bytes()
+		}
+
+		It("verifies a release signed by a trusted key", func() {
+			untrusted, err := openpgp.NewEntity("untrusted", "", "untrusted@example.com", signingConfig)
+			Expect(err).NotTo(HaveOccurred())
+			signatures["/sha256=573a86d57acab6dfb90799f568421e80a41f85aaef1e94a16e13af13339524c1/signature-1"] = sign(untrusted, getPayload(signedDigest))
+			signatures["/sha256=573a86d57acab6dfb90799f568421e80a41f85aaef1e94a16e13af13339524c1/signature-2"] = sign(signer, getPayload(signedDigest))
+
+			Expect(verifier.Verify(ctx, signedImage, authn.DefaultKeychain)).To(Succeed())
+		})
+
+		It("refuses a signature of another release", func() {
+			signatures["/sha256=573a86d57acab6dfb90799f568421e80a41f85aaef1e94a16e13af13339524c1/signature-1"] = sign(signer, getPayload("sha256:0123"))
+
+			err := verifier.Verify(ctx, signedImage, authn.DefaultKeychain)
+			Expect(err).To(MatchError(upgrade.ErrReleaseSignatureVerification))
+		})
+
+		It("refuses an unsigned release", func() {
+			err := verifier.Verify(ctx, signedImage, authn.DefaultKeychain)
+			Expect(err).To(MatchError(upgrade.ErrReleaseSignatureVerification))
+			Expect(err.Error()).To(ContainSubstring("no valid OpenPGP signature"))
+		})
+	})
+
+	Context("with cosign signatures", func() {
+		var signer *ecdsa.PrivateKey
+
+		BeforeEach(func() {
+			var err error
+			signer, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			Expect(err).NotTo(HaveOccurred())
+			publicKey, err := x509.MarshalPKIXPublicKey(&signer.PublicKey)
+			Expect(err).NotTo(HaveOccurred())
+			createKeysSecret(map[string][]byte{
+				"cosign.pub": pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKey}),
+			})
+		})
+
+		expectSignatureImage := func(key *ecdsa.PrivateKey, payload []byte) {
+			hash := sha256.Sum256(payload)
+			signature, err := ecdsa.SignASN1(rand.Reader, key, hash[:])
+			Expect(err).NotTo(HaveOccurred())
+			layerDigest := v1.Hash{Algorithm: "sha256", Hex: "0123"}
+
+			mockLayer := external_mocks.NewMockLayer(mockCtrl)
+			mockLayer.EXPECT().Compressed().Return(io.NopCloser(bytes.NewReader(payload)), nil)
+			mockImage := external_mocks.NewMockImage(mockCtrl)
+			mockImage.EXPECT().Manifest().Return(&v1.Manifest{Layers: []v1.Descriptor{{
+				Digest:      layerDigest,
+				Annotations: map[string]string{"dev.cosignproject.cosign/signature": base64.StdEncoding.EncodeToString(signature)},
+			}}}, nil)
+			mockImage.EXPECT().LayerByDigest(layerDigest).Return(mockLayer, nil)
+			mockRemoteImage.EXPECT().
+				GetImage("quay.io/openshift-release-dev/ocp-release:sha256-573a86d57acab6dfb90799f568421e80a41f85aaef1e94a16e13af13339524c1.sig", gomock.Any()).
+				Return(mockImage, nil)
+		}
+
+		It("verifies a release signed by a trusted key", func() {
+			expectSignatureImage(signer, getPayload(signedDigest))
+
+			Expect(verifier.Verify(ctx, signedImage, authn.DefaultKeychain)).To(Succeed())
+		})
+
+		It("refuses a release signed by an untrusted key", func() {
+			untrusted, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			Expect(err).NotTo(HaveOccurred())
+			expectSignatureImage(untrusted, getPayload(signedDigest))
+
+			err = verifier.Verify(ctx, signedImage, authn.DefaultKeychain)
+			Expect(err).To(MatchError(upgrade.ErrReleaseSignatureVerification))
+			Expect(err.Error()).To(ContainSubstring("no valid cosign signature"))
+		})
+	})
+
+	It("refuses a release not referenced by digest", func() {
+		err := verifier.Verify(ctx, "quay.io/openshift-release-dev/ocp-release:4.16.2-x86_64", authn.DefaultKeychain)
+		Expect(err).To(MatchError(upgrade.ErrReleaseSignatureVerification))
+	})
+
+	It("refuses releases without trusted keys", func() {
+		createKeysSecret(map[string][]byte{})
+
+		err := verifier.Verify(ctx, signedImage, authn.DefaultKeychain)
+		Expect(err).To(MatchError(upgrade.ErrReleaseSignatureVerification))
+		Expect(err.Error()).To(ContainSubstring("no trusted key"))
+	})
+})
+
+var _ = Describe("ReleaseSignatureSource", func() {
+	var (
+		ctx        context.Context
+		fakeClient client.Client
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		fakeClient = fake.NewClientBuilder().WithScheme(testScheme).Build()
+	})
+
+	It("reads the signatures of a release from a ConfigMap", func() {
+		Expect(fakeClient.Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "release-signatures"},
+			BinaryData: map[string][]byte{
+				"sha256-573a86d57acab6dfb90799f568421e80a41f85aaef1e94a16e13af13339524c1-1": []byte("signature-1"),
+				"sha256-573a86d57acab6dfb90799f568421e80a41f85aaef1e94a16e13af13339524c1-2": []byte("signature-2"),
+				"sha256-0123-1": []byte("other-signature"),
+			},
+		})).To(Succeed())
+		source := upgrade.NewConfigMapReleaseSignatureSource(fakeClient, "test-namespace", "release-signatures")
+
+		signatures, err := source.GetSignatures(ctx, signedDigest)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(signatures).To(Equal([][]byte{[]byte("signature-1"), []byte("signature-2")}))
+	})
+
+	It("replaces the signatures of a release pushed to the workload cluster", func() {
+		Expect(fakeClient.Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: upgrade.ReleaseSignaturesNamespace,
+				Name:      "sha256-573a86d57acab6dfb90799f568421e80a41f85aaef1e94a16e13af13339524c1",
+			},
+			BinaryData: map[string][]byte{
+				"sha256-573a86d57acab6dfb90799f568421e80a41f85aaef1e94a16e13af13339524c1-1": []byte("old-signature"),
+			},
+		})).To(Succeed())
+
+		Expect(upgrade.PushReleaseSignatures(ctx, fakeClient, signedDigest, [][]byte{[]byte("signature-1")})).To(Succeed())
+
+		configMap := &corev1.ConfigMap{}
+		Expect(fakeClient.Get(ctx, client.ObjectKey{
+			Namespace: upgrade.ReleaseSignaturesNamespace,
+			Name:      "sha256-573a86d57acab6dfb90799f568421e80a41f85aaef1e94a16e13af13339524c1",
+		}, configMap)).To(Succeed())
+		Expect(configMap.Labels).To(HaveKeyWithValue(upgrade.ReleaseSignaturesLabel, ""))
+		Expect(configMap.BinaryData).To(Equal(map[string][]byte{
+			"sha256-573a86d57acab6dfb90799f568421e80a41f85aaef1e94a16e13af13339524c1-1": []byte("signature-1"),
+		}))
+	})
+})
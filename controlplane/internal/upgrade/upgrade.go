@@ -9,9 +9,10 @@ import (
 	"github.com/openshift-assisted/cluster-api-agent/controlplane/internal/release"
 	"github.com/openshift-assisted/cluster-api-agent/pkg/containers"
 
-	"github.com/openshift-assisted/cluster-api-agent/controlplane/internal/workloadclient"
+	"github.com/openshift-assisted/cluster-api-agent/pkg/workloadclient"
 	configv1 "github.com/openshift/api/config/v1"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -20,8 +21,41 @@ const (
 	ClusterVersionName                   = "version"
 	ReleaseImageRepositoryOverrideOption = "ReleaseImageRepositoryOverride"
 	ReleaseImagePullSecretOption         = "ReleaseImagePullSecret"
+	// PushReleaseSignaturesOption pushes the signatures of releases upgraded to by image to the workload cluster,
+	// instead of forcing the update
+	PushReleaseSignaturesOption = "PushReleaseSignatures"
+
+	clusterVersionFailingCondition         configv1.ClusterStatusConditionType = "Failing"
+	clusterVersionReleaseAcceptedCondition configv1.ClusterStatusConditionType = "ReleaseAccepted"
+)
+
+// UpgradeIssueReason identifies an issue preventing an upgrade from completing
+type UpgradeIssueReason string
+
+const (
+	// UpgradeReleaseNotAccepted reports that the cluster-version-operator could not load or verify the desired release
+	UpgradeReleaseNotAccepted UpgradeIssueReason = "ReleaseNotAccepted"
+	// UpgradeFailing reports that the cluster-version-operator is failing to reconcile the desired release
+	UpgradeFailing UpgradeIssueReason = "Failing"
+	// UpgradeClusterOperatorsDegraded reports cluster operators that are degraded or unavailable
+	UpgradeClusterOperatorsDegraded UpgradeIssueReason = "ClusterOperatorsDegraded"
+	// UpgradeRetrievingUpdatesFailed reports that the available updates, required to upgrade by version,
+	// could not be retrieved
+	UpgradeRetrievingUpdatesFailed UpgradeIssueReason = "RetrievingUpdatesFailed"
 )
 
+type UpgradeIssue struct {
+	Reason  UpgradeIssueReason
+	Message string
+}
+
+type UpgradeStatus struct {
+	// StartedTime is the time the rollout of the desired release started, nil when the rollout did not start
+	StartedTime *metav1.Time
+	// Issues preventing the upgrade from completing, by decreasing severity
+	Issues []UpgradeIssue
+}
+
 type ClusterUpgradeOption struct {
 	Name  string
 	Value string
@@ -36,18 +70,35 @@ type ClusterUpgrade interface {
 	GetCurrentVersion(ctx context.Context) (string, error)
 	IsDesiredVersionUpdated(ctx context.Context, desiredVersion string) (bool, error)
 	UpdateClusterVersionDesiredUpdate(ctx context.Context, desiredVersion string, architecture string, options ...ClusterUpgradeOption) error
+	GetUpgradeStatus(ctx context.Context) (UpgradeStatus, error)
+	GetUpdateHistory(ctx context.Context) ([]configv1.UpdateHistory, error)
 }
 
-func NewOpenshiftUpgradeFactory(remoteImage containers.RemoteImage, clientGenerator workloadclient.ClientGenerator) *OpenshiftUpgradeFactory {
+// NewOpenshiftUpgradeFactory returns a factory of upgraders. Releases upgraded to by image are verified by the release
+// verifier, when set, or have their signatures pushed from the signature source when requested. Without a release
+// verifier, they are forced without verification, unless requireReleaseVerification is set.
+func NewOpenshiftUpgradeFactory(
+	remoteImage containers.RemoteImage,
+	clientGenerator workloadclient.ClientGenerator,
+	releaseVerifier ReleaseVerifier,
+	requireReleaseVerification bool,
+	signatureSource ReleaseSignatureSource,
+) *OpenshiftUpgradeFactory {
 	return &OpenshiftUpgradeFactory{
-		remoteImage:     remoteImage,
-		clientGenerator: clientGenerator,
+		remoteImage:                remoteImage,
+		clientGenerator:            clientGenerator,
+		releaseVerifier:            releaseVerifier,
+		requireReleaseVerification: requireReleaseVerification,
+		signatureSource:            signatureSource,
 	}
 }
 
 type OpenshiftUpgradeFactory struct {
-	remoteImage     containers.RemoteImage
-	clientGenerator workloadclient.ClientGenerator
+	remoteImage                containers.RemoteImage
+	clientGenerator            workloadclient.ClientGenerator
+	releaseVerifier            ReleaseVerifier
+	requireReleaseVerification bool
+	signatureSource            ReleaseSignatureSource
 }
 
 func (f *OpenshiftUpgradeFactory) NewUpgrader(kubeConfig []byte) (ClusterUpgrade, error) {
@@ -55,22 +106,32 @@ func (f *OpenshiftUpgradeFactory) NewUpgrader(kubeConfig []byte) (ClusterUpgrade
 	if err != nil {
 		return nil, err
 	}
-	return &OpenshiftUpgrader{
-		client:      c,
-		remoteImage: f.remoteImage,
-	}, nil
+	upgrader := NewOpenshiftUpgrader(c, f.remoteImage, f.releaseVerifier, f.requireReleaseVerification, f.signatureSource)
+	return &upgrader, nil
 }
 
-func NewOpenshiftUpgrader(client client.Client, remoteImage containers.RemoteImage) OpenshiftUpgrader {
+func NewOpenshiftUpgrader(
+	client client.Client,
+	remoteImage containers.RemoteImage,
+	releaseVerifier ReleaseVerifier,
+	requireReleaseVerification bool,
+	signatureSource ReleaseSignatureSource,
+) OpenshiftUpgrader {
 	return OpenshiftUpgrader{
-		client:      client,
-		remoteImage: remoteImage,
+		client:                     client,
+		remoteImage:                remoteImage,
+		releaseVerifier:            releaseVerifier,
+		requireReleaseVerification: requireReleaseVerification,
+		signatureSource:            signatureSource,
 	}
 }
 
 type OpenshiftUpgrader struct {
-	client      client.Client
-	remoteImage containers.RemoteImage
+	client                     client.Client
+	remoteImage                containers.RemoteImage
+	releaseVerifier            ReleaseVerifier
+	requireReleaseVerification bool
+	signatureSource            ReleaseSignatureSource
 }
 
 // Returns true if upgrade in progress, false otherwise. If any error occurs while performing this operation, it will be
@@ -145,12 +206,139 @@ func (u *OpenshiftUpgrader) UpdateClusterVersionDesiredUpdate(ctx context.Contex
 		return err
 	}
 	if clusterVersion.Spec.DesiredUpdate == nil || clusterVersion.Spec.DesiredUpdate.Image != releaseImageWithDigest {
+		if getOption(PushReleaseSignaturesOption, options...) == "true" {
+			// the cluster-version-operator verifies the release with the pushed signatures, so the update is not forced
+			if err := u.pushReleaseSignatures(ctx, releaseImageWithDigest); err != nil {
+				return err
+			}
+			clusterVersion.Spec.DesiredUpdate = &configv1.Update{Image: releaseImageWithDigest}
+			return u.client.Update(ctx, &clusterVersion)
+		}
+		// the update is forced, skipping the signature checks of the cluster-version-operator, so the signature
+		// of the release is verified first when a release verifier is configured
+		if err := u.verifyRelease(ctx, releaseImageWithDigest, pullSecret); err != nil {
+			return err
+		}
 		clusterVersion.Spec.DesiredUpdate = &configv1.Update{Image: releaseImageWithDigest, Force: true}
 		return u.client.Update(ctx, &clusterVersion)
 	}
 	return nil
 }
 
+func (u *OpenshiftUpgrader) verifyRelease(ctx context.Context, releaseImageWithDigest, pullSecret string) error {
+	// without trusted keys the release cannot be verified: it is forced unverified, unless verification is required
+	if u.releaseVerifier == nil {
+		if u.requireReleaseVerification {
+			return fmt.Errorf("%w for %s: no trusted release signature keys configured", ErrReleaseSignatureVerification, releaseImageWithDigest)
+		}
+		return nil
+	}
+	keychain, err := containers.PullSecretKeyChainFromString(pullSecret)
+	if err != nil {
+		return err
+	}
+	return u.releaseVerifier.Verify(ctx, releaseImageWithDigest, keychain)
+}
+
+// pushReleaseSignatures writes the signatures of the release to the workload cluster. Signatures not trusted by the
+// cluster-version-operator are reported by the ReleaseAccepted condition of the ClusterVersion.
+func (u *OpenshiftUpgrader) pushReleaseSignatures(ctx context.Context, releaseImageWithDigest string) error {
+	if u.signatureSource == nil {
+		return fmt.Errorf("%w: no release signature source configured", ErrReleaseSignatureNotFound)
+	}
+	_, digest, found := strings.Cut(releaseImageWithDigest, "@")
+	if !found {
+		return fmt.Errorf("release image %s is not referenced by digest", releaseImageWithDigest)
+	}
+	signatures, err := u.signatureSource.GetSignatures(ctx, digest)
+	if err != nil {
+		return err
+	}
+	if len(signatures) == 0 {
+		return fmt.Errorf("%w for release image %s", ErrReleaseSignatureNotFound, releaseImageWithDigest)
+	}
+	return PushReleaseSignatures(ctx, u.client, digest, signatures)
+}
+
+// Returns the status of the upgrade, with the issues reported by the ClusterVersion and the ClusterOperators that
+// prevent it from completing. If any error occurs while performing this operation, it will be returned
+func (u *OpenshiftUpgrader) GetUpgradeStatus(ctx context.Context) (UpgradeStatus, error) {
+	upgradeStatus := UpgradeStatus{}
+	clusterVersion, err := u.getClusterVersion(ctx)
+	if err != nil {
+		return upgradeStatus, err
+	}
+	if len(clusterVersion.Status.History) > 0 && clusterVersion.Status.History[0].State == configv1.PartialUpdate {
+		upgradeStatus.StartedTime = &clusterVersion.Status.History[0].StartedTime
+	}
+
+	if condition := getClusterVersionCondition(clusterVersion, clusterVersionReleaseAcceptedCondition); condition != nil &&
+		condition.Status == configv1.ConditionFalse {
+		upgradeStatus.Issues = append(upgradeStatus.Issues, UpgradeIssue{Reason: UpgradeReleaseNotAccepted, Message: condition.Message})
+	}
+	if condition := getClusterVersionCondition(clusterVersion, clusterVersionFailingCondition); condition != nil &&
+		condition.Status == configv1.ConditionTrue {
+		upgradeStatus.Issues = append(upgradeStatus.Issues, UpgradeIssue{Reason: UpgradeFailing, Message: condition.Message})
+	}
+	degradedOperators, err := getDegradedClusterOperators(ctx, u.client)
+	if err != nil {
+		return upgradeStatus, err
+	}
+	if len(degradedOperators) > 0 {
+		upgradeStatus.Issues = append(upgradeStatus.Issues, UpgradeIssue{
+			Reason:  UpgradeClusterOperatorsDegraded,
+			Message: fmt.Sprintf("cluster operators degraded or unavailable: %s", strings.Join(degradedOperators, ", ")),
+		})
+	}
+	// the available updates are only needed to upgrade by version, until the desired release is accepted
+	desiredUpdate := clusterVersion.Spec.DesiredUpdate
+	if desiredUpdate != nil && desiredUpdate.Image == "" && desiredUpdate.Version != clusterVersion.Status.Desired.Version {
+		if condition := getClusterVersionCondition(clusterVersion, configv1.RetrievedUpdates); condition != nil &&
+			condition.Status == configv1.ConditionFalse {
+			upgradeStatus.Issues = append(upgradeStatus.Issues, UpgradeIssue{Reason: UpgradeRetrievingUpdatesFailed, Message: condition.Message})
+		}
+	}
+	return upgradeStatus, nil
+}
+
+// Returns the update history of the ClusterVersion, the most recent first. If any error occurs while performing this
+// operation, it will be returned
+func (u *OpenshiftUpgrader) GetUpdateHistory(ctx context.Context) ([]configv1.UpdateHistory, error) {
+	clusterVersion, err := u.getClusterVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return clusterVersion.Status.History, nil
+}
+
+// getDegradedClusterOperators returns the names of the cluster operators that are degraded or unavailable
+func getDegradedClusterOperators(ctx context.Context, c client.Client) ([]string, error) {
+	clusterOperators := configv1.ClusterOperatorList{}
+	if err := c.List(ctx, &clusterOperators); err != nil {
+		return nil, err
+	}
+	degradedOperators := make([]string, 0)
+	for _, clusterOperator := range clusterOperators.Items {
+		for _, condition := range clusterOperator.Status.Conditions {
+			if (condition.Type == configv1.OperatorDegraded && condition.Status == configv1.ConditionTrue) ||
+				(condition.Type == configv1.OperatorAvailable && condition.Status == configv1.ConditionFalse) {
+				degradedOperators = append(degradedOperators, clusterOperator.Name)
+				break
+			}
+		}
+	}
+	return degradedOperators, nil
+}
+
+func getClusterVersionCondition(clusterVersion configv1.ClusterVersion, conditionType configv1.ClusterStatusConditionType) *configv1.ClusterOperatorStatusCondition {
+	for i := range clusterVersion.Status.Conditions {
+		if clusterVersion.Status.Conditions[i].Type == conditionType {
+			return &clusterVersion.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
 func (u *OpenshiftUpgrader) getClusterVersion(ctx context.Context) (configv1.ClusterVersion, error) {
 	clusterVersion := configv1.ClusterVersion{}
 	if err := u.client.Get(ctx, types.NamespacedName{Name: ClusterVersionName}, &clusterVersion); err != nil {
@@ -170,8 +358,8 @@ func getOption(optionName string, options ...ClusterUpgradeOption) string {
 
 // checks whether the current proposed version is a GA release
 func isGARelease(desiredVersion, repositoryOverride string) bool {
-	// if override is not the default repos
-	if !slices.Contains([]string{release.OCPRepository, release.OKDRepository}, repositoryOverride) {
+	// if override is set and is not one of the default repos
+	if repositoryOverride != "" && !slices.Contains([]string{release.OCPRepository, release.OKDRepository}, repositoryOverride) {
 		return false
 	}
 	return release.IsGA(desiredVersion)
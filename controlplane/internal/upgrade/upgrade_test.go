@@ -8,9 +8,10 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/openshift-assisted/cluster-api-agent/controlplane/internal/upgrade"
-	"github.com/openshift-assisted/cluster-api-agent/controlplane/internal/workloadclient"
 	"github.com/openshift-assisted/cluster-api-agent/pkg/containers"
+	"github.com/openshift-assisted/cluster-api-agent/pkg/workloadclient"
 	configv1 "github.com/openshift/api/config/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -48,7 +49,7 @@ var _ = Describe("OpenShift Upgrader", func() {
 		}
 		clusterVersion = getClusterVersion(updateHistory)
 
-		upgradeFactory = upgrade.NewOpenshiftUpgradeFactory(mockRemoteImage, clientGenerator)
+		upgradeFactory = upgrade.NewOpenshiftUpgradeFactory(mockRemoteImage, clientGenerator, nil, false, nil)
 
 		fakeClient = fake.NewClientBuilder().
 			WithScheme(testScheme).
@@ -85,7 +86,7 @@ var _ = Describe("OpenShift Upgrader", func() {
 		var upgrader upgrade.OpenshiftUpgrader
 
 		BeforeEach(func() {
-			upgrader = upgrade.NewOpenshiftUpgrader(fakeClient, mockRemoteImage)
+			upgrader = upgrade.NewOpenshiftUpgrader(fakeClient, mockRemoteImage, nil, false, nil)
 		})
 
 		Context("IsUpgradeInProgress", func() {
@@ -128,6 +129,16 @@ var _ = Describe("OpenShift Upgrader", func() {
 			})
 		})
 
+		Context("GetUpdateHistory", func() {
+			It("should return the ClusterVersion history", func() {
+				history, err := upgrader.GetUpdateHistory(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(history).To(HaveLen(1))
+				Expect(history[0].Version).To(Equal("4.10.0"))
+				Expect(history[0].State).To(Equal(configv1.CompletedUpdate))
+			})
+		})
+
 		Context("IsDesiredVersionUpdated", func() {
 			It("should be updated", func() {
 				isUpdated, err := upgrader.IsDesiredVersionUpdated(ctx, "4.10.0")
@@ -142,6 +153,59 @@ var _ = Describe("OpenShift Upgrader", func() {
 
 		})
 
+		Context("GetUpgradeStatus", func() {
+			It("should return no issues for a healthy cluster", func() {
+				status, err := upgrader.GetUpgradeStatus(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(status.StartedTime).To(BeNil())
+				Expect(status.Issues).To(BeEmpty())
+			})
+
+			It("should report the issues of the ClusterVersion and the ClusterOperators", func() {
+				startedTime := metav1.Now()
+				clusterVersion.Status.History = append([]configv1.UpdateHistory{{
+					State:       configv1.PartialUpdate,
+					Version:     "4.11.0",
+					StartedTime: startedTime,
+				}}, clusterVersion.Status.History...)
+				clusterVersion.Status.Conditions = []configv1.ClusterOperatorStatusCondition{
+					{Type: "Failing", Status: configv1.ConditionTrue, Message: "Cluster operator ingress is degraded"},
+					{Type: "ReleaseAccepted", Status: configv1.ConditionTrue},
+					{Type: configv1.RetrievedUpdates, Status: configv1.ConditionFalse, Message: "no channel"},
+				}
+				Expect(fakeClient.Status().Update(ctx, &clusterVersion)).To(Succeed())
+				Expect(fakeClient.Create(ctx, getClusterOperator("ingress", configv1.OperatorDegraded, configv1.ConditionTrue))).To(Succeed())
+				Expect(fakeClient.Create(ctx, getClusterOperator("dns", configv1.OperatorAvailable, configv1.ConditionFalse))).To(Succeed())
+				Expect(fakeClient.Create(ctx, getClusterOperator("etcd", configv1.OperatorAvailable, configv1.ConditionTrue))).To(Succeed())
+
+				status, err := upgrader.GetUpgradeStatus(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(status.StartedTime).NotTo(BeNil())
+				Expect(status.StartedTime.Unix()).To(Equal(startedTime.Unix()))
+				Expect(status.Issues).To(Equal([]upgrade.UpgradeIssue{
+					{Reason: upgrade.UpgradeFailing, Message: "Cluster operator ingress is degraded"},
+					{Reason: upgrade.UpgradeClusterOperatorsDegraded, Message: "cluster operators degraded or unavailable: dns, ingress"},
+				}))
+			})
+
+			It("should report a release that is not accepted, and missing updates when upgrading by version", func() {
+				clusterVersion.Spec.DesiredUpdate = &configv1.Update{Version: "4.11.0"}
+				Expect(fakeClient.Update(ctx, &clusterVersion)).To(Succeed())
+				clusterVersion.Status.Conditions = []configv1.ClusterOperatorStatusCondition{
+					{Type: "ReleaseAccepted", Status: configv1.ConditionFalse, Message: "The update cannot be verified"},
+					{Type: configv1.RetrievedUpdates, Status: configv1.ConditionFalse, Message: "no channel"},
+				}
+				Expect(fakeClient.Status().Update(ctx, &clusterVersion)).To(Succeed())
+
+				status, err := upgrader.GetUpgradeStatus(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(status.Issues).To(Equal([]upgrade.UpgradeIssue{
+					{Reason: upgrade.UpgradeReleaseNotAccepted, Message: "The update cannot be verified"},
+					{Reason: upgrade.UpgradeRetrievingUpdatesFailed, Message: "no channel"},
+				}))
+			})
+		})
+
 		Context("UpdateClusterVersionDesiredUpdate", func() {
 			It("should update GA version without image", func() {
 				err := upgrader.UpdateClusterVersionDesiredUpdate(ctx, "4.11.0",
@@ -158,8 +222,26 @@ var _ = Describe("OpenShift Upgrader", func() {
 				Expect(updatedCV.Spec.DesiredUpdate.Version).To(Equal("4.11.0"))
 			})
 
+			It("should update GA version without image when the repository is not overridden", func() {
+				err := upgrader.UpdateClusterVersionDesiredUpdate(ctx, "4.11.0",
+					"x86_64",
+					upgrade.ClusterUpgradeOption{
+						Name:  upgrade.ReleaseImagePullSecretOption,
+						Value: pullsecret,
+					})
+				Expect(err).NotTo(HaveOccurred())
+
+				updatedCV := &configv1.ClusterVersion{}
+				Expect(fakeClient.Get(ctx, client.ObjectKey{Name: upgrade.ClusterVersionName}, updatedCV)).To(Succeed())
+				Expect(updatedCV.Spec.DesiredUpdate.Version).To(Equal("4.11.0"))
+				Expect(updatedCV.Spec.DesiredUpdate.Image).To(BeEmpty())
+			})
+
 			It("should update non-GA version with image", func() {
+				releaseVerifier := upgrade.NewMockReleaseVerifier(mockCtrl)
+				upgrader = upgrade.NewOpenshiftUpgrader(fakeClient, mockRemoteImage, releaseVerifier, true, nil)
 				mockRemoteImage.EXPECT().GetDigest("quay.io/openshift-release-dev/ocp-release:4.11.0-rc.1-x86_64", gomock.Any()).Return("sha256:123456", nil)
+				releaseVerifier.EXPECT().Verify(ctx, "quay.io/openshift-release-dev/ocp-release@sha256:123456", gomock.Any()).Return(nil)
 
 				err := upgrader.UpdateClusterVersionDesiredUpdate(ctx, "4.11.0-rc.1",
 					"x86_64",
@@ -175,10 +257,129 @@ var _ = Describe("OpenShift Upgrader", func() {
 				Expect(updatedCV.Spec.DesiredUpdate.Image).To(ContainSubstring("sha256:123456"))
 				Expect(updatedCV.Spec.DesiredUpdate.Force).To(BeTrue())
 			})
+
+			It("should not update to a release without a valid signature", func() {
+				releaseVerifier := upgrade.NewMockReleaseVerifier(mockCtrl)
+				upgrader = upgrade.NewOpenshiftUpgrader(fakeClient, mockRemoteImage, releaseVerifier, true, nil)
+				mockRemoteImage.EXPECT().GetDigest("quay.io/openshift-release-dev/ocp-release:4.11.0-rc.1-x86_64", gomock.Any()).Return("sha256:123456", nil)
+				releaseVerifier.EXPECT().Verify(ctx, "quay.io/openshift-release-dev/ocp-release@sha256:123456", gomock.Any()).
+					Return(fmt.Errorf("%w: no valid signature", upgrade.ErrReleaseSignatureVerification))
+
+				err := upgrader.UpdateClusterVersionDesiredUpdate(ctx, "4.11.0-rc.1",
+					"x86_64",
+					upgrade.ClusterUpgradeOption{
+						Name:  upgrade.ReleaseImagePullSecretOption,
+						Value: pullsecret,
+					})
+				Expect(err).To(MatchError(upgrade.ErrReleaseSignatureVerification))
+
+				updatedCV := &configv1.ClusterVersion{}
+				Expect(fakeClient.Get(ctx, client.ObjectKey{Name: upgrade.ClusterVersionName}, updatedCV)).To(Succeed())
+				Expect(updatedCV.Spec.DesiredUpdate).To(BeNil())
+			})
+
+			It("should force an update without verification when no release verifier is configured", func() {
+				mockRemoteImage.EXPECT().GetDigest("quay.io/openshift-release-dev/ocp-release:4.11.0-rc.1-x86_64", gomock.Any()).Return("sha256:123456", nil)
+
+				err := upgrader.UpdateClusterVersionDesiredUpdate(ctx, "4.11.0-rc.1",
+					"x86_64",
+					upgrade.ClusterUpgradeOption{
+						Name:  upgrade.ReleaseImagePullSecretOption,
+						Value: pullsecret,
+					})
+				Expect(err).NotTo(HaveOccurred())
+
+				updatedCV := &configv1.ClusterVersion{}
+				Expect(fakeClient.Get(ctx, client.ObjectKey{Name: upgrade.ClusterVersionName}, updatedCV)).To(Succeed())
+				Expect(updatedCV.Spec.DesiredUpdate.Image).To(Equal("quay.io/openshift-release-dev/ocp-release@sha256:123456"))
+				Expect(updatedCV.Spec.DesiredUpdate.Force).To(BeTrue())
+			})
+
+			It("should not force an update without a release verifier when verification is required", func() {
+				upgrader = upgrade.NewOpenshiftUpgrader(fakeClient, mockRemoteImage, nil, true, nil)
+				mockRemoteImage.EXPECT().GetDigest("quay.io/openshift-release-dev/ocp-release:4.11.0-rc.1-x86_64", gomock.Any()).Return("sha256:123456", nil)
+
+				err := upgrader.UpdateClusterVersionDesiredUpdate(ctx, "4.11.0-rc.1",
+					"x86_64",
+					upgrade.ClusterUpgradeOption{
+						Name:  upgrade.ReleaseImagePullSecretOption,
+						Value: pullsecret,
+					})
+				Expect(err).To(MatchError(upgrade.ErrReleaseSignatureVerification))
+
+				updatedCV := &configv1.ClusterVersion{}
+				Expect(fakeClient.Get(ctx, client.ObjectKey{Name: upgrade.ClusterVersionName}, updatedCV)).To(Succeed())
+				Expect(updatedCV.Spec.DesiredUpdate).To(BeNil())
+			})
+
+			It("should push the release signatures and update non-GA version with image without forcing", func() {
+				signatureSource := upgrade.NewMockReleaseSignatureSource(mockCtrl)
+				upgrader = upgrade.NewOpenshiftUpgrader(fakeClient, mockRemoteImage, nil, false, signatureSource)
+				mockRemoteImage.EXPECT().GetDigest("quay.io/openshift-release-dev/ocp-release:4.11.0-rc.1-x86_64", gomock.Any()).Return("sha256:123456", nil)
+				signatureSource.EXPECT().GetSignatures(ctx, "sha256:123456").Return([][]byte{[]byte("signature-1"), []byte("signature-2")}, nil)
+
+				err := upgrader.UpdateClusterVersionDesiredUpdate(ctx, "4.11.0-rc.1",
+					"x86_64",
+					upgrade.ClusterUpgradeOption{
+						Name:  upgrade.ReleaseImagePullSecretOption,
+						Value: pullsecret,
+					},
+					upgrade.ClusterUpgradeOption{
+						Name:  upgrade.PushReleaseSignaturesOption,
+						Value: "true",
+					})
+				Expect(err).NotTo(HaveOccurred())
+
+				signaturesConfigMap := &corev1.ConfigMap{}
+				Expect(fakeClient.Get(ctx, client.ObjectKey{Namespace: upgrade.ReleaseSignaturesNamespace, Name: "sha256-123456"}, signaturesConfigMap)).To(Succeed())
+				Expect(signaturesConfigMap.Labels).To(HaveKeyWithValue(upgrade.ReleaseSignaturesLabel, ""))
+				Expect(signaturesConfigMap.BinaryData).To(Equal(map[string][]byte{
+					"sha256-123456-1": []byte("signature-1"),
+					"sha256-123456-2": []byte("signature-2"),
+				}))
+				updatedCV := &configv1.ClusterVersion{}
+				Expect(fakeClient.Get(ctx, client.ObjectKey{Name: upgrade.ClusterVersionName}, updatedCV)).To(Succeed())
+				Expect(updatedCV.Spec.DesiredUpdate.Image).To(Equal("quay.io/openshift-release-dev/ocp-release@sha256:123456"))
+				Expect(updatedCV.Spec.DesiredUpdate.Force).To(BeFalse())
+			})
+
+			It("should not update to a release without signatures to push", func() {
+				signatureSource := upgrade.NewMockReleaseSignatureSource(mockCtrl)
+				upgrader = upgrade.NewOpenshiftUpgrader(fakeClient, mockRemoteImage, nil, false, signatureSource)
+				mockRemoteImage.EXPECT().GetDigest("quay.io/openshift-release-dev/ocp-release:4.11.0-rc.1-x86_64", gomock.Any()).Return("sha256:123456", nil)
+				signatureSource.EXPECT().GetSignatures(ctx, "sha256:123456").Return([][]byte{}, nil)
+
+				err := upgrader.UpdateClusterVersionDesiredUpdate(ctx, "4.11.0-rc.1",
+					"x86_64",
+					upgrade.ClusterUpgradeOption{
+						Name:  upgrade.ReleaseImagePullSecretOption,
+						Value: pullsecret,
+					},
+					upgrade.ClusterUpgradeOption{
+						Name:  upgrade.PushReleaseSignaturesOption,
+						Value: "true",
+					})
+				Expect(err).To(MatchError(upgrade.ErrReleaseSignatureNotFound))
+
+				updatedCV := &configv1.ClusterVersion{}
+				Expect(fakeClient.Get(ctx, client.ObjectKey{Name: upgrade.ClusterVersionName}, updatedCV)).To(Succeed())
+				Expect(updatedCV.Spec.DesiredUpdate).To(BeNil())
+			})
 		})
 	})
 })
 
+func getClusterOperator(name string, conditionType configv1.ClusterStatusConditionType, status configv1.ConditionStatus) *configv1.ClusterOperator {
+	return &configv1.ClusterOperator{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Status: configv1.ClusterOperatorStatus{
+			Conditions: []configv1.ClusterOperatorStatusCondition{{Type: conditionType, Status: status}},
+		},
+	}
+}
+
 func getClusterVersion(history []configv1.UpdateHistory) configv1.ClusterVersion {
 	return configv1.ClusterVersion{
 		ObjectMeta: metav1.ObjectMeta{
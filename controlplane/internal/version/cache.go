@@ -0,0 +1,190 @@
+package version
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// MaxConfigMapReleaseMetadataEntries is the number of releases persisted to the ConfigMap of the release metadata cache.
+// Persisting more releases drops the oldest ones, keeping the ConfigMap under the size limit of Kubernetes objects.
+const MaxConfigMapReleaseMetadataEntries = 100
+
+// ReleaseMetadata is the metadata of a release image, read from its image-references
+type ReleaseMetadata struct {
+	// Version is the OpenShift version of the release
+	Version string `json:"version,omitempty"`
+	// KubernetesVersion is the version of Kubernetes shipped by the release
+	KubernetesVersion string `json:"kubernetesVersion"`
+}
+
+// ReleaseMetadataCache caches the metadata of release images by digest. Release images are immutable by digest, so
+// cached entries never expire.
+//
+//go:generate mockgen -destination=mock_cache.go -package=version -source cache.go ReleaseMetadataCache
+type ReleaseMetadataCache interface {
+	Get(ctx context.Context, digest string) (*ReleaseMetadata, bool)
+	Set(ctx context.Context, digest string, metadata ReleaseMetadata)
+}
+
+// NewReleaseMetadataCache returns an in memory cache of release metadata
+func NewReleaseMetadataCache() *MemoryReleaseMetadataCache {
+	return &MemoryReleaseMetadataCache{entries: map[string]ReleaseMetadata{}}
+}
+
+type MemoryReleaseMetadataCache struct {
+	lock    sync.RWMutex
+	entries map[string]ReleaseMetadata
+}
+
+func (c *MemoryReleaseMetadataCache) Get(_ context.Context, digest string) (*ReleaseMetadata, bool) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	metadata, ok := c.entries[digest]
+	if !ok {
+		return nil, false
+	}
+	return &metadata, true
+}
+
+func (c *MemoryReleaseMetadataCache) Set(_ context.Context, digest string, metadata ReleaseMetadata) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.entries[digest] = metadata
+}
+
+// NewConfigMapReleaseMetadataCache returns an in memory cache of release metadata persisted to a ConfigMap, so that
+// the metadata survives restarts of the controller. The ConfigMap is created when missing.
+func NewConfigMapReleaseMetadataCache(c client.Client, namespace, name string) *ConfigMapReleaseMetadataCache {
+	return &ConfigMapReleaseMetadataCache{
+		memory:    NewReleaseMetadataCache(),
+		client:    c,
+		namespace: namespace,
+		name:      name,
+	}
+}
+
+type ConfigMapReleaseMetadataCache struct {
+	memory    *MemoryReleaseMetadataCache
+	client    client.Client
+	namespace string
+	name      string
+}
+
+// configMapCacheEntry is the release metadata persisted to the ConfigMap, with the time it was cached at to drop the
+// oldest entries
+type configMapCacheEntry struct {
+	ReleaseMetadata
+	CachedAt time.Time `json:"cachedAt,omitempty"`
+}
+
+// Get returns the metadata of the release from memory, or from the ConfigMap when it is not in memory yet. Failing to
+// read the ConfigMap is reported as a cache miss.
+func (c *ConfigMapReleaseMetadataCache) Get(ctx context.Context, digest string) (*ReleaseMetadata, bool) {
+	if metadata, ok := c.memory.Get(ctx, digest); ok {
+		return metadata, true
+	}
+	log := ctrl.LoggerFrom(ctx)
+	configMap := &corev1.ConfigMap{}
+	if err := c.client.Get(ctx, client.ObjectKey{Namespace: c.namespace, Name: c.name}, configMap); err != nil {
+		if !apierrors.IsNotFound(err) {
+			log.Error(err, "failed to read release metadata cache", "configmap", c.name, "namespace", c.namespace)
+		}
+		return nil, false
+	}
+	data, ok := configMap.Data[getConfigMapKey(digest)]
+	if !ok {
+		return nil, false
+	}
+	entry := configMapCacheEntry{}
+	if err := json.Unmarshal([]byte(data), &entry); err != nil {
+		log.Error(err, "invalid release metadata in cache", "digest", digest)
+		return nil, false
+	}
+	c.memory.Set(ctx, digest, entry.ReleaseMetadata)
+	return &entry.ReleaseMetadata, true
+}
+
+// Set stores the metadata of the release in memory and in the ConfigMap, dropping the oldest releases of the ConfigMap
+// beyond MaxConfigMapReleaseMetadataEntries. Failing to write the ConfigMap is logged, the metadata is still cached
+// in memory.
+func (c *ConfigMapReleaseMetadataCache) Set(ctx context.Context, digest string, metadata ReleaseMetadata) {
+	c.memory.Set(ctx, digest, metadata)
+	if err := c.persist(ctx, digest, metadata); err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "failed to persist release metadata cache", "configmap", c.name, "namespace", c.namespace)
+	}
+}
+
+func (c *ConfigMapReleaseMetadataCache) persist(ctx context.Context, digest string, metadata ReleaseMetadata) error {
+	data, err := json.Marshal(configMapCacheEntry{ReleaseMetadata: metadata, CachedAt: time.Now()})
+	if err != nil {
+		return err
+	}
+	configMap := &corev1.ConfigMap{}
+	err = c.client.Get(ctx, client.ObjectKey{Namespace: c.namespace, Name: c.name}, configMap)
+	if apierrors.IsNotFound(err) {
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: c.namespace, Name: c.name},
+			Data:       map[string]string{getConfigMapKey(digest): string(data)},
+		}
+		return c.client.Create(ctx, configMap)
+	}
+	if err != nil {
+		return err
+	}
+	if configMap.Data == nil {
+		configMap.Data = map[string]string{}
+	}
+	configMap.Data[getConfigMapKey(digest)] = string(data)
+	dropOldestEntries(configMap.Data, MaxConfigMapReleaseMetadataEntries)
+	return c.client.Update(ctx, configMap)
+}
+
+// dropOldestEntries deletes the entries cached first until at most maxEntries remain. Entries that cannot be parsed
+// are dropped first.
+func dropOldestEntries(data map[string]string, maxEntries int) {
+	if len(data) <= maxEntries {
+		return
+	}
+	cachedAt := make(map[string]time.Time, len(data))
+	keys := make([]string, 0, len(data))
+	for key, value := range data {
+		entry := configMapCacheEntry{}
+		if err := json.Unmarshal([]byte(value), &entry); err == nil {
+			cachedAt[key] = entry.CachedAt
+		}
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if !cachedAt[keys[i]].Equal(cachedAt[keys[j]]) {
+			return cachedAt[keys[i]].Before(cachedAt[keys[j]])
+		}
+		return keys[i] < keys[j]
+	})
+	for _, key := range keys[:len(keys)-maxEntries] {
+		delete(data, key)
+	}
+}
+
+// getConfigMapKey returns a valid ConfigMap key for the digest, as ConfigMap keys cannot contain ':'
+func getConfigMapKey(digest string) string {
+	return strings.ReplaceAll(digest, ":", "-")
+}
+
+// getDigestFromImageRef returns the digest of an image referenced by digest, or false if it is referenced by tag
+func getDigestFromImageRef(imageRef string) (string, bool) {
+	_, digest, found := strings.Cut(imageRef, "@")
+	if !found || digest == "" {
+		return "", false
+	}
+	return digest, true
+}
@@ -0,0 +1,146 @@
+package version_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/openshift-assisted/cluster-api-agent/controlplane/internal/version"
+	"github.com/openshift-assisted/cluster-api-agent/external_mocks"
+	"github.com/openshift-assisted/cluster-api-agent/pkg/containers"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	releaseDigest     = "sha256:573a86d57acab6dfb90799f568421e80a41f85aaef1e94a16e13af13339524c1"
+	releasePullSecret = `{"auths":{"cloud.openshift.com":{"auth":"Zm9vOmJhcgo="}}}`
+)
+
+var _ = Describe("ReleaseMetadataCache", func() {
+	var ctx context.Context
+
+	BeforeEach(func() {
+		ctx = context.Background()
+	})
+
+	It("caches release metadata in memory", func() {
+		cache := version.NewReleaseMetadataCache()
+		_, ok := cache.Get(ctx, releaseDigest)
+		Expect(ok).To(BeFalse())
+
+		cache.Set(ctx, releaseDigest, version.ReleaseMetadata{Version: "4.16.2", KubernetesVersion: "1.29.6"})
+		metadata, ok := cache.Get(ctx, releaseDigest)
+		Expect(ok).To(BeTrue())
+		Expect(metadata.KubernetesVersion).To(Equal("1.29.6"))
+	})
+
+	Context("persisted to a ConfigMap", func() {
+		var k8sClient client.Client
+
+		BeforeEach(func() {
+			k8sClient = fakeclient.NewClientBuilder().WithScheme(testScheme).Build()
+		})
+
+		It("restores the release metadata from the ConfigMap", func() {
+			cache := version.NewConfigMapReleaseMetadataCache(k8sClient, "test-namespace", "release-metadata")
+			cache.Set(ctx, releaseDigest, version.ReleaseMetadata{Version: "4.16.2", KubernetesVersion: "1.29.6"})
+			cache.Set(ctx, "sha256:0123", version.ReleaseMetadata{Version: "4.16.3", KubernetesVersion: "1.29.7"})
+
+			configMap := &corev1.ConfigMap{}
+			Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: "test-namespace", Name: "release-metadata"}, configMap)).To(Succeed())
+			Expect(configMap.Data).To(HaveLen(2))
+
+			restored := version.NewConfigMapReleaseMetadataCache(k8sClient, "test-namespace", "release-metadata")
+			metadata, ok := restored.Get(ctx, releaseDigest)
+			Expect(ok).To(BeTrue())
+			Expect(*metadata).To(Equal(version.ReleaseMetadata{Version: "4.16.2", KubernetesVersion: "1.29.6"}))
+			_, ok = restored.Get(ctx, "sha256:4567")
+			Expect(ok).To(BeFalse())
+		})
+
+		It("drops the oldest releases beyond the maximum number of entries", func() {
+			configMap := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "release-metadata"},
+				Data:       map[string]string{},
+			}
+			cachedAt := time.Now().Add(-time.Hour)
+			for i := 0; i < version.MaxConfigMapReleaseMetadataEntries; i++ {
+				configMap.Data[fmt.Sprintf("sha256-%04d", i)] = fmt.Sprintf(`{"version":"4.16.%d","kubernetesVersion":"1.29.6","cachedAt":%q}`,
+					i, cachedAt.Add(time.Duration(i)*time.Second).Format(time.RFC3339Nano))
+			}
+			Expect(k8sClient.Create(ctx, configMap)).To(Succeed())
+
+			cache := version.NewConfigMapReleaseMetadataCache(k8sClient, "test-namespace", "release-metadata")
+			cache.Set(ctx, releaseDigest, version.ReleaseMetadata{Version: "4.17.0", KubernetesVersion: "1.30.4"})
+
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(configMap), configMap)).To(Succeed())
+			Expect(configMap.Data).To(HaveLen(version.MaxConfigMapReleaseMetadataEntries))
+			Expect(configMap.Data).NotTo(HaveKey("sha256-0000"))
+			Expect(configMap.Data).To(HaveKey("sha256-0001"))
+
+			restored := version.NewConfigMapReleaseMetadataCache(k8sClient, "test-namespace", "release-metadata")
+			metadata, ok := restored.Get(ctx, releaseDigest)
+			Expect(ok).To(BeTrue())
+			Expect(*metadata).To(Equal(version.ReleaseMetadata{Version: "4.17.0", KubernetesVersion: "1.30.4"}))
+		})
+	})
+})
+
+var _ = Describe("Cached GetKubernetesVersion", func() {
+	var (
+		ctrl            *gomock.Controller
+		mockImage       *external_mocks.MockImage
+		mockRemoteImage *containers.MockRemoteImage
+		detector        version.KubernetesVersionDetector
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		mockImage = external_mocks.NewMockImage(ctrl)
+		mockRemoteImage = containers.NewMockRemoteImage(ctrl)
+		detector = version.NewCachedKubernetesVersionDetector(mockRemoteImage, version.NewReleaseMetadataCache())
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	expectImagePulled := func(imageRef string) {
+		tarFile, err := createTarWithReleaseManifest("1.29.6")
+		Expect(err).ToNot(HaveOccurred())
+		mockLayer := external_mocks.NewMockLayer(ctrl)
+		mockLayer.EXPECT().Uncompressed().Return(tarFile, nil)
+		mockImage.EXPECT().Layers().Return([]v1.Layer{mockLayer}, nil)
+		mockRemoteImage.EXPECT().GetImage(imageRef, gomock.Any()).Return(mockImage, nil)
+	}
+
+	It("pulls a release image referenced by tag once, by digest", func() {
+		imageRef := "quay.io/openshift-release-dev/ocp-release:4.16.2-x86_64"
+		mockRemoteImage.EXPECT().GetDigest(imageRef, gomock.Any()).Return(releaseDigest, nil).Times(2)
+		expectImagePulled("quay.io/openshift-release-dev/ocp-release@" + releaseDigest)
+
+		for i := 0; i < 2; i++ {
+			k8sVersion, err := detector.GetKubernetesVersion(imageRef, releasePullSecret)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*k8sVersion).To(Equal("1.29.6"))
+		}
+	})
+
+	It("does not resolve the digest of a release image referenced by digest", func() {
+		imageRef := "quay.io/openshift-release-dev/ocp-release@" + releaseDigest
+		expectImagePulled(imageRef)
+
+		for i := 0; i < 2; i++ {
+			k8sVersion, err := detector.GetKubernetesVersion(imageRef, releasePullSecret)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*k8sVersion).To(Equal("1.29.6"))
+		}
+	})
+})
@@ -0,0 +1,62 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: cache.go
+
+// Package version is a generated GoMock package.
+package version
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockReleaseMetadataCache is a mock of ReleaseMetadataCache interface.
+type MockReleaseMetadataCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockReleaseMetadataCacheMockRecorder
+}
+
+// MockReleaseMetadataCacheMockRecorder is the mock recorder for MockReleaseMetadataCache.
+type MockReleaseMetadataCacheMockRecorder struct {
+	mock *MockReleaseMetadataCache
+}
+
+// NewMockReleaseMetadataCache creates a new mock instance.
+func NewMockReleaseMetadataCache(ctrl *gomock.Controller) *MockReleaseMetadataCache {
+	mock := &MockReleaseMetadataCache{ctrl: ctrl}
+	mock.recorder = &MockReleaseMetadataCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReleaseMetadataCache) EXPECT() *MockReleaseMetadataCacheMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockReleaseMetadataCache) Get(ctx context.Context, digest string) (*ReleaseMetadata, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, digest)
+	ret0, _ := ret[0].(*ReleaseMetadata)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockReleaseMetadataCacheMockRecorder) Get(ctx, digest interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockReleaseMetadataCache)(nil).Get), ctx, digest)
+}
+
+// Set mocks base method.
+func (m *MockReleaseMetadataCache) Set(ctx context.Context, digest string, metadata ReleaseMetadata) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Set", ctx, digest, metadata)
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockReleaseMetadataCacheMockRecorder) Set(ctx, digest, metadata interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockReleaseMetadataCache)(nil).Set), ctx, digest, metadata)
+}
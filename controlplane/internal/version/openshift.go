@@ -6,7 +6,9 @@ import (
 	"slices"
 	"strings"
 
-	controlplanev1alpha2 "github.com/openshift-assisted/cluster-api-agent/controlplane/api/v1alpha2"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	controlplanev1beta1 "github.com/openshift-assisted/cluster-api-agent/controlplane/api/v1beta1"
 	"github.com/openshift-assisted/cluster-api-agent/controlplane/internal/release"
 	"github.com/openshift-assisted/cluster-api-agent/pkg/containers"
 	v1 "github.com/openshift/api/config/v1"
@@ -25,6 +27,7 @@ type OpenShiftKubernetesVersionDetectorType struct {
 	filepath                string
 	annotationBuildVersions string
 	remoteImageRepository   containers.RemoteImage
+	cache                   ReleaseMetadataCache
 }
 
 func NewKubernetesVersionDetector(remoteImageRepo containers.RemoteImage) KubernetesVersionDetector {
@@ -38,13 +41,67 @@ func NewKubernetesVersionDetector(remoteImageRepo containers.RemoteImage) Kubern
 	}
 }
 
+// NewCachedKubernetesVersionDetector returns a detector caching the metadata of the release images by digest, so that
+// the layers of a release image are only pulled once
+func NewCachedKubernetesVersionDetector(remoteImageRepo containers.RemoteImage, cache ReleaseMetadataCache) KubernetesVersionDetector {
+	detector := NewKubernetesVersionDetector(remoteImageRepo).(*OpenShiftKubernetesVersionDetectorType)
+	detector.cache = cache
+	return detector
+}
+
 func (o *OpenShiftKubernetesVersionDetectorType) GetKubernetesVersion(imageRef, pullsecret string) (*string, error) {
+	metadata, err := o.GetReleaseMetadata(imageRef, pullsecret)
+	if err != nil {
+		return nil, err
+	}
+	return &metadata.KubernetesVersion, nil
+}
 
+// GetReleaseMetadata returns the metadata of the release image. With a cache, the digest of the image is resolved
+// first, and the image is only pulled when its metadata is not cached yet.
+func (o *OpenShiftKubernetesVersionDetectorType) GetReleaseMetadata(imageRef, pullsecret string) (*ReleaseMetadata, error) {
 	auth, err := containers.PullSecretKeyChainFromString(pullsecret)
 	if err != nil {
 		return nil, fmt.Errorf("unable to load auth from pull-secret: %v", err)
 	}
+	if o.cache == nil {
+		return o.readReleaseMetadata(imageRef, auth)
+	}
+
+	ctx := context.Background()
+	digestImageRef, digest, err := o.getDigestImageRef(imageRef, auth)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get image digest: %v", err)
+	}
+	if metadata, ok := o.cache.Get(ctx, digest); ok {
+		return metadata, nil
+	}
+	metadata, err := o.readReleaseMetadata(digestImageRef, auth)
+	if err != nil {
+		return nil, err
+	}
+	o.cache.Set(ctx, digest, *metadata)
+	return metadata, nil
+}
+
+// getDigestImageRef returns the reference by digest of the image, and its digest. Images referenced by tag are pulled
+// by the resolved digest, so that the cached metadata matches the pulled image even when the tag moves.
+func (o *OpenShiftKubernetesVersionDetectorType) getDigestImageRef(imageRef string, auth authn.Keychain) (string, string, error) {
+	if digest, ok := getDigestFromImageRef(imageRef); ok {
+		return imageRef, digest, nil
+	}
+	digest, err := o.remoteImageRepository.GetDigest(imageRef, auth)
+	if err != nil {
+		return "", "", err
+	}
+	ref, err := name.ParseReference(imageRef)
+	if err != nil {
+		return "", "", err
+	}
+	return ref.Context().Digest(digest).String(), digest, nil
+}
 
+func (o *OpenShiftKubernetesVersionDetectorType) readReleaseMetadata(imageRef string, auth authn.Keychain) (*ReleaseMetadata, error) {
 	image, err := o.remoteImageRepository.GetImage(imageRef, auth)
 	if err != nil {
 		return nil, fmt.Errorf("unable to get image: %v", err)
@@ -66,7 +123,7 @@ func (o *OpenShiftKubernetesVersionDetectorType) GetKubernetesVersion(imageRef,
 	if err != nil {
 		return nil, fmt.Errorf("unable to extract k8s from release image-references: %v", err)
 	}
-	return &k8sVersion, nil
+	return &ReleaseMetadata{Version: is.Name, KubernetesVersion: k8sVersion}, nil
 }
 
 func (o *OpenShiftKubernetesVersionDetectorType) getK8sVersionFromImageStream(is imageapi.ImageStream) (string, error) {
@@ -87,7 +144,7 @@ func (o *OpenShiftKubernetesVersionDetectorType) getK8sVersionFromImageStream(is
 	return "", fmt.Errorf("unable to find kubernetes version")
 }
 
-func UpdateClusterVersionDesiredUpdate(c client.Client, ctx context.Context, oacp *controlplanev1alpha2.OpenshiftAssistedControlPlane, clusterVersion *v1.ClusterVersion, releaseImageWithDigest string) error {
+func UpdateClusterVersionDesiredUpdate(c client.Client, ctx context.Context, oacp *controlplanev1beta1.OpenshiftAssistedControlPlane, clusterVersion *v1.ClusterVersion, releaseImageWithDigest string) error {
 
 	if isGARelease(oacp) {
 		if clusterVersion.Spec.DesiredUpdate == nil || clusterVersion.Spec.DesiredUpdate.Version != oacp.Spec.DistributionVersion {
@@ -113,7 +170,7 @@ func doUpdate(c client.Client, ctx context.Context, clusterVersion *v1.ClusterVe
 }
 
 // checks whether the current proposed version is a GA release
-func isGARelease(oacp *controlplanev1alpha2.OpenshiftAssistedControlPlane) bool {
+func isGARelease(oacp *controlplanev1beta1.OpenshiftAssistedControlPlane) bool {
 	if releaseImageOverride, ok := oacp.Annotations[release.ReleaseImageRepositoryOverrideAnnotation]; ok {
 		// if override is not the default repos
 		if !slices.Contains([]string{release.OCPRepository, release.OKDRepository}, releaseImageOverride) {
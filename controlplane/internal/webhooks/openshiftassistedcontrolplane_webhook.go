@@ -0,0 +1,374 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"reflect"
+	"slices"
+
+	"github.com/blang/semver/v4"
+	controlplanev1beta1 "github.com/openshift-assisted/cluster-api-agent/controlplane/api/v1beta1"
+	"github.com/openshift-assisted/cluster-api-agent/controlplane/internal/controller"
+	"github.com/openshift-assisted/cluster-api-agent/controlplane/internal/upgrade"
+	"github.com/openshift-assisted/cluster-api-agent/util/cron"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var minVersion = semver.MustParse(controller.MinOpenShiftVersion)
+
+const openShiftSDNNetworkType = "OpenShiftSDN"
+
+func (webhook *OpenshiftAssistedControlPlane) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&controlplanev1beta1.OpenshiftAssistedControlPlane{}).
+		WithDefaulter(webhook).
+		WithValidator(webhook).
+		Complete()
+}
+
+// +kubebuilder:webhook:verbs=create;update,path=/mutate-controlplane-cluster-x-k8s-io-v1beta1-openshiftassistedcontrolplane,mutating=true,failurePolicy=fail,groups=controlplane.cluster.x-k8s.io,resources=openshiftassistedcontrolplanes,versions=v1beta1,name=default.openshiftassistedcontrolplane.controlplane.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1
+// +kubebuilder:webhook:verbs=create;update,path=/validate-controlplane-cluster-x-k8s-io-v1beta1-openshiftassistedcontrolplane,mutating=false,failurePolicy=fail,groups=controlplane.cluster.x-k8s.io,resources=openshiftassistedcontrolplanes,versions=v1beta1,name=validation.openshiftassistedcontrolplane.controlplane.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1
+
+// OpenshiftAssistedControlPlane implements a validation and defaulting webhook for OpenshiftAssistedControlPlane.
+type OpenshiftAssistedControlPlane struct{}
+
+var _ webhook.CustomDefaulter = &OpenshiftAssistedControlPlane{}
+var _ webhook.CustomValidator = &OpenshiftAssistedControlPlane{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the type.
+// The cluster name defaults to the name of the CAPI Cluster owning the control plane, and the
+// pull secret defaults to the placeholder pull secret created by the controller.
+func (webhook *OpenshiftAssistedControlPlane) Default(_ context.Context, obj runtime.Object) error {
+	oacp, ok := obj.(*controlplanev1beta1.OpenshiftAssistedControlPlane)
+	if !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("expected an OpenshiftAssistedControlPlane but got a %T", obj))
+	}
+
+	if oacp.Spec.Config.ClusterName == "" {
+		oacp.Spec.Config.ClusterName = oacp.Labels[clusterv1.ClusterNameLabel]
+	}
+	if oacp.Spec.Config.PullSecretRef == nil {
+		oacp.Spec.Config.PullSecretRef = &corev1.LocalObjectReference{Name: controller.PlaceholderPullSecretName}
+	}
+	return nil
+}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type.
+func (webhook *OpenshiftAssistedControlPlane) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	oacp, ok := obj.(*controlplanev1beta1.OpenshiftAssistedControlPlane)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected an OpenshiftAssistedControlPlane but got a %T", obj))
+	}
+
+	allErrs := validateSpec(&oacp.Spec, field.NewPath("spec"))
+	allErrs = append(allErrs, validateSkippedUpgradeHealthGates(oacp, field.NewPath("metadata", "annotations"))...)
+	if len(allErrs) > 0 {
+		return nil, apierrors.NewInvalid(controlplanev1beta1.GroupVersion.WithKind("OpenshiftAssistedControlPlane").GroupKind(), oacp.Name, allErrs)
+	}
+	return nil, nil
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type.
+func (webhook *OpenshiftAssistedControlPlane) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldOACP, ok := oldObj.(*controlplanev1beta1.OpenshiftAssistedControlPlane)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected an OpenshiftAssistedControlPlane but got a %T", oldObj))
+	}
+	newOACP, ok := newObj.(*controlplanev1beta1.OpenshiftAssistedControlPlane)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected an OpenshiftAssistedControlPlane but got a %T", newObj))
+	}
+
+	// objects being deleted are not validated, so that their finalizers can be removed
+	if !newOACP.DeletionTimestamp.IsZero() {
+		return nil, nil
+	}
+	// the spec is only validated when modified, so that objects created before a validation was introduced can still
+	// be updated, e.g. their metadata
+	var allErrs field.ErrorList
+	if !reflect.DeepEqual(oldOACP.Spec, newOACP.Spec) {
+		allErrs = append(allErrs, validateSpec(&newOACP.Spec, field.NewPath("spec"))...)
+	}
+	if oldOACP.Annotations[controlplanev1beta1.SkipUpgradeHealthGatesAnnotation] != newOACP.Annotations[controlplanev1beta1.SkipUpgradeHealthGatesAnnotation] {
+		allErrs = append(allErrs, validateSkippedUpgradeHealthGates(newOACP, field.NewPath("metadata", "annotations"))...)
+	}
+	// the Cluster controller only copies the control plane endpoint to the Cluster once
+	if oldOACP.Spec.ControlPlaneEndpoint.IsValid() && oldOACP.Spec.ControlPlaneEndpoint != newOACP.Spec.ControlPlaneEndpoint {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "controlPlaneEndpoint"), "cannot be modified once set"))
+	}
+	if oldOACP.Status.Initialized {
+		// the cluster name of control planes created before it was defaulted is the name of the CAPI Cluster,
+		// so defaulting it on update does not modify it
+		oldConfig := oldOACP.Spec.Config
+		if oldConfig.ClusterName == "" {
+			oldConfig.ClusterName = oldOACP.Labels[clusterv1.ClusterNameLabel]
+		}
+		allErrs = append(allErrs, validateImmutableFields(&oldConfig, &newOACP.Spec.Config, field.NewPath("spec", "config"))...)
+		allErrs = append(allErrs, validateReplicasUpdate(oldOACP.Spec.Replicas, newOACP.Spec.Replicas, field.NewPath("spec", "replicas"))...)
+	}
+	if len(allErrs) > 0 {
+		return nil, apierrors.NewInvalid(controlplanev1beta1.GroupVersion.WithKind("OpenshiftAssistedControlPlane").GroupKind(), newOACP.Name, allErrs)
+	}
+	return nil, nil
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type.
+func (webhook *OpenshiftAssistedControlPlane) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateSkippedUpgradeHealthGates refuses skipping upgrade health gates that do not exist, as a misspelled gate
+// would still be checked
+func validateSkippedUpgradeHealthGates(oacp *controlplanev1beta1.OpenshiftAssistedControlPlane, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	gateNames := make([]string, 0)
+	for _, gate := range upgrade.DefaultHealthGates() {
+		gateNames = append(gateNames, gate.Name())
+	}
+	annotationPath := fldPath.Key(controlplanev1beta1.SkipUpgradeHealthGatesAnnotation)
+	for _, gate := range controller.GetSkippedUpgradeHealthGates(oacp) {
+		if !slices.Contains(gateNames, gate) {
+			allErrs = append(allErrs, field.NotSupported(annotationPath, gate, gateNames))
+		}
+	}
+	return allErrs
+}
+
+func validateSpec(spec *controlplanev1beta1.OpenshiftAssistedControlPlaneSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	// any version format is accepted (i.e. latest), but parsable versions must not be lower than the minimum
+	if version, err := semver.ParseTolerant(spec.DistributionVersion); err == nil && version.LT(minVersion) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("distributionVersion"), spec.DistributionVersion,
+			fmt.Sprintf("version is not supported, the minimum supported version is %s", controller.MinOpenShiftVersion)))
+	}
+
+	configPath := fldPath.Child("config")
+	if spec.Config.BaseDomain == "" {
+		allErrs = append(allErrs, field.Required(configPath.Child("baseDomain"), "baseDomain must be set"))
+	}
+
+	if err := controller.ValidateBaselineCapability(spec.Config.Capabilities.BaselineCapability); err != nil {
+		allErrs = append(allErrs, field.Invalid(configPath.Child("capabilities", "baselineCapability"),
+			spec.Config.Capabilities.BaselineCapability, err.Error()))
+	}
+
+	allErrs = append(allErrs, validateVIPs(spec.Config.APIVIPs, spec.Config.IngressVIPs, configPath)...)
+	allErrs = append(allErrs, validateNetworking(&spec.Config, configPath)...)
+	allErrs = append(allErrs, validateRolloutStrategy(spec.RolloutStrategy, spec.Replicas, fldPath.Child("rolloutStrategy"))...)
+	allErrs = append(allErrs, validateUpgradeStrategy(spec.UpgradeStrategy, fldPath.Child("upgradeStrategy"))...)
+	return allErrs
+}
+
+// validateUpgradeStrategy ensures the maintenance window schedule is a valid cron expression and the window has a duration.
+func validateUpgradeStrategy(strategy *controlplanev1beta1.UpgradeStrategy, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if strategy == nil || strategy.MaintenanceWindow == nil {
+		return allErrs
+	}
+
+	windowPath := fldPath.Child("maintenanceWindow")
+	if _, err := cron.Parse(strategy.MaintenanceWindow.Schedule); err != nil {
+		allErrs = append(allErrs, field.Invalid(windowPath.Child("schedule"), strategy.MaintenanceWindow.Schedule, err.Error()))
+	}
+	if strategy.MaintenanceWindow.Duration.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(windowPath.Child("duration"), strategy.MaintenanceWindow.Duration.String(),
+			"must be greater than 0"))
+	}
+	return allErrs
+}
+
+// validateRolloutStrategy ensures at most one machine is added during a rollout, and that removing a machine
+// before adding its replacement (maxSurge 0) keeps etcd quorum.
+func validateRolloutStrategy(strategy *controlplanev1beta1.RolloutStrategy, replicas int32, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if strategy == nil || strategy.RollingUpdate == nil || strategy.RollingUpdate.MaxSurge == nil {
+		return allErrs
+	}
+
+	maxSurgePath := fldPath.Child("rollingUpdate", "maxSurge")
+	maxSurge := strategy.RollingUpdate.MaxSurge
+	if maxSurge.Type != intstr.Int || (maxSurge.IntVal != 0 && maxSurge.IntVal != 1) {
+		allErrs = append(allErrs, field.Invalid(maxSurgePath, maxSurge.String(), "must be either 0 or 1"))
+		return allErrs
+	}
+	if maxSurge.IntVal == 0 && replicas < 3 {
+		allErrs = append(allErrs, field.Invalid(maxSurgePath, maxSurge.String(),
+			"must be 1 when the control plane has less than 3 replicas"))
+	}
+	return allErrs
+}
+
+// validateVIPs ensures API and ingress VIPs are either both unset or set with the same number of valid IPs,
+// as a partial configuration would silently fall back to a non-baremetal platform.
+func validateVIPs(apiVIPs, ingressVIPs []string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, vip := range apiVIPs {
+		if net.ParseIP(vip) == nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("apiVIPs").Index(i), vip, "must be a valid IP address"))
+		}
+	}
+	for i, vip := range ingressVIPs {
+		if net.ParseIP(vip) == nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("ingressVIPs").Index(i), vip, "must be a valid IP address"))
+		}
+	}
+	allErrs = append(allErrs, validateDualStackVIPs(apiVIPs, fldPath.Child("apiVIPs"))...)
+	allErrs = append(allErrs, validateDualStackVIPs(ingressVIPs, fldPath.Child("ingressVIPs"))...)
+	if len(apiVIPs) != len(ingressVIPs) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("ingressVIPs"), ingressVIPs,
+			fmt.Sprintf("the number of ingressVIPs (%d) must match the number of apiVIPs (%d)", len(ingressVIPs), len(apiVIPs))))
+	}
+	return allErrs
+}
+
+// validateDualStackVIPs ensures dual-stack VIPs hold one IP address per IP stack.
+func validateDualStackVIPs(vips []string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if len(vips) != 2 || net.ParseIP(vips[0]) == nil || net.ParseIP(vips[1]) == nil {
+		return allErrs
+	}
+	if (net.ParseIP(vips[0]).To4() != nil) == (net.ParseIP(vips[1]).To4() != nil) {
+		allErrs = append(allErrs, field.Invalid(fldPath, vips, "dual-stack VIPs must have one IPv4 and one IPv6 address"))
+	}
+	return allErrs
+}
+
+// validateNetworking ensures the networks are valid CIDRs, host prefixes fit in their cluster network, the OVN-Kubernetes
+// internal subnets match their IP stack, and the machine networks and VIPs list the IP stacks in the same order.
+// Cluster and service networks are defined on the CAPI Cluster, so their IP stack order is validated by the controller.
+func validateNetworking(config *controlplanev1beta1.OpenshiftAssistedControlPlaneConfigSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	networking := config.Networking
+	if networking == nil {
+		return allErrs
+	}
+
+	networkingPath := fldPath.Child("networking")
+	for i, cidr := range networking.MachineNetwork {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			allErrs = append(allErrs, field.Invalid(networkingPath.Child("machineNetwork").Index(i), cidr, "must be a valid CIDR"))
+		}
+	}
+	for i, hostPrefix := range networking.HostPrefixes {
+		hostPrefixPath := networkingPath.Child("hostPrefixes").Index(i)
+		_, ipNet, err := net.ParseCIDR(hostPrefix.CIDR)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(hostPrefixPath.Child("cidr"), hostPrefix.CIDR, "must be a valid CIDR"))
+			continue
+		}
+		prefixLength, bits := ipNet.Mask.Size()
+		if int(hostPrefix.HostPrefix) < prefixLength || int(hostPrefix.HostPrefix) > bits {
+			allErrs = append(allErrs, field.Invalid(hostPrefixPath.Child("hostPrefix"), hostPrefix.HostPrefix,
+				fmt.Sprintf("must be between the prefix length of the CIDR (%d) and %d", prefixLength, bits)))
+		}
+	}
+	if networking.OVNKubernetesConfig != nil {
+		ovnPath := networkingPath.Child("ovnKubernetesConfig")
+		if networking.NetworkType == openShiftSDNNetworkType {
+			allErrs = append(allErrs, field.Forbidden(ovnPath, fmt.Sprintf("cannot be set with the %s network type", openShiftSDNNetworkType)))
+		}
+		allErrs = append(allErrs, validateOVNKubernetesInternalSubnets(networking.OVNKubernetesConfig.IPv4, false, ovnPath.Child("ipv4"))...)
+		allErrs = append(allErrs, validateOVNKubernetesInternalSubnets(networking.OVNKubernetesConfig.IPv6, true, ovnPath.Child("ipv6"))...)
+	}
+	if len(allErrs) > 0 {
+		return allErrs
+	}
+
+	if err := controller.ValidateIPStackOrder(
+		controller.IPAddressPool{Name: "machineNetwork", Addresses: networking.MachineNetwork},
+		controller.IPAddressPool{Name: "apiVIPs", Addresses: config.APIVIPs},
+		controller.IPAddressPool{Name: "ingressVIPs", Addresses: config.IngressVIPs},
+	); err != nil {
+		allErrs = append(allErrs, field.Invalid(networkingPath.Child("machineNetwork"), networking.MachineNetwork, err.Error()))
+	}
+	return allErrs
+}
+
+func validateOVNKubernetesInternalSubnets(subnets *controlplanev1beta1.OVNKubernetesInternalSubnets, ipv6 bool, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if subnets == nil {
+		return allErrs
+	}
+	stack := "IPv4"
+	if ipv6 {
+		stack = "IPv6"
+	}
+	isValid := func(subnet string) bool {
+		ip, _, err := net.ParseCIDR(subnet)
+		return err == nil && (ip.To4() == nil) == ipv6
+	}
+	if subnets.InternalJoinSubnet != "" && !isValid(subnets.InternalJoinSubnet) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("internalJoinSubnet"), subnets.InternalJoinSubnet,
+			fmt.Sprintf("must be a valid %s CIDR", stack)))
+	}
+	if subnets.InternalTransitSwitchSubnet != "" && !isValid(subnets.InternalTransitSwitchSubnet) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("internalTransitSwitchSubnet"), subnets.InternalTransitSwitchSubnet,
+			fmt.Sprintf("must be a valid %s CIDR", stack)))
+	}
+	return allErrs
+}
+
+// validateReplicasUpdate ensures the topology of an installed control plane only changes through a supported path:
+// a Single Node OpenShift control plane can be scaled up to 3 replicas, but a highly available control plane
+// cannot be scaled down to a single replica.
+func validateReplicasUpdate(oldReplicas, newReplicas int32, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if oldReplicas == 1 && newReplicas != 1 && newReplicas != 3 {
+		allErrs = append(allErrs, field.Invalid(fldPath, newReplicas,
+			"a single node control plane can only be scaled up to 3 replicas once the control plane is initialized"))
+	}
+	if oldReplicas > 1 && newReplicas == 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath, newReplicas,
+			"cannot scale down to a single node control plane once the control plane is initialized"))
+	}
+	return allErrs
+}
+
+// validateImmutableFields rejects changes to the fields that cannot be modified once the cluster is installed.
+// Cluster and service networks are defined on the CAPI Cluster, so on this resource the network
+// configuration is defined by its VIPs and networking.
+func validateImmutableFields(oldConfig, newConfig *controlplanev1beta1.OpenshiftAssistedControlPlaneConfigSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if oldConfig.BaseDomain != newConfig.BaseDomain {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("baseDomain"), "cannot be modified once the control plane is initialized"))
+	}
+	if oldConfig.ClusterName != newConfig.ClusterName {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("clusterName"), "cannot be modified once the control plane is initialized"))
+	}
+	if !reflect.DeepEqual(oldConfig.APIVIPs, newConfig.APIVIPs) {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("apiVIPs"), "cannot be modified once the control plane is initialized"))
+	}
+	if !reflect.DeepEqual(oldConfig.IngressVIPs, newConfig.IngressVIPs) {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("ingressVIPs"), "cannot be modified once the control plane is initialized"))
+	}
+	if !reflect.DeepEqual(oldConfig.Networking, newConfig.Networking) {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("networking"), "cannot be modified once the control plane is initialized"))
+	}
+	return allErrs
+}
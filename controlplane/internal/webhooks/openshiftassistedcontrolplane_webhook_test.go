@@ -0,0 +1,306 @@
+package webhooks_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	controlplanev1beta1 "github.com/openshift-assisted/cluster-api-agent/controlplane/api/v1beta1"
+	"github.com/openshift-assisted/cluster-api-agent/controlplane/internal/webhooks"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+var _ = Describe("OpenshiftAssistedControlPlane webhook", func() {
+	var (
+		ctx     = context.Background()
+		webhook *webhooks.OpenshiftAssistedControlPlane
+		oacp    *controlplanev1beta1.OpenshiftAssistedControlPlane
+	)
+
+	BeforeEach(func() {
+		webhook = &webhooks.OpenshiftAssistedControlPlane{}
+		oacp = &controlplanev1beta1.OpenshiftAssistedControlPlane{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-oacp",
+				Namespace: "test",
+				Labels:    map[string]string{clusterv1.ClusterNameLabel: "test-cluster"},
+			},
+			Spec: controlplanev1beta1.OpenshiftAssistedControlPlaneSpec{
+				DistributionVersion: "4.16.0",
+				Config: controlplanev1beta1.OpenshiftAssistedControlPlaneConfigSpec{
+					BaseDomain: "example.com",
+				},
+			},
+		}
+	})
+
+	Context("Default", func() {
+		It("defaults cluster name and pull secret", func() {
+			Expect(webhook.Default(ctx, oacp)).To(Succeed())
+			Expect(oacp.Spec.Config.ClusterName).To(Equal("test-cluster"))
+			Expect(oacp.Spec.Config.PullSecretRef).NotTo(BeNil())
+			Expect(oacp.Spec.Config.PullSecretRef.Name).To(Equal("placeholder-pull-secret"))
+		})
+		It("does not override user provided values", func() {
+			oacp.Spec.Config.ClusterName = "my-cluster"
+			oacp.Spec.Config.PullSecretRef = &corev1.LocalObjectReference{Name: "my-pull-secret"}
+			Expect(webhook.Default(ctx, oacp)).To(Succeed())
+			Expect(oacp.Spec.Config.ClusterName).To(Equal("my-cluster"))
+			Expect(oacp.Spec.Config.PullSecretRef.Name).To(Equal("my-pull-secret"))
+		})
+	})
+
+	Context("ValidateCreate", func() {
+		It("accepts a valid control plane", func() {
+			_, err := webhook.ValidateCreate(ctx, oacp)
+			Expect(err).NotTo(HaveOccurred())
+		})
+		It("accepts a non semantic distribution version", func() {
+			oacp.Spec.DistributionVersion = "latest"
+			_, err := webhook.ValidateCreate(ctx, oacp)
+			Expect(err).NotTo(HaveOccurred())
+		})
+		It("rejects a distribution version lower than the minimum supported", func() {
+			oacp.Spec.DistributionVersion = "4.13.5"
+			_, err := webhook.ValidateCreate(ctx, oacp)
+			Expect(err).To(MatchError(ContainSubstring("spec.distributionVersion")))
+		})
+		It("rejects an invalid baseline capability", func() {
+			oacp.Spec.Config.Capabilities.BaselineCapability = "invalid"
+			_, err := webhook.ValidateCreate(ctx, oacp)
+			Expect(err).To(MatchError(ContainSubstring("spec.config.capabilities.baselineCapability")))
+		})
+		It("rejects an API VIP without an ingress VIP", func() {
+			oacp.Spec.Config.APIVIPs = []string{"192.168.111.5"}
+			_, err := webhook.ValidateCreate(ctx, oacp)
+			Expect(err).To(MatchError(ContainSubstring("spec.config.ingressVIPs")))
+		})
+		It("rejects invalid VIPs", func() {
+			oacp.Spec.Config.APIVIPs = []string{"192.168.111.5"}
+			oacp.Spec.Config.IngressVIPs = []string{"not-an-ip"}
+			_, err := webhook.ValidateCreate(ctx, oacp)
+			Expect(err).To(MatchError(ContainSubstring("spec.config.ingressVIPs[0]")))
+		})
+		It("rejects dual-stack VIPs of the same IP stack", func() {
+			oacp.Spec.Config.APIVIPs = []string{"192.168.111.5", "192.168.111.6"}
+			oacp.Spec.Config.IngressVIPs = []string{"192.168.111.4", "fd2e:6f44:5dd8::4"}
+			_, err := webhook.ValidateCreate(ctx, oacp)
+			Expect(err).To(MatchError(ContainSubstring("spec.config.apiVIPs")))
+			Expect(err).NotTo(MatchError(ContainSubstring("spec.config.ingressVIPs")))
+		})
+		It("accepts a dual-stack networking configuration", func() {
+			oacp.Spec.Config.APIVIPs = []string{"fd2e:6f44:5dd8::5", "192.168.111.5"}
+			oacp.Spec.Config.IngressVIPs = []string{"fd2e:6f44:5dd8::4", "192.168.111.4"}
+			oacp.Spec.Config.Networking = &controlplanev1beta1.Networking{
+				MachineNetwork: []string{"fd2e:6f44:5dd8::/64", "192.168.111.0/24"},
+				HostPrefixes:   []controlplanev1beta1.HostPrefix{{CIDR: "fd01::/48", HostPrefix: 64}},
+				NetworkType:    "OVNKubernetes",
+				OVNKubernetesConfig: &controlplanev1beta1.OVNKubernetesConfig{
+					IPv4: &controlplanev1beta1.OVNKubernetesInternalSubnets{InternalJoinSubnet: "100.65.0.0/16"},
+					IPv6: &controlplanev1beta1.OVNKubernetesInternalSubnets{InternalTransitSwitchSubnet: "fd96::/64"},
+				},
+			}
+			_, err := webhook.ValidateCreate(ctx, oacp)
+			Expect(err).NotTo(HaveOccurred())
+		})
+		It("rejects machine networks and VIPs in a different IP stack order", func() {
+			oacp.Spec.Config.APIVIPs = []string{"192.168.111.5", "fd2e:6f44:5dd8::5"}
+			oacp.Spec.Config.IngressVIPs = []string{"192.168.111.4", "fd2e:6f44:5dd8::4"}
+			oacp.Spec.Config.Networking = &controlplanev1beta1.Networking{
+				MachineNetwork: []string{"fd2e:6f44:5dd8::/64", "192.168.111.0/24"},
+			}
+			_, err := webhook.ValidateCreate(ctx, oacp)
+			Expect(err).To(MatchError(ContainSubstring("spec.config.networking.machineNetwork")))
+			Expect(err).To(MatchError(ContainSubstring("the IP stacks must be in the same order")))
+		})
+		It("rejects an invalid networking configuration", func() {
+			oacp.Spec.Config.Networking = &controlplanev1beta1.Networking{
+				MachineNetwork: []string{"192.168.111.0"},
+				HostPrefixes: []controlplanev1beta1.HostPrefix{
+					{CIDR: "10.128.0.0/14", HostPrefix: 12},
+					{CIDR: "fd01::", HostPrefix: 64},
+				},
+				NetworkType: "OpenShiftSDN",
+				OVNKubernetesConfig: &controlplanev1beta1.OVNKubernetesConfig{
+					IPv4: &controlplanev1beta1.OVNKubernetesInternalSubnets{InternalJoinSubnet: "fd98::/64"},
+					IPv6: &controlplanev1beta1.OVNKubernetesInternalSubnets{InternalTransitSwitchSubnet: "100.88.0.0/16"},
+				},
+			}
+			_, err := webhook.ValidateCreate(ctx, oacp)
+			Expect(err).To(MatchError(ContainSubstring("spec.config.networking.machineNetwork[0]")))
+			Expect(err).To(MatchError(ContainSubstring("spec.config.networking.hostPrefixes[0].hostPrefix")))
+			Expect(err).To(MatchError(ContainSubstring("spec.config.networking.hostPrefixes[1].cidr")))
+			Expect(err).To(MatchError(ContainSubstring("spec.config.networking.ovnKubernetesConfig: Forbidden")))
+			Expect(err).To(MatchError(ContainSubstring("spec.config.networking.ovnKubernetesConfig.ipv4.internalJoinSubnet")))
+			Expect(err).To(MatchError(ContainSubstring("spec.config.networking.ovnKubernetesConfig.ipv6.internalTransitSwitchSubnet")))
+		})
+		It("rejects a missing base domain", func() {
+			oacp.Spec.Config.BaseDomain = ""
+			_, err := webhook.ValidateCreate(ctx, oacp)
+			Expect(err).To(MatchError(ContainSubstring("spec.config.baseDomain")))
+		})
+		It("rejects a maxSurge other than 0 or 1", func() {
+			maxSurge := intstr.FromInt32(2)
+			oacp.Spec.RolloutStrategy = &controlplanev1beta1.RolloutStrategy{
+				RollingUpdate: &controlplanev1beta1.RollingUpdate{MaxSurge: &maxSurge},
+			}
+			_, err := webhook.ValidateCreate(ctx, oacp)
+			Expect(err).To(MatchError(ContainSubstring("spec.rolloutStrategy.rollingUpdate.maxSurge")))
+		})
+		It("rejects a maxSurge of 0 with less than 3 replicas", func() {
+			maxSurge := intstr.FromInt32(0)
+			oacp.Spec.Replicas = 1
+			oacp.Spec.RolloutStrategy = &controlplanev1beta1.RolloutStrategy{
+				RollingUpdate: &controlplanev1beta1.RollingUpdate{MaxSurge: &maxSurge},
+			}
+			_, err := webhook.ValidateCreate(ctx, oacp)
+			Expect(err).To(MatchError(ContainSubstring("spec.rolloutStrategy.rollingUpdate.maxSurge")))
+
+			oacp.Spec.Replicas = 3
+			_, err = webhook.ValidateCreate(ctx, oacp)
+			Expect(err).NotTo(HaveOccurred())
+		})
+		It("rejects an invalid maintenance window", func() {
+			oacp.Spec.UpgradeStrategy = &controlplanev1beta1.UpgradeStrategy{
+				MaintenanceWindow: &controlplanev1beta1.MaintenanceWindow{Schedule: "0 25 * * *"},
+			}
+			_, err := webhook.ValidateCreate(ctx, oacp)
+			Expect(err).To(MatchError(ContainSubstring("spec.upgradeStrategy.maintenanceWindow.schedule")))
+			Expect(err).To(MatchError(ContainSubstring("spec.upgradeStrategy.maintenanceWindow.duration")))
+
+			oacp.Spec.UpgradeStrategy.MaintenanceWindow = &controlplanev1beta1.MaintenanceWindow{
+				Schedule: "0 22 * * sat",
+				Duration: metav1.Duration{Duration: 4 * time.Hour},
+			}
+			_, err = webhook.ValidateCreate(ctx, oacp)
+			Expect(err).NotTo(HaveOccurred())
+		})
+		It("rejects skipping an unknown upgrade health gate", func() {
+			oacp.Annotations = map[string]string{controlplanev1beta1.SkipUpgradeHealthGatesAnnotation: "Etcd, MachineConfigPool"}
+			_, err := webhook.ValidateCreate(ctx, oacp)
+			Expect(err).To(MatchError(ContainSubstring(controlplanev1beta1.SkipUpgradeHealthGatesAnnotation)))
+			Expect(err).To(MatchError(ContainSubstring(`"MachineConfigPool"`)))
+
+			oacp.Annotations[controlplanev1beta1.SkipUpgradeHealthGatesAnnotation] = "Etcd, MachineConfigPools"
+			_, err = webhook.ValidateCreate(ctx, oacp)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Context("ValidateUpdate", func() {
+		var oldOACP *controlplanev1beta1.OpenshiftAssistedControlPlane
+
+		BeforeEach(func() {
+			oacp.Spec.Config.ClusterName = "test-cluster"
+			oacp.Spec.Config.APIVIPs = []string{"192.168.111.5"}
+			oacp.Spec.Config.IngressVIPs = []string{"192.168.111.4"}
+			oldOACP = oacp.DeepCopy()
+		})
+
+		It("allows changing the base domain before the control plane is initialized", func() {
+			oacp.Spec.Config.BaseDomain = "other.com"
+			_, err := webhook.ValidateUpdate(ctx, oldOACP, oacp)
+			Expect(err).NotTo(HaveOccurred())
+		})
+		It("rejects changes to immutable fields once the control plane is initialized", func() {
+			oldOACP.Status.Initialized = true
+			oacp.Spec.Config.BaseDomain = "other.com"
+			oacp.Spec.Config.ClusterName = "other-cluster"
+			oacp.Spec.Config.APIVIPs = []string{"192.168.111.6"}
+			oacp.Spec.Config.IngressVIPs = []string{"192.168.111.7"}
+			oacp.Spec.Config.Networking = &controlplanev1beta1.Networking{NetworkType: "OVNKubernetes"}
+			_, err := webhook.ValidateUpdate(ctx, oldOACP, oacp)
+			Expect(err).To(MatchError(ContainSubstring("spec.config.baseDomain")))
+			Expect(err).To(MatchError(ContainSubstring("spec.config.clusterName")))
+			Expect(err).To(MatchError(ContainSubstring("spec.config.apiVIPs")))
+			Expect(err).To(MatchError(ContainSubstring("spec.config.ingressVIPs")))
+			Expect(err).To(MatchError(ContainSubstring("spec.config.networking")))
+		})
+		It("allows defaulting the cluster name of an initialized control plane created without one", func() {
+			oldOACP.Status.Initialized = true
+			oldOACP.Spec.Config.ClusterName = ""
+			oacp.Spec.Config.ClusterName = ""
+			oacp.Spec.ControlPlaneEndpoint = clusterv1.APIEndpoint{Host: "192.168.111.5", Port: 6443}
+			Expect(webhook.Default(ctx, oacp)).To(Succeed())
+			Expect(oacp.Spec.Config.ClusterName).To(Equal("test-cluster"))
+			_, err := webhook.ValidateUpdate(ctx, oldOACP, oacp)
+			Expect(err).NotTo(HaveOccurred())
+
+			oacp.Spec.Config.ClusterName = "other-cluster"
+			_, err = webhook.ValidateUpdate(ctx, oldOACP, oacp)
+			Expect(err).To(MatchError(ContainSubstring("spec.config.clusterName")))
+		})
+		It("allows changing mutable fields once the control plane is initialized", func() {
+			oldOACP.Status.Initialized = true
+			oacp.Spec.DistributionVersion = "4.17.0"
+			oacp.Spec.Replicas = 3
+			_, err := webhook.ValidateUpdate(ctx, oldOACP, oacp)
+			Expect(err).NotTo(HaveOccurred())
+		})
+		It("rejects changes to the control plane endpoint once set", func() {
+			oacp.Spec.ControlPlaneEndpoint = clusterv1.APIEndpoint{Host: "192.168.111.5", Port: 6443}
+			_, err := webhook.ValidateUpdate(ctx, oldOACP, oacp)
+			Expect(err).NotTo(HaveOccurred())
+
+			oldOACP.Spec.ControlPlaneEndpoint = oacp.Spec.ControlPlaneEndpoint
+			oacp.Spec.ControlPlaneEndpoint.Host = "192.168.111.6"
+			_, err = webhook.ValidateUpdate(ctx, oldOACP, oacp)
+			Expect(err).To(MatchError(ContainSubstring("spec.controlPlaneEndpoint")))
+		})
+		It("allows scaling a single node control plane up to 3 replicas once the control plane is initialized", func() {
+			oldOACP.Status.Initialized = true
+			oldOACP.Spec.Replicas = 1
+			oacp.Spec.Replicas = 3
+			_, err := webhook.ValidateUpdate(ctx, oldOACP, oacp)
+			Expect(err).NotTo(HaveOccurred())
+
+			oacp.Spec.Replicas = 2
+			_, err = webhook.ValidateUpdate(ctx, oldOACP, oacp)
+			Expect(err).To(MatchError(ContainSubstring("spec.replicas")))
+		})
+		It("validates the spec only when it is modified", func() {
+			oldOACP.Spec.DistributionVersion = "4.12.0"
+			oacp.Spec.DistributionVersion = "4.12.0"
+			oacp.Finalizers = []string{"test-finalizer"}
+			_, err := webhook.ValidateUpdate(ctx, oldOACP, oacp)
+			Expect(err).NotTo(HaveOccurred())
+
+			oacp.Spec.Replicas = 5
+			_, err = webhook.ValidateUpdate(ctx, oldOACP, oacp)
+			Expect(err).To(MatchError(ContainSubstring("spec.distributionVersion")))
+		})
+		It("validates the skipped upgrade health gates only when they are modified", func() {
+			oldOACP.Annotations = map[string]string{controlplanev1beta1.SkipUpgradeHealthGatesAnnotation: "Unknown"}
+			oacp.Annotations = map[string]string{controlplanev1beta1.SkipUpgradeHealthGatesAnnotation: "Unknown"}
+			_, err := webhook.ValidateUpdate(ctx, oldOACP, oacp)
+			Expect(err).NotTo(HaveOccurred())
+
+			oacp.Annotations[controlplanev1beta1.SkipUpgradeHealthGatesAnnotation] = "Etcd, Unknown"
+			_, err = webhook.ValidateUpdate(ctx, oldOACP, oacp)
+			Expect(err).To(MatchError(ContainSubstring(controlplanev1beta1.SkipUpgradeHealthGatesAnnotation)))
+		})
+		It("does not validate a control plane being deleted", func() {
+			oldOACP.Spec.DistributionVersion = "4.12.0"
+			oacp.Spec.DistributionVersion = "4.12.0"
+			oacp.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+			oacp.Finalizers = nil
+			_, err := webhook.ValidateUpdate(ctx, oldOACP, oacp)
+			Expect(err).NotTo(HaveOccurred())
+		})
+		It("rejects scaling down to a single node control plane once the control plane is initialized", func() {
+			oldOACP.Status.Initialized = true
+			oldOACP.Spec.Replicas = 3
+			oacp.Spec.Replicas = 1
+			_, err := webhook.ValidateUpdate(ctx, oldOACP, oacp)
+			Expect(err).To(MatchError(ContainSubstring("spec.replicas")))
+
+			oldOACP.Status.Initialized = false
+			_, err = webhook.ValidateUpdate(ctx, oldOACP, oacp)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})
@@ -19,16 +19,20 @@ package main
 import (
 	"crypto/tls"
 	"flag"
+	"fmt"
 	"os"
+	"strings"
+	"time"
 
+	"github.com/openshift-assisted/cluster-api-agent/controlplane/internal/etcd"
 	"github.com/openshift-assisted/cluster-api-agent/controlplane/internal/upgrade"
-	"github.com/openshift-assisted/cluster-api-agent/controlplane/internal/workloadclient"
+	"github.com/openshift-assisted/cluster-api-agent/pkg/workloadclient"
 
 	"github.com/openshift-assisted/cluster-api-agent/pkg/containers"
 
 	"github.com/openshift-assisted/cluster-api-agent/controlplane/internal/version"
 
-	bootstrapv1alpha1 "github.com/openshift-assisted/cluster-api-agent/bootstrap/api/v1alpha1"
+	bootstrapv1beta1 "github.com/openshift-assisted/cluster-api-agent/bootstrap/api/v1beta1"
 	hiveext "github.com/openshift/assisted-service/api/hiveextension/v1beta1"
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -46,8 +50,10 @@ import (
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
-	controlplanev1alpha1 "github.com/openshift-assisted/cluster-api-agent/controlplane/api/v1alpha2"
+	controlplanev1alpha2 "github.com/openshift-assisted/cluster-api-agent/controlplane/api/v1alpha2"
+	controlplanev1beta1 "github.com/openshift-assisted/cluster-api-agent/controlplane/api/v1beta1"
 	controlplanecontroller "github.com/openshift-assisted/cluster-api-agent/controlplane/internal/controller"
+	controlplanewebhooks "github.com/openshift-assisted/cluster-api-agent/controlplane/internal/webhooks"
 )
 
 var (
@@ -57,11 +63,12 @@ var (
 
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
-	utilruntime.Must(controlplanev1alpha1.AddToScheme(scheme))
+	utilruntime.Must(controlplanev1alpha2.AddToScheme(scheme))
+	utilruntime.Must(controlplanev1beta1.AddToScheme(scheme))
 	utilruntime.Must(clusterv1.AddToScheme(scheme))
 	utilruntime.Must(hivev1.AddToScheme(scheme))
 	utilruntime.Must(hiveext.AddToScheme(scheme))
-	utilruntime.Must(bootstrapv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(bootstrapv1beta1.AddToScheme(scheme))
 
 	//+kubebuilder:scaffold:scheme
 }
@@ -72,6 +79,15 @@ func main() {
 	var probeAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
+	var upgradeGraphURL string
+	var upgradeGraphConfigMap string
+	var upgradeGraphChannel string
+	var upgradeTimeout time.Duration
+	var releaseMetadataConfigMap string
+	var releaseSignatureKeysSecret string
+	var requireReleaseSignatureVerification bool
+	var releaseSignatureStoreURL string
+	var releaseSignatureConfigMap string
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
@@ -81,6 +97,31 @@ func main() {
 		"If set the metrics endpoint is served securely")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.StringVar(&upgradeGraphURL, "upgrade-graph-url", "",
+		"The URL of the OpenShift Update Service graph API used to validate upgrade paths, "+
+			"e.g. https://api.openshift.com/api/upgrades_info/v1/graph")
+	flag.StringVar(&upgradeGraphConfigMap, "upgrade-graph-configmap", "",
+		"The <namespace>/<name> of a ConfigMap holding the update graph used to validate upgrade paths, "+
+			"for disconnected environments. Takes precedence over --upgrade-graph-url")
+	flag.StringVar(&upgradeGraphChannel, "upgrade-graph-channel", upgrade.DefaultUpgradeChannel,
+		"The channel of the update graph queried for the desired version, e.g. stable, fast, candidate or eus. "+
+			"The <major>.<minor> of the desired version is appended to it")
+	flag.DurationVar(&upgradeTimeout, "upgrade-timeout", 4*time.Hour,
+		"The time after which an upgrade that did not complete is reported as a failure of the control plane, 0 to disable it")
+	flag.StringVar(&releaseMetadataConfigMap, "release-metadata-configmap", "",
+		"The <namespace>/<name> of a ConfigMap persisting the metadata of the release images, "+
+			"so that release images are not pulled again after a restart. The ConfigMap is created when missing")
+	flag.StringVar(&releaseSignatureKeysSecret, "release-signature-keys-secret", "",
+		"The <namespace>/<name> of a Secret holding the keys trusted to sign release images: OpenPGP keyrings (.gpg, .asc) "+
+			"and cosign public keys (.pub, .pem). Releases upgraded to by image are verified with these keys before forcing the upgrade, "+
+			"and are forced unverified when unset, unless --require-release-signature-verification is set")
+	flag.BoolVar(&requireReleaseSignatureVerification, "require-release-signature-verification", false,
+		"Refuse to force upgrades to releases upgraded to by image when no release signature keys are configured")
+	flag.StringVar(&releaseSignatureStoreURL, "release-signature-store-url", upgrade.DefaultReleaseSignatureStoreURL,
+		"The URL of the store of the OpenPGP release signatures")
+	flag.StringVar(&releaseSignatureConfigMap, "release-signature-configmap", "",
+		"The <namespace>/<name> of a ConfigMap holding the OpenPGP release signatures, keyed <algorithm>-<hash>-<n>, "+
+			"for disconnected environments. Takes precedence over --release-signature-store-url")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -137,12 +178,59 @@ func main() {
 		os.Exit(1)
 	}
 	releaseImageRepository := containers.NewRemoteImageRepository()
+	var upgradeGraphProvider upgrade.UpgradeGraphProvider
+	if upgradeGraphConfigMap != "" {
+		namespace, name, found := strings.Cut(upgradeGraphConfigMap, "/")
+		if !found {
+			setupLog.Error(fmt.Errorf("expected <namespace>/<name>, got %s", upgradeGraphConfigMap), "invalid upgrade graph ConfigMap")
+			os.Exit(1)
+		}
+		upgradeGraphProvider = upgrade.NewConfigMapUpgradeGraphProvider(mgr.GetAPIReader(), namespace, name)
+	} else if upgradeGraphURL != "" {
+		upgradeGraphProvider = upgrade.NewURLUpgradeGraphProvider(upgradeGraphURL)
+	}
+	var releaseMetadataCache version.ReleaseMetadataCache = version.NewReleaseMetadataCache()
+	if releaseMetadataConfigMap != "" {
+		namespace, name, found := strings.Cut(releaseMetadataConfigMap, "/")
+		if !found {
+			setupLog.Error(fmt.Errorf("expected <namespace>/<name>, got %s", releaseMetadataConfigMap), "invalid release metadata ConfigMap")
+			os.Exit(1)
+		}
+		releaseMetadataCache = version.NewConfigMapReleaseMetadataCache(mgr.GetClient(), namespace, name)
+	}
+	var releaseSignatureSource upgrade.ReleaseSignatureSource = upgrade.NewStoreReleaseSignatureSource(releaseSignatureStoreURL)
+	if releaseSignatureConfigMap != "" {
+		namespace, name, found := strings.Cut(releaseSignatureConfigMap, "/")
+		if !found {
+			setupLog.Error(fmt.Errorf("expected <namespace>/<name>, got %s", releaseSignatureConfigMap), "invalid release signature ConfigMap")
+			os.Exit(1)
+		}
+		releaseSignatureSource = upgrade.NewConfigMapReleaseSignatureSource(mgr.GetAPIReader(), namespace, name)
+	}
+	var releaseVerifier upgrade.ReleaseVerifier
+	if releaseSignatureKeysSecret != "" {
+		namespace, name, found := strings.Cut(releaseSignatureKeysSecret, "/")
+		if !found {
+			setupLog.Error(fmt.Errorf("expected <namespace>/<name>, got %s", releaseSignatureKeysSecret), "invalid release signature keys Secret")
+			os.Exit(1)
+		}
+		releaseVerifier = upgrade.NewReleaseSignatureVerifier(mgr.GetAPIReader(), namespace, name, releaseSignatureSource, releaseImageRepository)
+	} else {
+		setupLog.Info("no release signature keys configured: upgrades to releases that are not GA or mirrored are not verified",
+			"requireReleaseSignatureVerification", requireReleaseSignatureVerification)
+	}
 	clientGenerator := workloadclient.NewWorkloadClusterClientGenerator()
 	if err = (&controlplanecontroller.OpenshiftAssistedControlPlaneReconciler{
-		Client:             mgr.GetClient(),
-		Scheme:             mgr.GetScheme(),
-		K8sVersionDetector: version.NewKubernetesVersionDetector(releaseImageRepository),
-		UpgradeFactory:     upgrade.NewOpenshiftUpgradeFactory(releaseImageRepository, clientGenerator),
+		Client:                    mgr.GetClient(),
+		Scheme:                    mgr.GetScheme(),
+		K8sVersionDetector:        version.NewCachedKubernetesVersionDetector(releaseImageRepository, releaseMetadataCache),
+		UpgradeFactory:            upgrade.NewOpenshiftUpgradeFactory(releaseImageRepository, clientGenerator, releaseVerifier, requireReleaseSignatureVerification, releaseSignatureSource),
+		UpgradeGraphProvider:      upgradeGraphProvider,
+		UpgradeChannel:            upgradeGraphChannel,
+		UpgradeTimeout:            upgradeTimeout,
+		UpgradeHealthGates:        upgrade.DefaultHealthGates(),
+		WorkloadClientGenerator:   clientGenerator,
+		EtcdMemberClientGenerator: etcd.NewMemberClientGenerator(),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "OpenshiftAssistedControlPlane")
 		os.Exit(1)
@@ -155,12 +243,21 @@ func main() {
 		os.Exit(1)
 	}
 	if err = (&controlplanecontroller.AgentClusterInstallReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:                  mgr.GetClient(),
+		Scheme:                  mgr.GetScheme(),
+		WorkloadClientGenerator: clientGenerator,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "AgentClusterInstall")
 		os.Exit(1)
 	}
+	// webhooks can be disabled to run the manager locally without certificates.
+	// The conversion webhook is served for all the types registered in the scheme.
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		if err = (&controlplanewebhooks.OpenshiftAssistedControlPlane{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "OpenshiftAssistedControlPlane")
+			os.Exit(1)
+		}
+	}
 	//+kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
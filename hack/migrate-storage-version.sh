@@ -0,0 +1,31 @@
+#!/usr/bin/env bash
+# Rewrites all the stored objects of the provider CRDs to their current storage version and
+# drops the previous versions from the CRDs' status.storedVersions.
+# This is only needed when the providers are not upgraded through `clusterctl upgrade`, which
+# performs the same migration. The new version of the providers (including the conversion
+# webhooks) must be running before executing this script.
+set -o errexit
+set -o nounset
+set -o pipefail
+
+KUBECTL=${KUBECTL:-kubectl}
+CRDS=(
+  openshiftassistedcontrolplanes.controlplane.cluster.x-k8s.io
+  openshiftassistedcontrolplanetemplates.controlplane.cluster.x-k8s.io
+  openshiftassistedconfigs.bootstrap.cluster.x-k8s.io
+  openshiftassistedconfigtemplates.bootstrap.cluster.x-k8s.io
+)
+
+for crd in "${CRDS[@]}"; do
+  storage_version=$(${KUBECTL} get crd "${crd}" -o jsonpath='{.spec.versions[?(@.storage==true)].name}')
+  echo "Migrating ${crd} to ${storage_version}"
+
+  # an empty patch is enough for the API server to write the object back in the storage version
+  ${KUBECTL} get "${crd}" --all-namespaces -o jsonpath='{range .items[*]}{.metadata.namespace}{" "}{.metadata.name}{"\n"}{end}' |
+    while read -r namespace name; do
+      ${KUBECTL} patch "${crd}" "${name}" --namespace "${namespace}" --type merge --patch '{}'
+    done
+
+  ${KUBECTL} patch crd "${crd}" --subresource status --type merge \
+    --patch "{\"status\":{\"storedVersions\":[\"${storage_version}\"]}}"
+done
@@ -11,7 +11,11 @@ import (
 	v1 "github.com/google/go-containerregistry/pkg/v1"
 )
 
-const maxSymlinkDepth = 5
+const (
+	maxSymlinkDepth = 5
+	// whiteoutPrefix marks the files deleted by a layer
+	whiteoutPrefix = ".wh."
+)
 
 func (e *ImageInspector) ExtractFileFromImage(filePath string) ([]byte, error) {
 	if e.image == nil {
@@ -20,7 +24,8 @@ func (e *ImageInspector) ExtractFileFromImage(filePath string) ([]byte, error) {
 	return e.extractFileFromImageInternal(e.image, filePath, 0)
 }
 
-// ExtractFileFromImage pulls an OCI Image and extracts a file, resolving symlinks
+// ExtractFileFromImage pulls an OCI Image and extracts a file, resolving symlinks. Layers are read from the latest to
+// the oldest, and the walk stops at the first layer holding the file, or deleting it.
 func (e *ImageInspector) extractFileFromImageInternal(image v1.Image, filePath string, depth int) ([]byte, error) {
 	if depth > maxSymlinkDepth {
 		return nil, fmt.Errorf("too many symlink resolutions, possible loop")
@@ -33,50 +38,73 @@ func (e *ImageInspector) extractFileFromImageInternal(image v1.Image, filePath s
 
 	// Iterate from the latest layer to the oldest
 	for i := len(layers) - 1; i >= 0; i-- {
-		layer := layers[i]
-		layerReader, err := layer.Uncompressed()
+		entry, err := findFileInLayer(layers[i], filePath)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get layer data: %w", err)
+			return nil, err
 		}
-
-		tr := tar.NewReader(layerReader)
-		for {
-			header, err := tr.Next()
-			if err == io.EOF {
-				break
-			}
-			if err != nil {
-				return nil, fmt.Errorf("error reading tar: %w", err)
+		if entry == nil {
+			continue
+		}
+		if entry.deleted {
+			break
+		}
+		// If it's a symlink, follow it
+		if entry.linkTarget != "" {
+			resolvedPath := entry.linkTarget
+			if !strings.HasPrefix(resolvedPath, "/") {
+				resolvedPath = filepath.Join(filepath.Dir(filePath), resolvedPath)
 			}
+			return e.extractFileFromImageInternal(image, resolvedPath, depth+1)
+		}
+		return entry.content, nil
+	}
 
-			// Normalize the tar file path
-			tarPath := "/" + header.Name
-			// If it's a symlink, follow it
-			if tarPath == filePath && header.Typeflag == tar.TypeSymlink {
+	return nil, fmt.Errorf("file %s not found in Image", filePath)
+}
 
-				resolvedPath := header.Linkname
-				if !strings.HasPrefix(resolvedPath, "/") {
-					resolvedPath = filepath.Join(filepath.Dir(filePath), resolvedPath)
-				}
-				return e.extractFileFromImageInternal(image, resolvedPath, depth+1) // Follow symlink
-			}
+// layerEntry is a file found in a layer
+type layerEntry struct {
+	content    []byte
+	linkTarget string
+	// deleted is true when the layer deletes the file with a whiteout
+	deleted bool
+}
 
-			// If it's the actual file, return contents
-			if tarPath == filePath {
+// findFileInLayer returns the file of the layer, or nil when the layer does not hold the file. The layer is only read
+// until the file is found.
+func findFileInLayer(layer v1.Layer, filePath string) (*layerEntry, error) {
+	layerReader, err := layer.Uncompressed()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get layer data: %w", err)
+	}
+	defer layerReader.Close()
 
-				var buf bytes.Buffer
-				_, err := io.Copy(&buf, tr)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read file content: %w", err)
-				}
-				return buf.Bytes(), nil
-			}
+	whiteoutPath := filepath.Join(filepath.Dir(filePath), whiteoutPrefix+filepath.Base(filePath))
+	tr := tar.NewReader(layerReader)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil, nil
 		}
-		err = layerReader.Close()
 		if err != nil {
-			return nil, fmt.Errorf("failed to close tar: %w", err)
+			return nil, fmt.Errorf("error reading tar: %w", err)
 		}
-	}
 
-	return nil, fmt.Errorf("file %s not found in Image", filePath)
+		// Normalize the tar file path
+		tarPath := filepath.Join("/", header.Name)
+		if tarPath == whiteoutPath {
+			return &layerEntry{deleted: true}, nil
+		}
+		if tarPath != filePath {
+			continue
+		}
+		if header.Typeflag == tar.TypeSymlink {
+			return &layerEntry{linkTarget: header.Linkname}, nil
+		}
+		var buf bytes.Buffer
+		if _, err := io.Copy(&buf, tr); err != nil {
+			return nil, fmt.Errorf("failed to read file content: %w", err)
+		}
+		return &layerEntry{content: buf.Bytes()}, nil
+	}
 }
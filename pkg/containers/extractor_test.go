@@ -160,5 +160,54 @@ var _ = Describe("ImageInspector", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(string(content)).To(Equal("New data"))
 		})
+
+		It("should not find a file deleted by a newer layer", func() {
+			// the older layer holding the file is not read, as the newer layer deletes it
+			mockLayer1 := external_mocks.NewMockLayer(ctrl)
+			mockLayer1.EXPECT().Uncompressed().DoAndReturn(
+				func() (io.ReadCloser, error) {
+					return test.CreateTarArchive(test.TarArchive{
+						Filepath: "dir/test.txt",
+						Content:  "Deleted data",
+					}), nil
+				}).Times(0)
+			mockLayer2 := external_mocks.NewMockLayer(ctrl)
+			mockLayer2.EXPECT().Uncompressed().Return(test.CreateTarArchive(test.TarArchive{
+				Filepath: "dir/.wh.test.txt",
+			}), nil)
+
+			mockImage := external_mocks.NewMockImage(ctrl)
+			mockImage.EXPECT().Layers().Return([]v1.Layer{mockLayer1, mockLayer2}, nil)
+
+			var err error
+			extractor, err = containers.NewImageInspector(mockImage)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = extractor.ExtractFileFromImage("/dir/test.txt")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("file /dir/test.txt not found"))
+		})
+
+		It("should find a file of an older layer when a newer layer deletes another file", func() {
+			mockLayer1 := external_mocks.NewMockLayer(ctrl)
+			mockLayer1.EXPECT().Uncompressed().Return(test.CreateTarArchive(test.TarArchive{
+				Filepath: "dir/test.txt",
+				Content:  "Old data",
+			}), nil)
+			mockLayer2 := external_mocks.NewMockLayer(ctrl)
+			mockLayer2.EXPECT().Uncompressed().Return(test.CreateTarArchive(test.TarArchive{
+				Filepath: "other/.wh.test.txt",
+			}), nil)
+
+			mockImage := external_mocks.NewMockImage(ctrl)
+			mockImage.EXPECT().Layers().Return([]v1.Layer{mockLayer1, mockLayer2}, nil)
+
+			var err error
+			var content []byte
+			extractor, err = containers.NewImageInspector(mockImage)
+			Expect(err).NotTo(HaveOccurred())
+			content, err = extractor.ExtractFileFromImage("/dir/test.txt")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(Equal("Old data"))
+		})
 	})
 })
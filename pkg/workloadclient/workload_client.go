@@ -9,6 +9,7 @@ import (
 	configv1 "github.com/openshift/api/config/v1"
 
 	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/clientcmd"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -39,6 +40,9 @@ func (w *WorkloadClusterClientGenerator) GetWorkloadClusterClient(kubeconfig []b
 	if err := configv1.Install(schemes); err != nil {
 		return nil, err
 	}
+	if err := clientgoscheme.AddToScheme(schemes); err != nil {
+		return nil, err
+	}
 	targetClient, err := client.New(restConfig, client.Options{Scheme: schemes})
 	if err != nil {
 		return nil, err
@@ -3,8 +3,8 @@ package utils
 import (
 	"github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
 	metal3 "github.com/metal3-io/cluster-api-provider-metal3/api/v1beta1"
-	v1alpha2 "github.com/openshift-assisted/cluster-api-agent/bootstrap/api/v1alpha1"
-	controlplanev1alpha1 "github.com/openshift-assisted/cluster-api-agent/controlplane/api/v1alpha2"
+	bootstrapv1beta1 "github.com/openshift-assisted/cluster-api-agent/bootstrap/api/v1beta1"
+	controlplanev1beta1 "github.com/openshift-assisted/cluster-api-agent/controlplane/api/v1beta1"
 	hiveext "github.com/openshift/assisted-service/api/hiveextension/v1beta1"
 	"github.com/openshift/assisted-service/api/v1beta1"
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
@@ -76,7 +76,7 @@ func NewCluster(clusterName, namespace string) *clusterv1.Cluster {
 
 func NewMachineWithInfraRef(
 	machineName, namespace, clusterName string,
-	acp *controlplanev1alpha1.OpenshiftAssistedControlPlane,
+	acp *controlplanev1beta1.OpenshiftAssistedControlPlane,
 	infraRef client.Object,
 ) *clusterv1.Machine {
 	infraRefGVK := infraRef.GetObjectKind().GroupVersionKind()
@@ -133,17 +133,17 @@ func NewM3MachineTemplate(namespace, name string) *metal3.Metal3MachineTemplate
 	}
 }
 
-func NewOpenshiftAssistedControlPlane(namespace, name string) *controlplanev1alpha1.OpenshiftAssistedControlPlane {
-	return &controlplanev1alpha1.OpenshiftAssistedControlPlane{
+func NewOpenshiftAssistedControlPlane(namespace, name string) *controlplanev1beta1.OpenshiftAssistedControlPlane {
+	return &controlplanev1beta1.OpenshiftAssistedControlPlane{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "OpenshiftAssistedControlPlane",
-			APIVersion: controlplanev1alpha1.GroupVersion.String(),
+			APIVersion: controlplanev1beta1.GroupVersion.String(),
 		},
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
 			Namespace: namespace,
 		},
-		Spec: controlplanev1alpha1.OpenshiftAssistedControlPlaneSpec{
+		Spec: controlplanev1beta1.OpenshiftAssistedControlPlaneSpec{
 			DistributionVersion: "4.18.0",
 		},
 	}
@@ -154,7 +154,7 @@ func NewOpenshiftAssistedControlPlaneWithCapabilities(
 	replicas int32,
 	baselineCapability string,
 	additionalCapabilities []string,
-) *controlplanev1alpha1.OpenshiftAssistedControlPlane {
+) *controlplanev1beta1.OpenshiftAssistedControlPlane {
 	oacp := NewOpenshiftAssistedControlPlane(namespace, name)
 	oacp.Spec.Config.Capabilities.BaselineCapability = baselineCapability
 	oacp.Spec.Config.Capabilities.AdditionalEnabledCapabilities = additionalCapabilities
@@ -165,7 +165,7 @@ func NewOpenshiftAssistedControlPlaneWithCapabilities(
 func NewOpenshiftAssistedControlPlaneWithMachineTemplate(
 	namespace, name string,
 	m3Template *metal3.Metal3MachineTemplate,
-) *controlplanev1alpha1.OpenshiftAssistedControlPlane {
+) *controlplanev1beta1.OpenshiftAssistedControlPlane {
 	acp := NewOpenshiftAssistedControlPlane(namespace, name)
 	acp.Spec.MachineTemplate.InfrastructureRef = corev1.ObjectReference{
 		Kind:       m3Template.Kind,
@@ -242,7 +242,7 @@ func NewBareMetalHost(namespace, name string) *v1alpha1.BareMetalHost {
 func NewOpenshiftAssistedConfigWithInfraEnv(
 	namespace, name, clusterName string,
 	infraEnv *v1beta1.InfraEnv,
-) *v1alpha2.OpenshiftAssistedConfig {
+) *bootstrapv1beta1.OpenshiftAssistedConfig {
 	var ref *corev1.ObjectReference
 	if infraEnv != nil {
 		ref = &corev1.ObjectReference{
@@ -250,7 +250,7 @@ func NewOpenshiftAssistedConfigWithInfraEnv(
 			Name:      infraEnv.GetName(),
 		}
 	}
-	return &v1alpha2.OpenshiftAssistedConfig{
+	return &bootstrapv1beta1.OpenshiftAssistedConfig{
 		ObjectMeta: metav1.ObjectMeta{
 			Labels: map[string]string{
 				clusterv1.ClusterNameLabel:         clusterName,
@@ -259,12 +259,12 @@ func NewOpenshiftAssistedConfigWithInfraEnv(
 			Name:      name,
 			Namespace: namespace,
 		},
-		Status: v1alpha2.OpenshiftAssistedConfigStatus{
+		Status: bootstrapv1beta1.OpenshiftAssistedConfigStatus{
 			InfraEnvRef: ref,
 		},
 	}
 }
 
-func NewOpenshiftAssistedConfig(namespace, name, clusterName string) *v1alpha2.OpenshiftAssistedConfig {
+func NewOpenshiftAssistedConfig(namespace, name, clusterName string) *bootstrapv1beta1.OpenshiftAssistedConfig {
 	return NewOpenshiftAssistedConfigWithInfraEnv(namespace, name, clusterName, nil)
 }
@@ -0,0 +1,188 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxSearchYears bounds the search of the next activation, for schedules that never match (i.e. February 30th)
+const maxSearchYears = 5
+
+var (
+	monthNames = map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}
+	dayOfWeekNames = map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}
+)
+
+type fieldBounds struct {
+	name  string
+	min   int
+	max   int
+	names map[string]int
+}
+
+var (
+	minuteBounds     = fieldBounds{name: "minute", min: 0, max: 59}
+	hourBounds       = fieldBounds{name: "hour", min: 0, max: 23}
+	dayOfMonthBounds = fieldBounds{name: "day of month", min: 1, max: 31}
+	monthBounds      = fieldBounds{name: "month", min: 1, max: 12, names: monthNames}
+	// 7 is an alias of Sunday
+	dayOfWeekBounds = fieldBounds{name: "day of week", min: 0, max: 7, names: dayOfWeekNames}
+)
+
+// Schedule is a parsed cron expression with 5 fields: minute, hour, day of month, month and day of week
+type Schedule struct {
+	minutes     uint64
+	hours       uint64
+	daysOfMonth uint64
+	months      uint64
+	daysOfWeek  uint64
+	// as in standard cron, when both the day of month and the day of week are restricted,
+	// a day matches if either of them matches
+	dayOfMonthStar bool
+	dayOfWeekStar  bool
+}
+
+// Parse parses a standard cron expression. Each field is either `*`, a value, a range `a-b`, any of them followed by
+// a step `/n`, or a comma separated list of those. Months and days of week also accept 3 letters names.
+func Parse(expression string) (*Schedule, error) {
+	fields := strings.Fields(expression)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid cron expression %q: expected 5 fields, got %d", expression, len(fields))
+	}
+	schedule := &Schedule{
+		dayOfMonthStar: strings.HasPrefix(fields[2], "*"),
+		dayOfWeekStar:  strings.HasPrefix(fields[4], "*"),
+	}
+	var err error
+	if schedule.minutes, err = parseField(fields[0], minuteBounds); err != nil {
+		return nil, err
+	}
+	if schedule.hours, err = parseField(fields[1], hourBounds); err != nil {
+		return nil, err
+	}
+	if schedule.daysOfMonth, err = parseField(fields[2], dayOfMonthBounds); err != nil {
+		return nil, err
+	}
+	if schedule.months, err = parseField(fields[3], monthBounds); err != nil {
+		return nil, err
+	}
+	if schedule.daysOfWeek, err = parseField(fields[4], dayOfWeekBounds); err != nil {
+		return nil, err
+	}
+	if schedule.daysOfWeek&(1<<7) != 0 {
+		schedule.daysOfWeek |= 1 << 0
+	}
+	return schedule, nil
+}
+
+// Next returns the first activation of the schedule strictly after t, in the location of t.
+// The zero time is returned if the schedule never matches.
+func (s *Schedule) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(maxSearchYears, 0, 0)
+	for t.Before(limit) {
+		if !has(s.months, int(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !has(s.hours, t.Hour()) {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if !has(s.minutes, t.Minute()) {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (s *Schedule) matchesDay(t time.Time) bool {
+	dayOfMonth := has(s.daysOfMonth, t.Day())
+	dayOfWeek := has(s.daysOfWeek, int(t.Weekday()))
+	if s.dayOfMonthStar || s.dayOfWeekStar {
+		return dayOfMonth && dayOfWeek
+	}
+	return dayOfMonth || dayOfWeek
+}
+
+func has(set uint64, value int) bool {
+	return set&(1<<uint(value)) != 0
+}
+
+func parseField(field string, bounds fieldBounds) (uint64, error) {
+	var set uint64
+	for _, part := range strings.Split(field, ",") {
+		partSet, err := parseRange(part, bounds)
+		if err != nil {
+			return 0, fmt.Errorf("invalid %s %q: %w", bounds.name, field, err)
+		}
+		set |= partSet
+	}
+	return set, nil
+}
+
+func parseRange(part string, bounds fieldBounds) (uint64, error) {
+	rangePart, stepPart, hasStep := strings.Cut(part, "/")
+	step := 1
+	if hasStep {
+		var err error
+		step, err = strconv.Atoi(stepPart)
+		if err != nil || step <= 0 {
+			return 0, fmt.Errorf("invalid step %q", stepPart)
+		}
+	}
+
+	start, end := bounds.min, bounds.max
+	if rangePart != "*" {
+		startPart, endPart, isRange := strings.Cut(rangePart, "-")
+		var err error
+		if start, err = parseValue(startPart, bounds); err != nil {
+			return 0, err
+		}
+		end = start
+		if isRange {
+			if end, err = parseValue(endPart, bounds); err != nil {
+				return 0, err
+			}
+		} else if hasStep {
+			// a/n starts at a and goes up to the maximum
+			end = bounds.max
+		}
+		if start > end {
+			return 0, fmt.Errorf("invalid range %q", rangePart)
+		}
+	}
+
+	var set uint64
+	for value := start; value <= end; value += step {
+		set |= 1 << uint(value)
+	}
+	return set, nil
+}
+
+func parseValue(value string, bounds fieldBounds) (int, error) {
+	if v, ok := bounds.names[strings.ToLower(value)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", value)
+	}
+	if v < bounds.min || v > bounds.max {
+		return 0, fmt.Errorf("value %d out of range [%d, %d]", v, bounds.min, bounds.max)
+	}
+	return v, nil
+}
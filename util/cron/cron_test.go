@@ -0,0 +1,45 @@
+package cron
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cron schedule", func() {
+	// Monday
+	now := time.Date(2024, time.March, 4, 10, 30, 0, 0, time.UTC)
+
+	DescribeTable("Next",
+		func(expression string, expected time.Time) {
+			schedule, err := Parse(expression)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(schedule.Next(now)).To(Equal(expected))
+		},
+		Entry("every minute", "* * * * *", time.Date(2024, time.March, 4, 10, 31, 0, 0, time.UTC)),
+		Entry("later today", "0 22 * * *", time.Date(2024, time.March, 4, 22, 0, 0, 0, time.UTC)),
+		Entry("tomorrow", "0 2 * * *", time.Date(2024, time.March, 5, 2, 0, 0, 0, time.UTC)),
+		Entry("every 15 minutes", "*/15 * * * *", time.Date(2024, time.March, 4, 10, 45, 0, 0, time.UTC)),
+		Entry("weekends", "0 1 * * sat,sun", time.Date(2024, time.March, 9, 1, 0, 0, 0, time.UTC)),
+		Entry("Sunday as 7", "0 1 * * 7", time.Date(2024, time.March, 10, 1, 0, 0, 0, time.UTC)),
+		Entry("working days range", "0 20 * * MON-FRI", time.Date(2024, time.March, 4, 20, 0, 0, 0, time.UTC)),
+		Entry("first day of the month", "0 0 1 * *", time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC)),
+		Entry("day of month or day of week", "0 0 15 * fri", time.Date(2024, time.March, 8, 0, 0, 0, 0, time.UTC)),
+		Entry("next year", "0 0 1 jan *", time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)),
+		Entry("leap day", "0 0 29 2 *", time.Date(2028, time.February, 29, 0, 0, 0, 0, time.UTC)),
+		Entry("never", "0 0 30 2 *", time.Time{}),
+	)
+
+	DescribeTable("invalid expressions",
+		func(expression string) {
+			_, err := Parse(expression)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("missing fields", "0 0 * *"),
+		Entry("out of range", "60 * * * *"),
+		Entry("invalid name", "0 0 * * funday"),
+		Entry("invalid step", "*/0 * * * *"),
+		Entry("inverted range", "0 10-2 * * *"),
+	)
+})
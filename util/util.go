@@ -7,7 +7,7 @@ import (
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 
-	controlplanev1alpha1 "github.com/openshift-assisted/cluster-api-agent/controlplane/api/v1alpha2"
+	controlplanev1beta1 "github.com/openshift-assisted/cluster-api-agent/controlplane/api/v1beta1"
 	logutil "github.com/openshift-assisted/cluster-api-agent/util/log"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -42,7 +42,7 @@ func GetTypedOwner(ctx context.Context, k8sClient client.Client, obj client.Obje
 // ControlPlaneMachineLabelsForCluster returns a set of labels to add
 // to a control plane machine for this specific cluster.
 func ControlPlaneMachineLabelsForCluster(
-	acp *controlplanev1alpha1.OpenshiftAssistedControlPlane,
+	acp *controlplanev1beta1.OpenshiftAssistedControlPlane,
 	clusterName string,
 ) map[string]string {
 	labels := map[string]string{}